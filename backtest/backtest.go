@@ -0,0 +1,318 @@
+// Package backtest replays historical CLOB price series and Data API trade
+// prints through clob.OrderClient, so a strategy written for live or paper
+// trading runs unchanged against history.
+package backtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/clob"
+	clobtypes "github.com/override-coder/go-polymarket-sdk/clob/types"
+	datatypes "github.com/override-coder/go-polymarket-sdk/dataapi/types"
+	"github.com/override-coder/go-polymarket-sdk/internal/ledger"
+	"github.com/shopspring/decimal"
+)
+
+// PriceSource is the part of clob.Client price series are loaded from.
+type PriceSource interface {
+	GetPricesHistory(ctx context.Context, req clobtypes.PricesHistoryRequest) (*clobtypes.PricesHistory, error)
+}
+
+// TradeSource is the part of dataapi.Client trade prints are loaded from.
+type TradeSource interface {
+	GetTrades(ctx context.Context, q datatypes.TradesQuery) ([]datatypes.Trade, error)
+}
+
+// Market is one outcome token of a backtest.
+type Market struct {
+	TokenID     string
+	ConditionID string
+	// TickSize defaults to 0.01.
+	TickSize   clobtypes.TickSize
+	FeeRateBps float64
+	// ResolvedAt is when the market resolved, zero if it did not within the
+	// backtest. Each share held then pays Payout USDC: 1 for the winning
+	// outcome, 0 for the others.
+	ResolvedAt time.Time
+	Payout     float64
+}
+
+// Event kinds.
+const (
+	EventPrice      = "price"
+	EventTrade      = "trade"
+	EventResolution = "resolution"
+)
+
+// Event is one step of the replay. Size and Side are set for trade prints,
+// Side being the taker's.
+type Event struct {
+	Kind    string
+	Time    time.Time
+	TokenID string
+	Price   float64
+	Size    float64
+	Side    string
+}
+
+// Strategy reacts to each event, after resting orders were matched against
+// it, by trading through client.
+type Strategy interface {
+	OnEvent(ctx context.Context, ev Event, client clob.OrderClient) error
+}
+
+// StrategyFunc adapts a function to Strategy.
+type StrategyFunc func(ctx context.Context, ev Event, client clob.OrderClient) error
+
+func (f StrategyFunc) OnEvent(ctx context.Context, ev Event, client clob.OrderClient) error {
+	return f(ctx, ev, client)
+}
+
+type Options struct {
+	// Balance is the initial USDC balance.
+	Balance decimal.Decimal
+}
+
+// EquityPoint is the account value after an event.
+type EquityPoint struct {
+	Time      time.Time
+	Cash      decimal.Decimal
+	Positions decimal.Decimal // shares marked at the last price
+	Equity    decimal.Decimal
+}
+
+// MarketPnL is the result of one market. PnL is Sold + Payout + Position
+// marked at Mark - Bought - Fees.
+type MarketPnL struct {
+	TokenID     string
+	ConditionID string
+	Bought      decimal.Decimal // USDC paid for shares
+	Sold        decimal.Decimal // USDC received for shares
+	Fees        decimal.Decimal
+	Payout      decimal.Decimal
+	Position    decimal.Decimal // shares held at the end
+	Mark        decimal.Decimal // last price, or the payout once resolved
+	PnL         decimal.Decimal
+}
+
+// Fill is a simulated trade and the fee it paid in USDC.
+type Fill = ledger.Fill
+
+type Result struct {
+	Equity  []EquityPoint
+	Markets []MarketPnL // in AddMarket order
+	Fills   []Fill
+}
+
+// Engine runs strategies over the history of its markets. It implements
+// clob.OrderClient for the strategy while Run is in progress:
+//
+//   - An order crossing the last price fills in full at that price as taker.
+//   - A resting order fills at its own price, in full when a price point
+//     moves through it, or up to the print size on trade prints at or
+//     through it.
+//
+// Amounts are rounded like OrderBuilder rounds them for the market's tick
+// size, and FOK and FAK buys are sized in USDC.
+type Engine struct {
+	opts    Options
+	markets []*Market
+	events  []Event
+
+	// Replay state, reset by Run.
+	now      time.Time
+	ledger   *ledger.Ledger
+	last     map[string]decimal.Decimal
+	resolved map[string]bool
+	pnl      map[string]*MarketPnL
+}
+
+func New(opts Options) *Engine {
+	return &Engine{opts: opts, ledger: ledger.New("backtest", opts.Balance)}
+}
+
+func (e *Engine) AddMarket(m Market) {
+	if m.TickSize == "" {
+		m.TickSize = clobtypes.TickSize001
+	}
+	e.markets = append(e.markets, &m)
+}
+
+func (e *Engine) market(tokenID string) *Market {
+	for _, m := range e.markets {
+		if m.TokenID == tokenID {
+			return m
+		}
+	}
+	return nil
+}
+
+// AddPrices adds the price series of tokenID.
+func (e *Engine) AddPrices(tokenID string, points []clobtypes.PriceHistoryPoint) {
+	for _, p := range points {
+		e.events = append(e.events, Event{Kind: EventPrice, Time: time.Unix(p.T, 0), TokenID: tokenID, Price: p.P})
+	}
+}
+
+// AddTrades adds the trade prints of the backtest's markets among trades.
+func (e *Engine) AddTrades(trades []datatypes.Trade) {
+	for _, t := range trades {
+		if e.market(t.Asset) == nil {
+			continue
+		}
+		e.events = append(e.events, Event{
+			Kind:    EventTrade,
+			Time:    time.Unix(t.Timestamp, 0),
+			TokenID: t.Asset,
+			Price:   t.Price,
+			Size:    t.Size,
+			Side:    t.Side,
+		})
+	}
+}
+
+const (
+	// tradesPageSize is the page size used to load trade prints.
+	tradesPageSize = 500
+	// maxTradesOffset is the largest offset the data API pages trades from.
+	maxTradesOffset = 10000
+)
+
+// ErrTradesTruncated is returned by Load when the markets have more trade
+// prints than the data API pages through. The prints loaded are kept.
+var ErrTradesTruncated = errors.New("trade prints truncated at the data API offset limit")
+
+// Load adds the price series of every market from prices, and, if trades is
+// not nil, their trade prints between req.StartTs and req.EndTs. req.Market
+// is set per market. Only the first maxTradesOffset+tradesPageSize prints
+// can be loaded; Load returns ErrTradesTruncated when there are more.
+func (e *Engine) Load(ctx context.Context, prices PriceSource, trades TradeSource, req clobtypes.PricesHistoryRequest) error {
+	conditionIDs := []string{}
+	seen := map[string]bool{}
+	for _, m := range e.markets {
+		req.Market = m.TokenID
+		history, err := prices.GetPricesHistory(ctx, req)
+		if err != nil {
+			return fmt.Errorf("load prices of %s: %w", m.TokenID, err)
+		}
+		e.AddPrices(m.TokenID, history.History)
+		if m.ConditionID != "" && !seen[m.ConditionID] {
+			seen[m.ConditionID] = true
+			conditionIDs = append(conditionIDs, m.ConditionID)
+		}
+	}
+	if trades == nil || len(conditionIDs) == 0 {
+		return nil
+	}
+
+	limit := tradesPageSize
+	for offset := 0; ; offset += limit {
+		page, err := trades.GetTrades(ctx, datatypes.TradesQuery{Market: conditionIDs, Limit: &limit, Offset: &offset})
+		if err != nil {
+			return fmt.Errorf("load trades: %w", err)
+		}
+		var kept []datatypes.Trade
+		for _, t := range page {
+			if (req.StartTs == nil || t.Timestamp >= *req.StartTs) && (req.EndTs == nil || t.Timestamp <= *req.EndTs) {
+				kept = append(kept, t)
+			}
+		}
+		e.AddTrades(kept)
+		if len(page) < limit {
+			return nil
+		}
+		if offset+limit > maxTradesOffset {
+			return fmt.Errorf("load trades: %w after %d prints", ErrTradesTruncated, offset+len(page))
+		}
+	}
+}
+
+// Run replays the events in time order through s and returns the result.
+// Each run starts from Options.Balance with no orders.
+func (e *Engine) Run(ctx context.Context, s Strategy) (*Result, error) {
+	e.ledger = ledger.New("backtest", e.opts.Balance)
+	e.last = make(map[string]decimal.Decimal)
+	e.resolved = make(map[string]bool)
+	e.pnl = make(map[string]*MarketPnL)
+	for _, m := range e.markets {
+		e.pnl[m.TokenID] = &MarketPnL{TokenID: m.TokenID, ConditionID: m.ConditionID}
+	}
+
+	events := append([]Event{}, e.events...)
+	for _, m := range e.markets {
+		if !m.ResolvedAt.IsZero() {
+			events = append(events, Event{Kind: EventResolution, Time: m.ResolvedAt, TokenID: m.TokenID, Price: m.Payout})
+		}
+	}
+	// Resolutions go last among events at the same time.
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Time.Equal(events[j].Time) {
+			return events[i].Time.Before(events[j].Time)
+		}
+		return events[i].Kind != EventResolution && events[j].Kind == EventResolution
+	})
+
+	result := &Result{}
+	for _, ev := range events {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if e.resolved[ev.TokenID] {
+			continue
+		}
+		e.now = ev.Time
+		switch ev.Kind {
+		case EventPrice:
+			e.last[ev.TokenID] = decimal.NewFromFloat(ev.Price)
+			e.matchPrice(ev)
+		case EventTrade:
+			e.matchTrade(ev)
+			e.last[ev.TokenID] = decimal.NewFromFloat(ev.Price)
+		case EventResolution:
+			e.resolve(ev)
+		}
+		if err := s.OnEvent(ctx, ev, e); err != nil {
+			return nil, fmt.Errorf("strategy at %s %s %s: %w", ev.Time.UTC().Format(time.RFC3339), ev.Kind, ev.TokenID, err)
+		}
+		result.Equity = append(result.Equity, e.equity())
+	}
+
+	for _, m := range e.markets {
+		pnl := e.pnl[m.TokenID]
+		pnl.Position = e.ledger.Positions[m.TokenID]
+		pnl.Mark = e.last[m.TokenID]
+		pnl.PnL = pnl.Sold.Add(pnl.Payout).Add(pnl.Position.Mul(pnl.Mark)).Sub(pnl.Bought).Sub(pnl.Fees)
+		result.Markets = append(result.Markets, *pnl)
+	}
+	result.Fills = append([]Fill{}, e.ledger.Fills...)
+	return result, nil
+}
+
+func (e *Engine) equity() EquityPoint {
+	value := decimal.Zero
+	for tokenID, size := range e.ledger.Positions {
+		value = value.Add(size.Mul(e.last[tokenID]))
+	}
+	cash := e.ledger.Cash
+	return EquityPoint{Time: e.now, Cash: cash, Positions: value, Equity: cash.Add(value)}
+}
+
+// resolve cancels the orders of the token and pays out its shares.
+func (e *Engine) resolve(ev Event) {
+	for _, o := range e.ledger.Orders {
+		if o.Status == ledger.StatusLive && o.TokenID == ev.TokenID {
+			o.Status = ledger.StatusCanceled
+		}
+	}
+	payout := decimal.NewFromFloat(ev.Price)
+	amount := e.ledger.Positions[ev.TokenID].Mul(payout)
+	e.ledger.Cash = e.ledger.Cash.Add(amount)
+	e.pnl[ev.TokenID].Payout = e.pnl[ev.TokenID].Payout.Add(amount)
+	e.ledger.Positions[ev.TokenID] = decimal.Zero
+	e.last[ev.TokenID] = payout
+	e.resolved[ev.TokenID] = true
+}
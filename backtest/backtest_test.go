@@ -0,0 +1,151 @@
+package backtest_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/backtest"
+	"github.com/override-coder/go-polymarket-sdk/clob"
+	"github.com/override-coder/go-polymarket-sdk/clob/clobtest"
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/dataapi"
+	datatypes "github.com/override-coder/go-polymarket-sdk/dataapi/types"
+	"github.com/override-coder/go-polymarket-sdk/gamma/gammatest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	yes         = "1001"
+	no          = "1002"
+	conditionID = "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+)
+
+var chainId = big.NewInt(137)
+
+func newEngine(t *testing.T) *backtest.Engine {
+	clobServer := clobtest.NewServer(chainId, clobtest.Options{})
+	t.Cleanup(clobServer.Close)
+	clobServer.SetPricesHistory(yes, []types.PriceHistoryPoint{{T: 1000, P: 0.4}, {T: 2000, P: 0.45}, {T: 3000, P: 0.38}, {T: 4000, P: 0.6}})
+	clobServer.SetPricesHistory(no, []types.PriceHistoryPoint{{T: 1000, P: 0.6}})
+	dataServer, err := gammatest.NewDataServer(os.DirFS("testdata"))
+	assert.NoError(t, err)
+	t.Cleanup(dataServer.Close)
+
+	e := backtest.New(backtest.Options{Balance: decimal.NewFromInt(100)})
+	e.AddMarket(backtest.Market{TokenID: yes, ConditionID: conditionID, FeeRateBps: 100, ResolvedAt: time.Unix(5000, 0), Payout: 1})
+	e.AddMarket(backtest.Market{TokenID: no, ConditionID: conditionID, ResolvedAt: time.Unix(5000, 0)})
+	start, end := int64(0), int64(6000)
+	err = e.Load(context.Background(), clob.NewClient(clobServer.URL, chainId, nil, nil), dataapi.NewClient(dataServer.URL, chainId),
+		types.PricesHistoryRequest{StartTs: &start, EndTs: &end})
+	assert.NoError(t, err)
+	return e
+}
+
+func TestRun(t *testing.T) {
+	e := newEngine(t)
+	order := func(side types.Side, price, size float64) types.UserOrder {
+		return types.UserOrder{TokenID: yes, Side: side, Price: price, Size: size}
+	}
+
+	var kinds []string
+	result, err := e.Run(context.Background(), backtest.StrategyFunc(func(ctx context.Context, ev backtest.Event, client clob.OrderClient) error {
+		if ev.TokenID != yes {
+			return nil
+		}
+		kinds = append(kinds, ev.Kind)
+		switch {
+		case ev.Kind == backtest.EventPrice && ev.Time.Unix() == 1000:
+			// 10 USDC at 0.40 buys 25 shares.
+			resp, err := client.CreateOrder(ctx, order(types.BUY, 0.41, 10), types.OrderTypeFOK, false, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, "25", resp.TakingAmount)
+			resp, err = client.CreateOrder(ctx, order(types.BUY, 0.39, 20), types.OrderTypeGTC, false, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, "live", resp.Status)
+			_, err = client.CreateOrder(ctx, order(types.BUY, 0.385, 20), types.OrderTypeGTC, false, nil)
+			assert.ErrorContains(t, err, "tick size")
+			_, err = client.CreateOrder(ctx, order(types.BUY, 0.3, 300), types.OrderTypeGTC, false, nil)
+			assert.ErrorContains(t, err, "not enough balance")
+		case ev.Kind == backtest.EventTrade && ev.Time.Unix() == 2600:
+			// A taker buying at the bid's price does not fill it.
+			orders, err := client.GetOrders(ctx, types.GetActiveOrdersRequest{}, nil)
+			assert.NoError(t, err)
+			if assert.Len(t, orders.Data, 1) {
+				assert.Equal(t, "12", orders.Data[0].SizeMatched)
+			}
+		case ev.Kind == backtest.EventPrice && ev.Time.Unix() == 3000:
+			_, err := client.CreateOrder(ctx, order(types.SELL, 0.55, 10), types.OrderTypeGTC, false, nil)
+			assert.NoError(t, err)
+		case ev.Kind == backtest.EventResolution:
+			_, err := client.CreateOrder(ctx, order(types.BUY, 0.5, 10), types.OrderTypeGTC, false, nil)
+			assert.ErrorContains(t, err, "resolved")
+		}
+		return nil
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"price", "price", "trade", "trade", "price", "price", "resolution"}, kinds, "prints outside the range or of other markets are dropped")
+
+	if assert.Len(t, result.Fills, 4) {
+		assert.Equal(t, "TAKER", result.Fills[0].TraderSide)
+		assert.Equal(t, "0.1", result.Fills[0].Fee.String())
+		assert.Equal(t, []string{"12", "8", "10"}, []string{result.Fills[1].Size, result.Fills[2].Size, result.Fills[3].Size})
+		assert.Equal(t, "MAKER", result.Fills[3].TraderSide)
+	}
+	final := result.Equity[len(result.Equity)-1]
+	assert.Equal(t, "122.6", final.Equity.String())
+	assert.True(t, final.Positions.IsZero())
+	if assert.Len(t, result.Markets, 2) {
+		assert.Equal(t, "17.8", result.Markets[0].Bought.String())
+		assert.Equal(t, "5.5", result.Markets[0].Sold.String())
+		assert.Equal(t, "35", result.Markets[0].Payout.String())
+		assert.Equal(t, "22.6", result.Markets[0].PnL.String())
+		assert.True(t, result.Markets[1].PnL.IsZero())
+	}
+
+	// Runs are independent.
+	again, err := e.Run(context.Background(), backtest.StrategyFunc(func(context.Context, backtest.Event, clob.OrderClient) error { return nil }))
+	assert.NoError(t, err)
+	assert.Empty(t, again.Fills)
+	assert.Equal(t, "100", again.Equity[len(again.Equity)-1].Equity.String())
+}
+
+type prices struct{}
+
+func (prices) GetPricesHistory(ctx context.Context, req types.PricesHistoryRequest) (*types.PricesHistory, error) {
+	return &types.PricesHistory{}, nil
+}
+
+// endlessTrades returns full pages of prints at any offset the data API takes.
+type endlessTrades struct{ offsets []int }
+
+func (s *endlessTrades) GetTrades(ctx context.Context, q datatypes.TradesQuery) ([]datatypes.Trade, error) {
+	if *q.Offset > 10000 {
+		return nil, fmt.Errorf("offset out of range (0..10000)")
+	}
+	s.offsets = append(s.offsets, *q.Offset)
+	page := make([]datatypes.Trade, *q.Limit)
+	for i := range page {
+		page[i] = datatypes.Trade{Asset: yes, Side: "BUY", Price: 0.5, Size: 1, Timestamp: 1000}
+	}
+	return page, nil
+}
+
+func TestLoadTruncated(t *testing.T) {
+	e := backtest.New(backtest.Options{Balance: decimal.NewFromInt(100)})
+	e.AddMarket(backtest.Market{TokenID: yes, ConditionID: conditionID})
+	trades := &endlessTrades{}
+	err := e.Load(context.Background(), prices{}, trades, types.PricesHistoryRequest{})
+	assert.ErrorIs(t, err, backtest.ErrTradesTruncated)
+	assert.Equal(t, 10000, trades.offsets[len(trades.offsets)-1])
+
+	result, err := e.Run(context.Background(), backtest.StrategyFunc(func(ctx context.Context, ev backtest.Event, client clob.OrderClient) error {
+		return nil
+	}))
+	assert.NoError(t, err)
+	assert.Len(t, result.Equity, 10500)
+}
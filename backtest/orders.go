@@ -0,0 +1,241 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/override-coder/go-polymarket-sdk/clob"
+	clobtypes "github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/internal/ledger"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/override-coder/go-polymarket-sdk/types/utils"
+	"github.com/shopspring/decimal"
+)
+
+var _ clob.OrderClient = (*Engine)(nil)
+
+// rawAmounts returns the shares and USDC of userOrder as OrderBuilder rounds
+// them.
+func rawAmounts(userOrder clobtypes.UserOrder, orderType clobtypes.OrderType, tickSize clobtypes.TickSize) (decimal.Decimal, decimal.Decimal, error) {
+	makerAmt, takerAmt, err := clob.RawAmounts(userOrder, orderType, tickSize)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	if userOrder.Side == clobtypes.BUY {
+		return decimal.NewFromFloat(takerAmt), decimal.NewFromFloat(makerAmt), nil
+	}
+	return decimal.NewFromFloat(makerAmt), decimal.NewFromFloat(takerAmt), nil
+}
+
+// limitAmounts returns the shares and USDC of a limit order of size at price.
+func limitAmounts(side clobtypes.Side, size, price decimal.Decimal, tickSize clobtypes.TickSize) (decimal.Decimal, decimal.Decimal) {
+	shares, cash, _ := rawAmounts(clobtypes.UserOrder{Side: side, Size: size.InexactFloat64(), Price: price.InexactFloat64()}, clobtypes.OrderTypeGTC, tickSize)
+	return shares, cash
+}
+
+// snap rounds price to the nearest tick within [tick, 1-tick].
+func snap(price decimal.Decimal, tickSize clobtypes.TickSize) decimal.Decimal {
+	tick := decimal.RequireFromString(string(tickSize))
+	snapped := price.Div(tick).Round(0).Mul(tick)
+	return decimal.Min(decimal.Max(snapped, tick), decimal.NewFromInt(1).Sub(tick))
+}
+
+// reserved returns the USDC and shares of tokenID locked by resting orders.
+func (e *Engine) reserved(tokenID string) (decimal.Decimal, decimal.Decimal) {
+	usdc, shares := decimal.Zero, decimal.Zero
+	for _, o := range e.ledger.Orders {
+		if o.Status != ledger.StatusLive {
+			continue
+		}
+		if o.Side == clobtypes.BUY {
+			_, cash := limitAmounts(o.Side, o.Remaining(), o.Price, o.TickSize)
+			usdc = usdc.Add(cash)
+		} else if o.TokenID == tokenID {
+			shares = shares.Add(o.Remaining())
+		}
+	}
+	return usdc, shares
+}
+
+// CreateOrder simulates userOrder against the last price of its token. FOK
+// and FAK buys are sized in USDC, other orders in shares.
+func (e *Engine) CreateOrder(ctx context.Context, userOrder clobtypes.UserOrder, orderType clobtypes.OrderType, deferExec bool, option *sdktypes.AuthOption) (*clobtypes.OrderResponse, error) {
+	m := e.market(userOrder.TokenID)
+	if m == nil {
+		return nil, fmt.Errorf("invalid token id %s", userOrder.TokenID)
+	}
+	if e.resolved[m.TokenID] {
+		return nil, fmt.Errorf("market %s is resolved", m.TokenID)
+	}
+	last, ok := e.last[m.TokenID]
+	if !ok {
+		return nil, fmt.Errorf("no price for token %s yet", m.TokenID)
+	}
+	if userOrder.Side != clobtypes.BUY && userOrder.Side != clobtypes.SELL {
+		return nil, fmt.Errorf("invalid side %q", userOrder.Side)
+	}
+	if userOrder.Size <= 0 {
+		return nil, fmt.Errorf("invalid size %v", userOrder.Size)
+	}
+
+	tick := decimal.RequireFromString(string(m.TickSize))
+	userOrder.Price = utils.NormalizePrice(userOrder.Price, tick.InexactFloat64())
+	price := decimal.NewFromFloat(userOrder.Price)
+	if !price.Mod(tick).IsZero() {
+		return nil, fmt.Errorf("invalid price (%s), breaks minimum tick size rule: %s", price, tick)
+	}
+	expiration, err := ledger.Expiration(userOrder, orderType, e.now)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &ledger.Order{
+		Status:     ledger.StatusLive,
+		TokenID:    m.TokenID,
+		Market:     m.ConditionID,
+		TickSize:   m.TickSize,
+		Side:       userOrder.Side,
+		Price:      price,
+		OrderType:  orderType,
+		Expiration: expiration,
+		FeeRateBps: decimal.NewFromFloat(m.FeeRateBps),
+		CreatedAt:  e.now,
+	}
+	o.SetAuth(option)
+
+	reservedUSDC, reservedShares := e.reserved(m.TokenID)
+	available := e.ledger.Cash.Sub(reservedUSDC)
+	held := e.ledger.Positions[m.TokenID].Sub(reservedShares)
+
+	if !ledger.Crosses(o.Side, price, last) {
+		switch orderType {
+		case clobtypes.OrderTypeFOK:
+			return nil, fmt.Errorf("order couldn't be fully filled. FOK orders are fully filled or killed.")
+		case clobtypes.OrderTypeFAK:
+			return nil, fmt.Errorf("no orders found to match with FAK order. FAK orders are partially filled or killed if no match is found.")
+		}
+		shares, cash, err := rawAmounts(userOrder, orderType, m.TickSize)
+		if err != nil {
+			return nil, err
+		}
+		if !shares.IsPositive() {
+			return nil, fmt.Errorf("invalid amounts, maker and taker amounts must be positive")
+		}
+		if o.Side == clobtypes.BUY && cash.GreaterThan(available) {
+			return nil, fmt.Errorf("not enough balance / allowance: need %s USDC, available %s", cash, available)
+		}
+		if o.Side == clobtypes.SELL && shares.GreaterThan(held) {
+			return nil, fmt.Errorf("not enough balance / allowance: need %s shares, available %s", shares, held)
+		}
+		o.Size = shares
+		e.ledger.Add(o)
+		return &clobtypes.OrderResponse{Success: true, OrderID: o.ID, Status: "live", TransactionsHashes: []string{}, MakingAmount: "0", TakingAmount: "0"}, nil
+	}
+
+	// Marketable: the whole order trades at the last price.
+	fillPrice := snap(last, m.TickSize)
+	filled := userOrder
+	filled.Price = fillPrice.InexactFloat64()
+	shares, cash, err := rawAmounts(filled, orderType, m.TickSize)
+	if err != nil {
+		return nil, err
+	}
+	if !shares.IsPositive() {
+		return nil, fmt.Errorf("invalid amounts, maker and taker amounts must be positive")
+	}
+	paid := ledger.Fee(o.FeeRateBps, fillPrice, shares)
+	if o.Side == clobtypes.BUY && cash.Add(paid).GreaterThan(available) {
+		return nil, fmt.Errorf("not enough balance / allowance: need %s USDC, available %s", cash.Add(paid), available)
+	}
+	if o.Side == clobtypes.SELL && shares.GreaterThan(held) {
+		return nil, fmt.Errorf("not enough balance / allowance: need %s shares, available %s", shares, held)
+	}
+	o.Size = shares
+	e.ledger.Add(o)
+	e.fill(o, fillPrice, shares, cash, true)
+
+	resp := &clobtypes.OrderResponse{Success: true, OrderID: o.ID, Status: "matched", TransactionsHashes: []string{}}
+	if o.Side == clobtypes.BUY {
+		resp.MakingAmount, resp.TakingAmount = cash.String(), shares.String()
+	} else {
+		resp.MakingAmount, resp.TakingAmount = shares.String(), cash.String()
+	}
+	return resp, nil
+}
+
+// matchPrice fills the resting orders a price point moved through.
+func (e *Engine) matchPrice(ev Event) {
+	price := decimal.NewFromFloat(ev.Price)
+	for _, o := range e.ledger.Live(ev.TokenID, e.now) {
+		if ledger.Crosses(o.Side, o.Price, price) && !price.Equal(o.Price) {
+			shares, cash := limitAmounts(o.Side, o.Remaining(), o.Price, o.TickSize)
+			e.fill(o, o.Price, shares, cash, false)
+		}
+	}
+}
+
+// matchTrade fills resting orders from a trade print through their price, or
+// at their price against a taker on the other side, up to the print size.
+func (e *Engine) matchTrade(ev Event) {
+	price := decimal.NewFromFloat(ev.Price)
+	left := decimal.NewFromFloat(ev.Size)
+	for _, o := range e.ledger.Live(ev.TokenID, e.now) {
+		if !left.IsPositive() {
+			return
+		}
+		if !ledger.Crosses(o.Side, o.Price, price) || (price.Equal(o.Price) && ev.Side == string(o.Side)) {
+			continue
+		}
+		shares, cash := limitAmounts(o.Side, decimal.Min(left, o.Remaining()), o.Price, o.TickSize)
+		if !shares.IsPositive() {
+			continue
+		}
+		e.fill(o, o.Price, shares, cash, false)
+		left = left.Sub(shares)
+	}
+}
+
+// fill settles a fill of o for shares and cash USDC in the ledger and adds
+// it to the PnL of the token.
+func (e *Engine) fill(o *ledger.Order, price, shares, cash decimal.Decimal, taker bool) {
+	paid := e.ledger.Settle(o, price, shares, cash, taker, e.now)
+	pnl := e.pnl[o.TokenID]
+	pnl.Fees = pnl.Fees.Add(paid)
+	if o.Side == clobtypes.BUY {
+		pnl.Bought = pnl.Bought.Add(cash)
+	} else {
+		pnl.Sold = pnl.Sold.Add(cash)
+	}
+}
+
+func (e *Engine) GetOrders(ctx context.Context, req clobtypes.GetActiveOrdersRequest, option *sdktypes.AuthOption) (*clobtypes.OpenOrders, error) {
+	return e.ledger.GetOrders(req), nil
+}
+
+func (e *Engine) GetTrades(ctx context.Context, req clobtypes.GetTradesRequest, option *sdktypes.AuthOption) (*clobtypes.Trades, error) {
+	return e.ledger.GetTrades(req), nil
+}
+
+// GetBalanceAllowance returns the USDC balance in base units. Backtests have
+// no allowances, so they are all unlimited.
+func (e *Engine) GetBalanceAllowance(option *sdktypes.AuthOption) (*clobtypes.BalanceAllowanceResponse, error) {
+	return e.ledger.BalanceAllowance(), nil
+}
+
+func (e *Engine) CancelOrder(ctx context.Context, orderId string, option *sdktypes.AuthOption) (*clobtypes.CancelOrder, error) {
+	if orderId == "" {
+		return nil, fmt.Errorf("order id is empty")
+	}
+	return e.ledger.Cancel([]string{orderId}), nil
+}
+
+func (e *Engine) CancelOrders(ctx context.Context, orderId []string, option *sdktypes.AuthOption) (*clobtypes.CancelOrder, error) {
+	if len(orderId) == 0 {
+		return nil, fmt.Errorf("orderId is empty")
+	}
+	return e.ledger.Cancel(orderId), nil
+}
+
+func (e *Engine) CancelOrderAll(ctx context.Context, option *sdktypes.AuthOption) (*clobtypes.CancelOrder, error) {
+	return e.ledger.Cancel(e.ledger.LiveIDs()), nil
+}
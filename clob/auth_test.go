@@ -1,95 +1,124 @@
 package clob_test
 
 import (
-	"github.com/ethereum/go-ethereum/common"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/override-coder/go-polymarket-sdk/clob"
+	"github.com/override-coder/go-polymarket-sdk/clob/clobtest"
 	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/signing"
 	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
 	"github.com/polymarket/go-order-utils/pkg/model"
 	"github.com/stretchr/testify/assert"
-	"math/big"
-	"testing"
-	"time"
 )
 
 var (
-	PolymarketClobURL = "https://clob.polymarket.com"
-	chaindId          = big.NewInt(137)
-	privateKey, _     = crypto.ToECDSA(common.Hex2Bytes(""))
+	chaindId = big.NewInt(137)
+	tokenID  = "108743709732442130739073851488597967747030701044009651663118921104082786836017"
 )
 
-func signature(signer string, digest []byte) ([]byte, error) {
-	sig, err := crypto.Sign(digest, privateKey)
-	if err != nil {
-		return nil, err
+func newServer(t *testing.T) (*clobtest.Server, *clob.Client, *sdktypes.AuthOption) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	signer := signing.NewPrivateKeySigner(key)
+
+	server := clobtest.NewServer(chaindId, clobtest.Options{})
+	t.Cleanup(server.Close)
+	server.AddMarket(clobtest.Market{TokenID: tokenID, ConditionID: "0xc0", Outcome: "Yes"})
+
+	client := clob.NewClient(server.URL, chaindId, signing.ToSignatureFunc(signer), nil)
+	return server, client, &sdktypes.AuthOption{
+		SignatureType: model.EOA,
+		SingerAddress: signer.Address().Hex(),
 	}
-	sig[64] += 27
-	return sig, nil
 }
 
 func TestClient(t *testing.T) {
-	client := clob.NewClient(PolymarketClobURL, chaindId, signature, nil)
-	key, err := client.DeriveAPIKey(big.NewInt(1), &sdktypes.AuthOption{
-		SignatureType: model.POLY_GNOSIS_SAFE,
-		SingerAddress: "0x8c5f23249462e20C4a202Ad35275562075F37e09",
-		FunderAddress: "0x3BfD9C49E5B62cBc4b7DcE1b7a1f8123B515D278",
-	})
-	assert.Nil(t, err)
-	t.Logf("%+v", key)
+	_, client, authOption := newServer(t)
+	ctx := context.Background()
+
+	_, err := client.DeriveAPIKey(ctx, big.NewInt(1), authOption)
+	assert.Error(t, err)
+
+	created, err := client.CreateApiKey(ctx, big.NewInt(1), authOption)
+	assert.NoError(t, err)
+	derived, err := client.DeriveAPIKey(ctx, big.NewInt(1), authOption)
+	assert.NoError(t, err)
+	assert.Equal(t, created, derived)
 }
 
 func TestGetTickSize(t *testing.T) {
-	client := clob.NewClient(PolymarketClobURL, chaindId, signature, nil)
-	tokenID := "108743709732442130739073851488597967747030701044009651663118921104082786836017"
-	size, err := client.GetTickSize(tokenID)
-	assert.Nil(t, err)
-	t.Logf("%+v", size)
-
-	risk, err := client.GetOrderBook(tokenID)
-	assert.Nil(t, err)
-	t.Logf("%+v", risk)
-
-	rateBps, err := client.GetMarketPrice(tokenID, "BUY")
-	assert.Nil(t, err)
-	t.Logf("%+v", rateBps)
+	_, client, _ := newServer(t)
+	ctx := context.Background()
+
+	size, err := client.GetTickSize(ctx, tokenID)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.01", size)
+
+	book, err := client.GetOrderBook(tokenID)
+	assert.NoError(t, err)
+	assert.Equal(t, tokenID, book.AssetID)
+
+	_, err = client.GetTickSize(ctx, "1")
+	assert.Error(t, err)
 }
 
 func TestPostOrder(t *testing.T) {
-	client := clob.NewClient(PolymarketClobURL, chaindId, signature, &sdktypes.BuilderApiKeyCreds{
-		Key:        "019a4dec-fc6a-79ba-8937-d9bf3c2792ca",
-		Secret:     "Q23ZHyR21V5_F8qVLvOvnXGhxtW6CmNCWDjHzFJQW7k=",
-		Passphrase: "2a171196ddfe34aab62eea32ed63fe424fde8144413982dd90527c844cf2e8d3",
-	})
-
-	authOption := &sdktypes.AuthOption{
-		SignatureType: model.POLY_GNOSIS_SAFE,
-		SingerAddress: "0x8c5f23249462e20C4a202Ad35275562075F37e09",
-		FunderAddress: "0x3BfD9C49E5B62cBc4b7DcE1b7a1f8123B515D278",
-	}
-	_, err := client.EnsureAPIKey(big.NewInt(0), authOption)
-	assert.Nil(t, err)
+	_, client, authOption := newServer(t)
+	ctx := context.Background()
 
-	orders, err := client.GetOrders(types.GetActiveOrdersRequest{}, authOption)
-	assert.Nil(t, err)
+	_, err := client.EnsureAPIKey(ctx, big.NewInt(0), authOption)
+	assert.NoError(t, err)
 
-	if len(orders.Data) > 0 {
-		getOrder, err := client.GetOrder(orders.Data[0].ID, types.GetOrderRequest{}, authOption)
-		assert.Nil(t, err)
-		t.Logf("%+v", getOrder)
+	expiration := time.Now().Add(2 * time.Minute).Unix()
+	userOrder := types.UserOrder{
+		TokenID:    tokenID,
+		Price:      0.01,
+		Size:       100,
+		Side:       types.BUY,
+		Expiration: &expiration,
+	}
+	order, err := client.CreateOrder(ctx, userOrder, types.OrderTypeGTD, false, authOption)
+	assert.NoError(t, err)
+	assert.Equal(t, "live", order.Status)
+
+	orders, err := client.GetOrders(ctx, types.GetActiveOrdersRequest{}, authOption)
+	assert.NoError(t, err)
+	if assert.Len(t, orders.Data, 1) {
+		getOrder, err := client.GetOrder(ctx, orders.Data[0].ID, types.GetOrderRequest{}, authOption)
+		assert.NoError(t, err)
+		assert.Equal(t, order.OrderID, getOrder.ID)
+		assert.Equal(t, "100", getOrder.OriginalSize)
 	}
+}
+
+func TestBuildOrder(t *testing.T) {
+	_, client, authOption := newServer(t)
+	ctx := context.Background()
+
+	_, err := client.EnsureAPIKey(ctx, big.NewInt(0), authOption)
+	assert.NoError(t, err)
 
-	return
 	expiration := time.Now().Add(2 * time.Minute).Unix()
 	userOrder := types.UserOrder{
-		TokenID:    "29932229206038996544221694126815434341861961592336413071656609906503218641045",
+		TokenID:    tokenID,
 		Price:      0.01,
 		Size:       100,
 		Side:       types.BUY,
 		Expiration: &expiration,
 	}
+	payload, err := client.BuildOrder(ctx, userOrder, types.OrderTypeGTD, false, authOption)
+	assert.NoError(t, err)
+	assert.Equal(t, authOption.SingerAddress, payload.Order.Signer)
+	assert.Equal(t, "1000000", payload.Order.MakerAmount)
+	assert.NotEmpty(t, payload.Owner)
 
-	order, err := client.CreateOrder(userOrder, types.OrderTypeGTD, false, authOption)
-	assert.Nil(t, err)
-	t.Logf("%+v", order)
+	// Building does not post the order.
+	orders, err := client.GetOrders(ctx, types.GetActiveOrdersRequest{}, authOption)
+	assert.NoError(t, err)
+	assert.Empty(t, orders.Data)
 }
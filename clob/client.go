@@ -28,6 +28,20 @@ type Client struct {
 	feeRates  types.FeeRates
 }
 
+// OrderClient is the trading surface of Client. paper.Client implements it
+// with simulated fills, so strategies can switch between live and paper
+// trading.
+type OrderClient interface {
+	CreateOrder(ctx context.Context, userOrder types.UserOrder, orderType types.OrderType, deferExec bool, option *sdktypes.AuthOption) (*types.OrderResponse, error)
+	CancelOrder(ctx context.Context, orderId string, option *sdktypes.AuthOption) (*types.CancelOrder, error)
+	CancelOrders(ctx context.Context, orderId []string, option *sdktypes.AuthOption) (*types.CancelOrder, error)
+	GetOrders(ctx context.Context, req types.GetActiveOrdersRequest, option *sdktypes.AuthOption) (*types.OpenOrders, error)
+	GetTrades(ctx context.Context, req types.GetTradesRequest, option *sdktypes.AuthOption) (*types.Trades, error)
+	GetBalanceAllowance(option *sdktypes.AuthOption) (*types.BalanceAllowanceResponse, error)
+}
+
+var _ OrderClient = (*Client)(nil)
+
 func NewClient(host string, chainId *big.Int, signFn signing.SignatureFunc, builderApiKeyCreds *sdktypes.BuilderApiKeyCreds) *Client {
 	if strings.HasSuffix(host, "/") {
 		host = host[:len(host)-1]
@@ -52,6 +66,13 @@ func (c *Client) WithSignatureFunc(signFn signing.SignatureFunc) error {
 	return c.orderBuilder.WithSignatureFunc(signFn)
 }
 
+// WithTransport routes the client's requests through rt, e.g. an
+// http.Recorder or http.Replayer.
+func (c *Client) WithTransport(rt http.RoundTripper) error {
+	c.client.SetTransport(rt)
+	return nil
+}
+
 func (c *Client) GetTickSize(ctx context.Context, tokenID string) (string, error) {
 	if size, ok := c.tickSizes[tokenID]; ok {
 		return string(size), nil
@@ -164,3 +185,61 @@ func (c *Client) GetMarketPrices(ctx context.Context, prices []types.PricesReque
 	}
 	return out, nil
 }
+
+func (c *Client) GetMidpoint(ctx context.Context, tokenID string) (string, error) {
+	var resp map[string]string
+	res, err := c.client.DoRequest(ctx, http.MethodGet, types.GET_MIDPOINT, &http2.RequestOptions{
+		Params: map[string]any{"token_id": tokenID},
+	}, &resp)
+	if _, e := http2.ParseHTTPError(res, err); e != nil {
+		return "", errors.Wrap(e, "get midpoint")
+	}
+	return resp["mid"], nil
+}
+
+// GetMidpoints returns the midpoint of each token keyed by token ID.
+func (c *Client) GetMidpoints(ctx context.Context, tokenIDs []string) (map[string]string, error) {
+	params := make([]types.BookParams, 0, len(tokenIDs))
+	for _, id := range tokenIDs {
+		params = append(params, types.BookParams{TokenId: id})
+	}
+	resp := make(map[string]string, len(tokenIDs))
+	res, err := c.client.DoRequest(ctx, http.MethodPost, types.GET_MIDPOINTS, &http2.RequestOptions{
+		Data: params,
+	}, &resp)
+	if _, e := http2.ParseHTTPError(res, err); e != nil {
+		return nil, errors.Wrap(e, "get midpoints")
+	}
+	return resp, nil
+}
+
+// GetPricesHistory returns the price series of req.Market, oldest first.
+func (c *Client) GetPricesHistory(ctx context.Context, req types.PricesHistoryRequest) (*types.PricesHistory, error) {
+	if req.Market == "" {
+		return nil, errors.New("market is empty")
+	}
+	if req.Interval != nil && (req.StartTs != nil || req.EndTs != nil) {
+		return nil, errors.New("interval and startTs/endTs are mutually exclusive")
+	}
+	params := map[string]any{"market": req.Market}
+	if req.StartTs != nil {
+		params["startTs"] = *req.StartTs
+	}
+	if req.EndTs != nil {
+		params["endTs"] = *req.EndTs
+	}
+	if req.Interval != nil {
+		params["interval"] = *req.Interval
+	}
+	if req.Fidelity != nil {
+		params["fidelity"] = *req.Fidelity
+	}
+	var resp types.PricesHistory
+	res, err := c.client.DoRequest(ctx, http.MethodGet, types.GET_PRICES_HISTORY, &http2.RequestOptions{
+		Params: params,
+	}, &resp)
+	if _, e := http2.ParseHTTPError(res, err); e != nil {
+		return nil, errors.Wrap(e, "get prices history")
+	}
+	return &resp, nil
+}
@@ -0,0 +1,167 @@
+package clobtest
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	statusLive     = "LIVE"
+	statusMatched  = "MATCHED"
+	statusCanceled = "CANCELED"
+)
+
+// order is a resting or just submitted order. Sizes are in shares.
+type order struct {
+	id         string
+	status     string
+	owner      string // API key
+	maker      string
+	tokenID    string
+	market     string
+	outcome    string
+	side       types.Side
+	price      decimal.Decimal
+	size       decimal.Decimal
+	matched    decimal.Decimal
+	orderType  types.OrderType
+	expiration int64
+	feeRateBps string
+	createdAt  time.Time
+	seq        int64
+	trades     []string
+}
+
+func (o *order) remaining() decimal.Decimal {
+	return o.size.Sub(o.matched)
+}
+
+func (o *order) crosses(maker *order) bool {
+	if o.side == types.BUY {
+		return maker.price.LessThanOrEqual(o.price)
+	}
+	return maker.price.GreaterThanOrEqual(o.price)
+}
+
+func (o *order) openOrder() types.OpenOrder {
+	return types.OpenOrder{
+		AssociateTrades: append([]string{}, o.trades...),
+		ID:              o.id,
+		Status:          o.status,
+		Market:          o.market,
+		OriginalSize:    o.size.String(),
+		Outcome:         o.outcome,
+		Owner:           o.owner,
+		Price:           o.price.String(),
+		Side:            string(o.side),
+		SizeMatched:     o.matched.String(),
+		AssetID:         o.tokenID,
+		Expiration:      strconv.FormatInt(o.expiration, 10),
+		Type:            string(o.orderType),
+		CreatedAt:       uint64(o.createdAt.Unix()),
+	}
+}
+
+// book holds the resting orders of one token.
+type book struct {
+	bids []*order // best first: highest price, then oldest
+	asks []*order // best first: lowest price, then oldest
+
+	lastTradePrice decimal.Decimal
+}
+
+func (b *book) insert(o *order) {
+	if o.side == types.BUY {
+		b.bids = append(b.bids, o)
+		sort.SliceStable(b.bids, func(i, j int) bool {
+			if !b.bids[i].price.Equal(b.bids[j].price) {
+				return b.bids[i].price.GreaterThan(b.bids[j].price)
+			}
+			return b.bids[i].seq < b.bids[j].seq
+		})
+		return
+	}
+	b.asks = append(b.asks, o)
+	sort.SliceStable(b.asks, func(i, j int) bool {
+		if !b.asks[i].price.Equal(b.asks[j].price) {
+			return b.asks[i].price.LessThan(b.asks[j].price)
+		}
+		return b.asks[i].seq < b.asks[j].seq
+	})
+}
+
+func (b *book) remove(id string) bool {
+	for _, side := range []*[]*order{&b.bids, &b.asks} {
+		for i, o := range *side {
+			if o.id == id {
+				*side = append((*side)[:i], (*side)[i+1:]...)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// opposite returns the resting orders taker can match, best first.
+func (b *book) opposite(taker *order) []*order {
+	if taker.side == types.BUY {
+		return b.asks
+	}
+	return b.bids
+}
+
+// fill is one match between a taker and a resting maker order.
+type fill struct {
+	maker *order
+	size  decimal.Decimal
+}
+
+// match returns the fills for taker without applying them.
+func (b *book) match(taker *order) []fill {
+	var fills []fill
+	left := taker.remaining()
+	for _, maker := range b.opposite(taker) {
+		if left.IsZero() || !taker.crosses(maker) {
+			break
+		}
+		size := decimal.Min(left, maker.remaining())
+		fills = append(fills, fill{maker: maker, size: size})
+		left = left.Sub(size)
+	}
+	return fills
+}
+
+// prune drops fully matched orders.
+func (b *book) prune() {
+	for _, side := range []*[]*order{&b.bids, &b.asks} {
+		kept := (*side)[:0]
+		for _, o := range *side {
+			if o.remaining().IsPositive() {
+				kept = append(kept, o)
+			}
+		}
+		*side = kept
+	}
+}
+
+// levels aggregates orders by price. The CLOB lists bids ascending and asks
+// descending, so the best price is the last level of each side.
+func levels(orders []*order) []types.OrderSummary {
+	var out []types.OrderSummary
+	for _, o := range orders {
+		if n := len(out); n > 0 && out[n-1].Price == o.price.String() {
+			size := decimal.RequireFromString(out[n-1].Size).Add(o.remaining())
+			out[n-1].Size = size.String()
+			continue
+		}
+		out = append(out, types.OrderSummary{Price: o.price.String(), Size: o.remaining().String()})
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
@@ -0,0 +1,391 @@
+package clobtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	"github.com/polymarket/go-order-utils/pkg/builder"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/shopspring/decimal"
+)
+
+var one = decimal.NewFromInt(1)
+
+func (s *Server) handlePostOrder(w http.ResponseWriter, _ *http.Request, key *apiKey, body []byte) {
+	var req types.NewOrder
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid order payload")
+		return
+	}
+	resp, err := s.place(key, req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handlePostOrders(w http.ResponseWriter, _ *http.Request, key *apiKey, body []byte) {
+	var reqs []types.NewOrder
+	if err := json.Unmarshal(body, &reqs); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid order payload")
+		return
+	}
+	out := make([]types.OrderResponse, 0, len(reqs))
+	for _, req := range reqs {
+		resp, err := s.place(key, req)
+		if err != nil {
+			resp = &types.OrderResponse{ErrorMsg: err.Error()}
+		}
+		out = append(out, *resp)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// verify checks the signature of o and returns its order hash, which is also
+// its ID.
+func (s *Server) verify(key *apiKey, o *model.SignedOrder, m Market) (common.Hash, error) {
+	contract := model.CTFExchange
+	if m.NegRisk {
+		contract = model.NegRiskCTFExchange
+	}
+	hash, err := builder.NewExchangeOrderBuilderImpl(s.chainId, nil).BuildOrderHash(&o.Order, contract)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if o.SignatureType.Int64() == int64(types.POLY_1271) {
+		if s.opts.ContractSignatureVerifier == nil {
+			return common.Hash{}, fmt.Errorf("invalid order signature: POLY_1271 orders are not supported")
+		}
+		if err := s.opts.ContractSignatureVerifier(o.Maker, hash, o.Signature); err != nil {
+			return common.Hash{}, fmt.Errorf("invalid order signature: %w", err)
+		}
+		return hash, nil
+	}
+	if _, err := signing.VerifyOrder(o, s.chainId, contract); err != nil {
+		return common.Hash{}, fmt.Errorf("invalid order signature: %w", err)
+	}
+	if o.Signer != key.address {
+		return common.Hash{}, fmt.Errorf("the order signer address has to be the address of the API KEY")
+	}
+	return hash, nil
+}
+
+// place validates and matches req. FOK and FAK orders that cannot be filled
+// leave the books unchanged.
+func (s *Server) place(key *apiKey, req types.NewOrder) (*types.OrderResponse, error) {
+	if req.Owner != key.creds.ApiKey {
+		return nil, fmt.Errorf("the order owner has to be the owner of the API KEY")
+	}
+	m, ok := s.markets[req.Order.TokenID]
+	if !ok {
+		return nil, fmt.Errorf("invalid token id %s", req.Order.TokenID)
+	}
+	signed, err := signedOrder(req.Order)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := s.verify(key, signed, m)
+	if err != nil {
+		return nil, err
+	}
+	id := hash.Hex()
+	if _, ok := s.orders[id]; ok {
+		return nil, fmt.Errorf("order %s is invalid. Duplicated.", id)
+	}
+
+	now := s.opts.Now()
+	expiration := signed.Expiration.Int64()
+	switch req.OrderType {
+	case types.OrderTypeGTD:
+		if expiration <= now.Unix() {
+			return nil, fmt.Errorf("invalid expiration value (%d), expiration must be in the future", expiration)
+		}
+	case types.OrderTypeGTC, types.OrderTypeFOK, types.OrderTypeFAK:
+		if expiration != 0 {
+			return nil, fmt.Errorf("invalid expiration value (%d), only GTD orders expire", expiration)
+		}
+	default:
+		return nil, fmt.Errorf("invalid order type %q", req.OrderType)
+	}
+	if signed.FeeRateBps.Int64() != int64(m.FeeRateBps) {
+		return nil, fmt.Errorf("invalid fee rate (%s), current fee rate: %d", signed.FeeRateBps, m.FeeRateBps)
+	}
+
+	makerAmount := decimal.NewFromBigInt(signed.MakerAmount, -int32(types.CollateralTokenDecimals))
+	takerAmount := decimal.NewFromBigInt(signed.TakerAmount, -int32(types.CollateralTokenDecimals))
+	if !makerAmount.IsPositive() || !takerAmount.IsPositive() {
+		return nil, fmt.Errorf("invalid amounts, maker and taker amounts must be positive")
+	}
+	// Amounts are rounded when the order is built, so the implied price is
+	// only close to a tick.
+	price, size := makerAmount.Div(takerAmount), makerAmount
+	if req.Order.Side == types.BUY {
+		size = takerAmount
+	} else {
+		price = takerAmount.Div(makerAmount)
+	}
+	tick := decimal.RequireFromString(string(m.TickSize))
+	snapped := price.Div(tick).Round(0).Mul(tick)
+	if price.Sub(snapped).Abs().GreaterThan(tick.Div(decimal.NewFromInt(100))) {
+		return nil, fmt.Errorf("invalid price (%s), breaks minimum tick size rule: %s", price, m.TickSize)
+	}
+	if snapped.LessThan(tick) || snapped.GreaterThan(one.Sub(tick)) {
+		return nil, fmt.Errorf("invalid price (%s), min: %s - max: %s", snapped, tick, one.Sub(tick))
+	}
+	if size.LessThan(m.MinOrderSize) {
+		return nil, fmt.Errorf("Size (%s) lower than the minimum: %s", size, m.MinOrderSize)
+	}
+
+	s.seq++
+	taker := &order{
+		id:         id,
+		status:     statusLive,
+		owner:      key.creds.ApiKey,
+		maker:      signed.Maker.Hex(),
+		tokenID:    m.TokenID,
+		market:     m.ConditionID,
+		outcome:    m.Outcome,
+		side:       req.Order.Side,
+		price:      snapped,
+		size:       size,
+		orderType:  req.OrderType,
+		expiration: expiration,
+		feeRateBps: signed.FeeRateBps.String(),
+		createdAt:  now,
+		seq:        s.seq,
+	}
+
+	b := s.book(m.TokenID)
+	fills := b.match(taker)
+	filled := decimal.Zero
+	for _, f := range fills {
+		filled = filled.Add(f.size)
+	}
+	switch {
+	case req.OrderType == types.OrderTypeFOK && filled.LessThan(size):
+		return nil, fmt.Errorf("order couldn't be fully filled. FOK orders are fully filled or killed.")
+	case req.OrderType == types.OrderTypeFAK && filled.IsZero():
+		return nil, fmt.Errorf("no orders found to match with FAK order. FAK orders are partially filled or killed if no match is found.")
+	}
+
+	shares, collateral := decimal.Zero, decimal.Zero
+	for _, f := range fills {
+		s.fill(taker, f, now)
+		shares = shares.Add(f.size)
+		collateral = collateral.Add(f.size.Mul(f.maker.price))
+		b.lastTradePrice = f.maker.price
+	}
+	b.prune()
+	s.orders[id] = taker
+
+	resp := &types.OrderResponse{Success: true, OrderID: id, Status: "live"}
+	if len(fills) > 0 {
+		resp.Status = "matched"
+		resp.MakingAmount, resp.TakingAmount = collateral.String(), shares.String()
+		if taker.side == types.SELL {
+			resp.MakingAmount, resp.TakingAmount = shares.String(), collateral.String()
+		}
+	}
+	switch {
+	case taker.remaining().IsZero():
+		taker.status = statusMatched
+	case req.OrderType == types.OrderTypeFAK:
+		taker.status = statusCanceled
+	default:
+		b.insert(taker)
+	}
+	return resp, nil
+}
+
+// fill applies one match and records its trade.
+func (s *Server) fill(taker *order, f fill, now time.Time) {
+	taker.matched = taker.matched.Add(f.size)
+	f.maker.matched = f.maker.matched.Add(f.size)
+	if f.maker.remaining().IsZero() {
+		f.maker.status = statusMatched
+	}
+
+	id := crypto.Keccak256Hash([]byte(taker.id), []byte(f.maker.id), big.NewInt(int64(len(s.trades))).Bytes())
+	ts := strconv.FormatInt(now.Unix(), 10)
+	trade := types.Trade{
+		ID:           id.Hex(),
+		TakerOrderID: taker.id,
+		Market:       taker.market,
+		AssetID:      taker.tokenID,
+		Side:         string(taker.side),
+		Size:         f.size.String(),
+		FeeRateBps:   taker.feeRateBps,
+		Price:        f.maker.price.String(),
+		Status:       "MATCHED",
+		MatchTime:    ts,
+		LastUpdate:   ts,
+		Outcome:      taker.outcome,
+		Owner:        taker.owner,
+		MakerAddress: taker.maker,
+		TraderSide:   "TAKER",
+		MakerOrders: []map[string]any{{
+			"order_id":       f.maker.id,
+			"owner":          f.maker.owner,
+			"maker_address":  f.maker.maker,
+			"matched_amount": f.size.String(),
+			"price":          f.maker.price.String(),
+			"fee_rate_bps":   f.maker.feeRateBps,
+			"asset_id":       f.maker.tokenID,
+			"outcome":        f.maker.outcome,
+			"side":           string(f.maker.side),
+		}},
+	}
+	s.trades = append(s.trades, trade)
+	taker.trades = append(taker.trades, trade.ID)
+	f.maker.trades = append(f.maker.trades, trade.ID)
+}
+
+func (s *Server) handleGetOrders(w http.ResponseWriter, r *http.Request, key *apiKey, _ []byte) {
+	q := r.URL.Query()
+	out := types.OpenOrders{Data: []types.OpenOrder{}, NextCursor: types.END_CURSOR}
+	for _, id := range s.orderIDs() {
+		o := s.orders[id]
+		if o.owner != key.creds.ApiKey || o.status != statusLive {
+			continue
+		}
+		if v := q.Get("id"); v != "" && v != o.id {
+			continue
+		}
+		if v := q.Get("market"); v != "" && v != o.market {
+			continue
+		}
+		if v := q.Get("asset_id"); v != "" && v != o.tokenID {
+			continue
+		}
+		out.Data = append(out.Data, o.openOrder())
+	}
+	out.Count, out.Limit = len(out.Data), len(out.Data)
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleGetOrder(w http.ResponseWriter, r *http.Request, key *apiKey, _ []byte) {
+	o, ok := s.orders[r.PathValue("id")]
+	if !ok || o.owner != key.creds.ApiKey {
+		writeError(w, http.StatusNotFound, "order not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, o.openOrder())
+}
+
+func (s *Server) handleGetTrades(w http.ResponseWriter, r *http.Request, key *apiKey, _ []byte) {
+	q := r.URL.Query()
+	out := types.Trades{Data: []types.Trade{}, NextCursor: types.END_CURSOR}
+	for _, t := range s.trades {
+		maker := t.MakerOrders[0]
+		switch {
+		case t.Owner == key.creds.ApiKey:
+		case maker["owner"] == key.creds.ApiKey:
+			t.TraderSide = "MAKER"
+		default:
+			continue
+		}
+		if v := q.Get("id"); v != "" && v != t.ID {
+			continue
+		}
+		if v := q.Get("maker_address"); v != "" && !common.IsHexAddress(v) {
+			continue
+		} else if v != "" && common.HexToAddress(v).Hex() != t.MakerAddress && common.HexToAddress(v).Hex() != maker["maker_address"] {
+			continue
+		}
+		if v := q.Get("market"); v != "" && v != t.Market {
+			continue
+		}
+		if v := q.Get("asset_id"); v != "" && v != t.AssetID {
+			continue
+		}
+		matchTime, _ := strconv.ParseInt(t.MatchTime, 10, 64)
+		if v, err := strconv.ParseInt(q.Get("before"), 10, 64); err == nil && matchTime >= v {
+			continue
+		}
+		if v, err := strconv.ParseInt(q.Get("after"), 10, 64); err == nil && matchTime <= v {
+			continue
+		}
+		out.Data = append(out.Data, t)
+	}
+	out.Count, out.Limit = len(out.Data), len(out.Data)
+	writeJSON(w, http.StatusOK, out)
+}
+
+// cancel cancels the live orders of key with the given IDs.
+func (s *Server) cancel(key *apiKey, ids []string) types.CancelOrder {
+	out := types.CancelOrder{Canceled: []string{}, NotCanceled: map[string]string{}}
+	for _, id := range ids {
+		o, ok := s.orders[id]
+		switch {
+		case !ok || o.owner != key.creds.ApiKey:
+			out.NotCanceled[id] = "order not found"
+		case o.status != statusLive:
+			out.NotCanceled[id] = "order can't be found - already canceled or matched"
+		default:
+			o.status = statusCanceled
+			s.book(o.tokenID).remove(id)
+			out.Canceled = append(out.Canceled, id)
+		}
+	}
+	return out
+}
+
+// liveOrders returns the IDs of the live orders of key accepted by keep.
+func (s *Server) liveOrders(key *apiKey, keep func(o *order) bool) []string {
+	var ids []string
+	for _, id := range s.orderIDs() {
+		if o := s.orders[id]; o.owner == key.creds.ApiKey && o.status == statusLive && keep(o) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (s *Server) handleCancelOrder(w http.ResponseWriter, _ *http.Request, key *apiKey, body []byte) {
+	var req struct {
+		OrderID string `json:"orderID"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil || req.OrderID == "" {
+		writeError(w, http.StatusBadRequest, "Invalid order payload")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.cancel(key, []string{req.OrderID}))
+}
+
+func (s *Server) handleCancelOrders(w http.ResponseWriter, _ *http.Request, key *apiKey, body []byte) {
+	var ids []string
+	if err := json.Unmarshal(body, &ids); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid order payload")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.cancel(key, ids))
+}
+
+func (s *Server) handleCancelAll(w http.ResponseWriter, _ *http.Request, key *apiKey, _ []byte) {
+	ids := s.liveOrders(key, func(*order) bool { return true })
+	writeJSON(w, http.StatusOK, s.cancel(key, ids))
+}
+
+func (s *Server) handleCancelMarketOrders(w http.ResponseWriter, _ *http.Request, key *apiKey, body []byte) {
+	var req struct {
+		Market  string `json:"market"`
+		AssetID string `json:"asset_id"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+	ids := s.liveOrders(key, func(o *order) bool {
+		return (req.Market == "" || req.Market == o.market) && (req.AssetID == "" || req.AssetID == o.tokenID)
+	})
+	writeJSON(w, http.StatusOK, s.cancel(key, ids))
+}
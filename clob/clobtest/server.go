@@ -0,0 +1,496 @@
+// Package clobtest provides Server, an in-process fake of the CLOB API for
+// offline tests of code built on clob.Client.
+//
+// The fake checks L1 and L2 auth headers and order signatures the way the CLOB
+// does and matches orders in memory with price-time priority. It does not
+// check balances or allowances and never settles on chain.
+package clobtest
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/shopspring/decimal"
+)
+
+// Market is an outcome token the fake serves.
+type Market struct {
+	TokenID     string
+	ConditionID string
+	Outcome     string
+	TickSize    types.TickSize // default 0.01
+	NegRisk     bool
+	FeeRateBps  int
+	// MinOrderSize rejects smaller orders (in shares), zero disables the check.
+	MinOrderSize decimal.Decimal
+}
+
+type Options struct {
+	// ContractSignatureVerifier checks POLY_1271 orders, which cannot be
+	// recovered with ecrecover, e.g. with simulate.Simulator.IsValidSignature.
+	// They are rejected when nil.
+	ContractSignatureVerifier func(maker common.Address, hash common.Hash, signature []byte) error
+	// Now is the clock for expirations and timestamps, default time.Now.
+	Now func() time.Time
+}
+
+type apiKey struct {
+	address common.Address
+	creds   sdktypes.ApiKeyCreds
+}
+
+// Server is a fake CLOB listening on URL.
+type Server struct {
+	URL string
+
+	server  *httptest.Server
+	chainId *big.Int
+	opts    Options
+
+	mu       sync.Mutex
+	markets  map[string]Market
+	keys     map[string]*apiKey // by API key
+	derived  map[string]*apiKey // by address and nonce
+	balances map[common.Address]decimal.Decimal
+	orders   map[string]*order
+	books    map[string]*book
+	trades   []types.Trade
+	history  map[string][]types.PriceHistoryPoint
+	seq      int64
+}
+
+func NewServer(chainId *big.Int, opts Options) *Server {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	s := &Server{
+		chainId:  chainId,
+		opts:     opts,
+		markets:  make(map[string]Market),
+		keys:     make(map[string]*apiKey),
+		derived:  make(map[string]*apiKey),
+		balances: make(map[common.Address]decimal.Decimal),
+		orders:   make(map[string]*order),
+		books:    make(map[string]*book),
+		history:  make(map[string][]types.PriceHistoryPoint),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+types.TIME, s.handleTime)
+	mux.HandleFunc("POST "+types.CREATE_API_KEY, s.handleCreateAPIKey)
+	mux.HandleFunc("GET "+types.DERIVE_API_KEY, s.handleDeriveAPIKey)
+	mux.HandleFunc("GET "+types.GET_TICK_SIZE, s.handleMarketInfo)
+	mux.HandleFunc("GET "+types.GET_NEG_RISK, s.handleMarketInfo)
+	mux.HandleFunc("GET "+types.GET_FEE_RATE, s.handleMarketInfo)
+	mux.HandleFunc("GET "+types.GET_ORDER_BOOK, s.handleBook)
+	mux.HandleFunc("GET "+types.GET_MIDPOINT, s.handleMidpoint)
+	mux.HandleFunc("POST "+types.GET_MIDPOINTS, s.handleMidpoints)
+	mux.HandleFunc("GET "+types.GET_PRICES_HISTORY, s.handlePricesHistory)
+	mux.HandleFunc("POST "+types.POST_ORDER, s.l2(s.handlePostOrder))
+	mux.HandleFunc("POST "+types.POST_ORDERS, s.l2(s.handlePostOrders))
+	mux.HandleFunc("DELETE "+types.CANCEL_ORDER, s.l2(s.handleCancelOrder))
+	mux.HandleFunc("DELETE "+types.CANCEL_ORDERS, s.l2(s.handleCancelOrders))
+	mux.HandleFunc("DELETE "+types.CANCEL_ALL, s.l2(s.handleCancelAll))
+	mux.HandleFunc("DELETE "+types.CANCEL_MARKET_ORDERS, s.l2(s.handleCancelMarketOrders))
+	mux.HandleFunc("GET "+types.GET_OPEN_ORDERS, s.l2(s.handleGetOrders))
+	mux.HandleFunc("GET "+types.GET_ORDER+"{id}", s.l2(s.handleGetOrder))
+	mux.HandleFunc("GET "+types.GET_TRADES, s.l2(s.handleGetTrades))
+	mux.HandleFunc("GET "+types.GET_BALANCE_ALLOWANCE, s.l2(s.handleBalanceAllowance))
+
+	s.server = httptest.NewServer(mux)
+	s.URL = s.server.URL
+	return s
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+func (s *Server) AddMarket(m Market) {
+	if m.TickSize == "" {
+		m.TickSize = types.TickSize001
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.markets[m.TokenID] = m
+}
+
+// AddAPIKey registers existing credentials for address, e.g. to reuse fixed
+// credentials across tests instead of calling clob.Client.CreateApiKey.
+func (s *Server) AddAPIKey(address string, creds sdktypes.ApiKeyCreds) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[creds.ApiKey] = &apiKey{address: common.HexToAddress(address), creds: creds}
+}
+
+// SetBalance sets the collateral balance served by /balance-allowance.
+func (s *Server) SetBalance(address string, usdc decimal.Decimal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.balances[common.HexToAddress(address)] = usdc
+}
+
+// SetPricesHistory sets the price series served by /prices-history for
+// tokenID, oldest first.
+func (s *Server) SetPricesHistory(tokenID string, points []types.PriceHistoryPoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history[tokenID] = points
+}
+
+// OpenOrders returns the resting orders of every user.
+func (s *Server) OpenOrders() []types.OpenOrder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	var out []types.OpenOrder
+	for _, id := range s.orderIDs() {
+		if o := s.orders[id]; o.status == statusLive {
+			out = append(out, o.openOrder())
+		}
+	}
+	return out
+}
+
+// Trades returns every trade in match order, from the taker's side.
+func (s *Server) Trades() []types.Trade {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]types.Trade{}, s.trades...)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func (s *Server) handleTime(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.opts.Now().Unix())
+}
+
+func (s *Server) authL1(r *http.Request) (common.Address, *big.Int, error) {
+	headers := make(map[string]string, 4)
+	for _, h := range []string{"POLY_ADDRESS", "POLY_SIGNATURE", "POLY_TIMESTAMP", "POLY_NONCE"} {
+		headers[h] = r.Header.Get(h)
+	}
+	address, err := signing.VerifyL1Headers(headers, s.chainId)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	nonce, _ := new(big.Int).SetString(headers["POLY_NONCE"], 10)
+	return address, nonce, nil
+}
+
+// newCreds derives credentials deterministically from address and nonce.
+func newCreds(address common.Address, nonce *big.Int) sdktypes.ApiKeyCreds {
+	seed := func(label string) []byte {
+		return crypto.Keccak256([]byte(label), address.Bytes(), nonce.Bytes())
+	}
+	k := seed("key")
+	return sdktypes.ApiKeyCreds{
+		ApiKey:     fmt.Sprintf("%x-%x-%x-%x-%x", k[0:4], k[4:6], k[6:8], k[8:10], k[10:16]),
+		Secret:     base64.URLEncoding.EncodeToString(seed("secret")),
+		Passphrase: hex.EncodeToString(seed("passphrase")),
+	}
+}
+
+func (s *Server) handleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	address, nonce, err := s.authL1(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Invalid L1 Request headers: "+err.Error())
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := address.Hex() + ":" + nonce.String()
+	if _, ok := s.derived[id]; ok {
+		writeError(w, http.StatusBadRequest, "Could not create api key")
+		return
+	}
+	key := &apiKey{address: address, creds: newCreds(address, nonce)}
+	s.derived[id] = key
+	s.keys[key.creds.ApiKey] = key
+	writeJSON(w, http.StatusOK, key.creds)
+}
+
+func (s *Server) handleDeriveAPIKey(w http.ResponseWriter, r *http.Request) {
+	address, nonce, err := s.authL1(r)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Invalid L1 Request headers: "+err.Error())
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.derived[address.Hex()+":"+nonce.String()]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Could not derive api key!")
+		return
+	}
+	writeJSON(w, http.StatusOK, key.creds)
+}
+
+// l2 authenticates a request with the HMAC headers built by
+// headers.CreateL2Headers and serves it with the state locked.
+func (s *Server) l2(next func(w http.ResponseWriter, r *http.Request, key *apiKey, body []byte)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		key, ok := s.keys[r.Header.Get("POLY_API_KEY")]
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "Unauthorized/Invalid api key")
+			return
+		}
+		if r.Header.Get("POLY_PASSPHRASE") != key.creds.Passphrase {
+			writeError(w, http.StatusUnauthorized, "Unauthorized/Invalid passphrase")
+			return
+		}
+		if !common.IsHexAddress(r.Header.Get("POLY_ADDRESS")) || common.HexToAddress(r.Header.Get("POLY_ADDRESS")) != key.address {
+			writeError(w, http.StatusUnauthorized, "Unauthorized/POLY_ADDRESS does not own the api key")
+			return
+		}
+		bodyStr := string(body)
+		expected, err := signing.BuildPolyHmacSignature(key.creds.Secret, r.Header.Get("POLY_TIMESTAMP"), r.Method, r.URL.Path, &bodyStr)
+		if err != nil || !hmac.Equal([]byte(expected), []byte(r.Header.Get("POLY_SIGNATURE"))) {
+			writeError(w, http.StatusUnauthorized, "Unauthorized/Invalid signature")
+			return
+		}
+		s.expire()
+		next(w, r, key, body)
+	}
+}
+
+func (s *Server) market(w http.ResponseWriter, r *http.Request) (Market, bool) {
+	m, ok := s.markets[r.URL.Query().Get("token_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "No orderbook exists for the requested token id")
+	}
+	return m, ok
+}
+
+func (s *Server) handleMarketInfo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.market(w, r)
+	if !ok {
+		return
+	}
+	switch r.URL.Path {
+	case types.GET_TICK_SIZE:
+		tick, _ := strconv.ParseFloat(string(m.TickSize), 64)
+		writeJSON(w, http.StatusOK, map[string]float64{"minimum_tick_size": tick})
+	case types.GET_NEG_RISK:
+		writeJSON(w, http.StatusOK, map[string]bool{"neg_risk": m.NegRisk})
+	default:
+		writeJSON(w, http.StatusOK, map[string]int{"base_fee": m.FeeRateBps})
+	}
+}
+
+func (s *Server) book(tokenID string) *book {
+	b, ok := s.books[tokenID]
+	if !ok {
+		b = &book{}
+		s.books[tokenID] = b
+	}
+	return b
+}
+
+func (s *Server) handleBook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.market(w, r)
+	if !ok {
+		return
+	}
+	s.expire()
+	b := s.book(m.TokenID)
+	summary := types.OrderBookSummary{
+		Market:       m.ConditionID,
+		AssetID:      m.TokenID,
+		Timestamp:    strconv.FormatInt(s.opts.Now().UnixMilli(), 10),
+		Bids:         levels(b.bids),
+		Asks:         levels(b.asks),
+		MinOrderSize: m.MinOrderSize.String(),
+		TickSize:     string(m.TickSize),
+		NegRisk:      m.NegRisk,
+	}
+	if b.lastTradePrice.IsPositive() {
+		summary.LastTradePrice = b.lastTradePrice.String()
+	}
+	raw, _ := json.Marshal(summary)
+	summary.Hash = hex.EncodeToString(crypto.Keccak256(raw)[:20])
+	writeJSON(w, http.StatusOK, summary)
+}
+
+func (s *Server) midpoint(tokenID string) (decimal.Decimal, bool) {
+	b := s.book(tokenID)
+	if len(b.bids) == 0 || len(b.asks) == 0 {
+		return decimal.Zero, false
+	}
+	return b.bids[0].price.Add(b.asks[0].price).Div(decimal.NewFromInt(2)), true
+}
+
+func (s *Server) handleMidpoint(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.market(w, r)
+	if !ok {
+		return
+	}
+	s.expire()
+	mid, ok := s.midpoint(m.TokenID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "No orderbook exists for the requested token id")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"mid": mid.String()})
+}
+
+func (s *Server) handleMidpoints(w http.ResponseWriter, r *http.Request) {
+	var params []types.BookParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	out := make(map[string]string, len(params))
+	for _, p := range params {
+		if _, ok := s.markets[p.TokenId]; !ok {
+			continue
+		}
+		if mid, ok := s.midpoint(p.TokenId); ok {
+			out[p.TokenId] = mid.String()
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handlePricesHistory(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q := r.URL.Query()
+	out := types.PricesHistory{History: []types.PriceHistoryPoint{}}
+	for _, p := range s.history[q.Get("market")] {
+		if v, err := strconv.ParseInt(q.Get("startTs"), 10, 64); err == nil && p.T < v {
+			continue
+		}
+		if v, err := strconv.ParseInt(q.Get("endTs"), 10, 64); err == nil && p.T > v {
+			continue
+		}
+		out.History = append(out.History, p)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleBalanceAllowance(w http.ResponseWriter, _ *http.Request, key *apiKey, _ []byte) {
+	var resp types.BalanceAllowanceResponse
+	resp.Balance = s.balances[key.address].Shift(int32(types.CollateralTokenDecimals)).Truncate(0).String()
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// orderIDs returns the IDs of all orders in submission order.
+func (s *Server) orderIDs() []string {
+	ids := make([]string, s.seq+1)
+	for id, o := range s.orders {
+		ids[o.seq] = id
+	}
+	out := ids[:0]
+	for _, id := range ids {
+		if id != "" {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// expire cancels resting GTD orders past their expiration.
+func (s *Server) expire() {
+	now := s.opts.Now().Unix()
+	for _, o := range s.orders {
+		if o.status == statusLive && o.expiration > 0 && o.expiration <= now {
+			o.status = statusCanceled
+			s.book(o.tokenID).remove(o.id)
+		}
+	}
+}
+
+// signedOrder converts the JSON order posted by clob.Client back to the
+// go-order-utils order it was signed as.
+func signedOrder(o types.Order) (*model.SignedOrder, error) {
+	ints := map[string]string{
+		"tokenId": o.TokenID, "makerAmount": o.MakerAmount, "takerAmount": o.TakerAmount,
+		"expiration": o.Expiration, "nonce": o.Nonce, "feeRateBps": o.FeeRateBps,
+	}
+	parsed := make(map[string]*big.Int, len(ints))
+	for name, v := range ints {
+		n, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s %q", name, v)
+		}
+		parsed[name] = n
+	}
+	for name, v := range map[string]string{"maker": o.Maker, "signer": o.Signer, "taker": o.Taker} {
+		if !common.IsHexAddress(v) {
+			return nil, fmt.Errorf("invalid %s %q", name, v)
+		}
+	}
+	var side int64
+	switch o.Side {
+	case types.BUY:
+		side = int64(model.BUY)
+	case types.SELL:
+		side = int64(model.SELL)
+	default:
+		return nil, fmt.Errorf("invalid side %q", o.Side)
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(o.Signature, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature encoding")
+	}
+	return &model.SignedOrder{
+		Order: model.Order{
+			Salt:          big.NewInt(o.Salt),
+			Maker:         common.HexToAddress(o.Maker),
+			Signer:        common.HexToAddress(o.Signer),
+			Taker:         common.HexToAddress(o.Taker),
+			TokenId:       parsed["tokenId"],
+			MakerAmount:   parsed["makerAmount"],
+			TakerAmount:   parsed["takerAmount"],
+			Expiration:    parsed["expiration"],
+			Nonce:         parsed["nonce"],
+			FeeRateBps:    parsed["feeRateBps"],
+			Side:          big.NewInt(side),
+			SignatureType: big.NewInt(int64(o.SignatureType)),
+		},
+		Signature: sig,
+	}, nil
+}
@@ -0,0 +1,179 @@
+package clobtest_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/override-coder/go-polymarket-sdk/clob"
+	"github.com/override-coder/go-polymarket-sdk/clob/clobtest"
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/headers"
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenID = "1234"
+
+var chainId = big.NewInt(137)
+
+type trader struct {
+	signer signing.Signer
+	client *clob.Client
+	auth   *sdktypes.AuthOption
+}
+
+func newTrader(t *testing.T, s *clobtest.Server, chainId *big.Int) *trader {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	signer := signing.NewPrivateKeySigner(key)
+	tr := &trader{
+		signer: signer,
+		client: clob.NewClient(s.URL, chainId, signing.ToSignatureFunc(signer), nil),
+		auth:   &sdktypes.AuthOption{SignatureType: model.EOA, SingerAddress: signer.Address().Hex()},
+	}
+	_, err = tr.client.EnsureAPIKey(context.Background(), big.NewInt(0), tr.auth)
+	assert.NoError(t, err)
+	return tr
+}
+
+func (tr *trader) order(t *testing.T, side types.Side, price, size float64, orderType types.OrderType) (*types.OrderResponse, error) {
+	t.Helper()
+	return tr.client.CreateOrder(context.Background(), types.UserOrder{
+		TokenID: tokenID,
+		Price:   price,
+		Size:    size,
+		Side:    side,
+	}, orderType, false, tr.auth)
+}
+
+func newServer(t *testing.T) *clobtest.Server {
+	s := clobtest.NewServer(chainId, clobtest.Options{})
+	t.Cleanup(s.Close)
+	s.AddMarket(clobtest.Market{TokenID: tokenID, ConditionID: "0xc0", Outcome: "Yes", MinOrderSize: decimal.NewFromInt(5)})
+	return s
+}
+
+func TestMatching(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+	maker, taker := newTrader(t, s, chainId), newTrader(t, s, chainId)
+
+	for _, price := range []float64{0.55, 0.52, 0.52} {
+		resp, err := maker.order(t, types.SELL, price, 10, types.OrderTypeGTC)
+		assert.NoError(t, err)
+		assert.Equal(t, "live", resp.Status)
+	}
+	_, err := maker.order(t, types.BUY, 0.4, 10, types.OrderTypeGTC)
+	assert.NoError(t, err)
+
+	book, err := taker.client.GetOrderBook(tokenID)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.OrderSummary{{Price: "0.55", Size: "10"}, {Price: "0.52", Size: "20"}}, book.Asks)
+	assert.Equal(t, []types.OrderSummary{{Price: "0.4", Size: "10"}}, book.Bids)
+	mid, err := taker.client.GetMidpoint(ctx, tokenID)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.46", mid)
+
+	// FOK larger than the book is killed without touching it.
+	_, err = taker.order(t, types.BUY, 0.55, 40, types.OrderTypeFOK)
+	assert.ErrorContains(t, err, "fully filled or killed")
+	assert.Len(t, s.OpenOrders(), 4)
+
+	// Crosses both 0.52 asks at the maker price and rests 5 at 0.53.
+	resp, err := taker.order(t, types.BUY, 0.53, 25, types.OrderTypeGTC)
+	assert.NoError(t, err)
+	assert.Equal(t, "matched", resp.Status)
+	assert.Equal(t, "10.4", resp.MakingAmount)
+	assert.Equal(t, "20", resp.TakingAmount)
+
+	trades, err := taker.client.GetTrades(ctx, types.GetTradesRequest{}, taker.auth)
+	assert.NoError(t, err)
+	if assert.Len(t, trades.Data, 2) {
+		assert.Equal(t, "TAKER", trades.Data[0].TraderSide)
+		assert.Equal(t, "0.52", trades.Data[0].Price)
+		assert.Equal(t, resp.OrderID, trades.Data[0].TakerOrderID)
+	}
+	trades, err = maker.client.GetTrades(ctx, types.GetTradesRequest{}, maker.auth)
+	assert.NoError(t, err)
+	if assert.Len(t, trades.Data, 2) {
+		assert.Equal(t, "MAKER", trades.Data[1].TraderSide)
+	}
+
+	orders, err := taker.client.GetOrders(ctx, types.GetActiveOrdersRequest{}, taker.auth)
+	assert.NoError(t, err)
+	if assert.Len(t, orders.Data, 1) {
+		assert.Equal(t, "0.53", orders.Data[0].Price)
+		assert.Equal(t, "20", orders.Data[0].SizeMatched)
+	}
+
+	canceled, err := taker.client.CancelOrders(ctx, []string{orders.Data[0].ID}, taker.auth)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{orders.Data[0].ID}, canceled.Canceled)
+
+	// FAK fills what it can and cancels the rest.
+	resp, err = taker.order(t, types.SELL, 0.4, 15, types.OrderTypeFAK)
+	assert.NoError(t, err)
+	assert.Equal(t, "10", resp.MakingAmount)
+	assert.Equal(t, "4", resp.TakingAmount)
+	order, err := taker.client.GetOrder(ctx, resp.OrderID, types.GetOrderRequest{}, taker.auth)
+	assert.NoError(t, err)
+	assert.Equal(t, "CANCELED", order.Status)
+	assert.Equal(t, "10", order.SizeMatched)
+	_, err = taker.order(t, types.SELL, 0.6, 10, types.OrderTypeFAK)
+	assert.Error(t, err)
+
+	_, err = taker.order(t, types.BUY, 0.3, 1, types.OrderTypeGTC)
+	assert.ErrorContains(t, err, "lower than the minimum")
+
+	canceled, err = maker.client.CancelOrderAll(ctx, maker.auth)
+	assert.NoError(t, err)
+	assert.Len(t, canceled.Canceled, 1)
+	canceled, err = maker.client.CancelOrder(ctx, canceled.Canceled[0], maker.auth)
+	assert.NoError(t, err)
+	assert.Len(t, canceled.NotCanceled, 1)
+	assert.Len(t, s.OpenOrders(), 0)
+	assert.Len(t, s.Trades(), 3)
+}
+
+func TestAuthentication(t *testing.T) {
+	s := newServer(t)
+	tr := newTrader(t, s, chainId)
+
+	// An order signed for another chain, posted with valid L2 headers.
+	other := &trader{
+		client: clob.NewClient(s.URL, big.NewInt(80002), signing.ToSignatureFunc(tr.signer), nil),
+		auth:   tr.auth,
+	}
+	_, err := other.order(t, types.BUY, 0.5, 10, types.OrderTypeGTC)
+	assert.ErrorContains(t, err, "domain.chainId")
+	_, err = tr.order(t, types.BUY, 0.5, 10, types.OrderTypeGTC)
+	assert.NoError(t, err)
+
+	// The body is covered by the HMAC.
+	body := `{"orderID":"0x01"}`
+	h, err := headers.CreateL2Headers(tr.auth.SingerAddress, tr.auth.ApiKeyCreds, types.L2HeaderArgs{
+		Method: http.MethodDelete, RequestPath: types.CANCEL_ORDER, Body: body,
+	}, nil)
+	assert.NoError(t, err)
+	for _, b := range []string{body, `{"orderID":"0x02"}`} {
+		req, _ := http.NewRequest(http.MethodDelete, s.URL+types.CANCEL_ORDER, strings.NewReader(b))
+		for k, v := range h {
+			req.Header.Set(k, v)
+		}
+		res, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		res.Body.Close()
+		if b == body {
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+		} else {
+			assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		}
+	}
+}
@@ -18,6 +18,62 @@ import (
 )
 
 func (c *Client) CreateOrder(ctx context.Context, userOrder types.UserOrder, orderType types.OrderType, deferExec bool, option *sdktypes.AuthOption) (*types.OrderResponse, error) {
+	userOrder, options, err := c.prepareOrder(ctx, userOrder, option)
+	if err != nil {
+		return nil, err
+	}
+
+	signedOrder, err := c.orderBuilder.buildOrder(userOrder, orderType, options)
+	if err != nil {
+		return nil, errors.WithMessage(err, "create order buildOrder")
+	}
+
+	return c.postOrder(ctx, signedOrder, orderType, deferExec, option)
+}
+
+// BuildOrder signs userOrder like CreateOrder and returns the payload
+// CreateOrder would post, without posting it. The owner is empty when option
+// has no ApiKeyCreds.
+func (c *Client) BuildOrder(ctx context.Context, userOrder types.UserOrder, orderType types.OrderType, deferExec bool, option *sdktypes.AuthOption) (*types.NewOrder, error) {
+	userOrder, options, err := c.prepareOrder(ctx, userOrder, option)
+	if err != nil {
+		return nil, err
+	}
+
+	signedOrder, err := c.orderBuilder.buildOrder(userOrder, orderType, options)
+	if err != nil {
+		return nil, errors.WithMessage(err, "build order buildOrder")
+	}
+
+	owner := ""
+	if option != nil && option.ApiKeyCreds != nil {
+		owner = option.ApiKeyCreds.ApiKey
+	}
+	payload := orderToJson(signedOrder, owner, &orderType, deferExec)
+	return &payload, nil
+}
+
+// CreateSafeOrder creates an order signed by the Safe in option.FunderAddress
+// through EIP-1271, see OrderBuilder.BuildSafeOrder. owners sign with the
+// client's SignatureFunc; option.SingerAddress and ApiKeyCreds authenticate
+// the request.
+func (c *Client) CreateSafeOrder(ctx context.Context, userOrder types.UserOrder, orderType types.OrderType, deferExec bool, owners []string, option *sdktypes.AuthOption) (*types.OrderResponse, error) {
+	userOrder, options, err := c.prepareOrder(ctx, userOrder, option)
+	if err != nil {
+		return nil, err
+	}
+
+	signedOrder, err := c.orderBuilder.BuildSafeOrder(userOrder, orderType, options, owners)
+	if err != nil {
+		return nil, errors.WithMessage(err, "create safe order BuildSafeOrder")
+	}
+
+	return c.postOrder(ctx, signedOrder, orderType, deferExec, option)
+}
+
+// prepareOrder resolves tick size, fee rate and neg risk for userOrder and
+// normalizes its price.
+func (c *Client) prepareOrder(ctx context.Context, userOrder types.UserOrder, option *sdktypes.AuthOption) (types.UserOrder, types.CreateOrderOptions, error) {
 	tokenID := userOrder.TokenID
 
 	tickSize := ""
@@ -26,7 +82,7 @@ func (c *Client) CreateOrder(ctx context.Context, userOrder types.UserOrder, ord
 	} else {
 		tickSz, err := c.GetTickSize(ctx, tokenID)
 		if err != nil {
-			return nil, errors.WithMessage(err, "create order get tickSize")
+			return userOrder, types.CreateOrderOptions{}, errors.WithMessage(err, "create order get tickSize")
 		}
 		tickSize = tickSz
 	}
@@ -37,7 +93,7 @@ func (c *Client) CreateOrder(ctx context.Context, userOrder types.UserOrder, ord
 	} else {
 		bps, err := c.GetFeeRateBps(ctx, tokenID)
 		if err != nil {
-			return nil, errors.WithMessage(err, "create order get feeRateBps")
+			return userOrder, types.CreateOrderOptions{}, errors.WithMessage(err, "create order get feeRateBps")
 		}
 		feeRateBps = bps
 	}
@@ -62,21 +118,16 @@ func (c *Client) CreateOrder(ctx context.Context, userOrder types.UserOrder, ord
 	} else {
 		risk, err := c.GetNegRisk(ctx, tokenID)
 		if err != nil {
-			return nil, errors.WithMessage(err, "create order get negRisk")
+			return userOrder, types.CreateOrderOptions{}, errors.WithMessage(err, "create order get negRisk")
 		}
 		negRisk = risk
 	}
 
-	signedOrder, err := c.orderBuilder.buildOrder(userOrder, orderType, types.CreateOrderOptions{
+	return userOrder, types.CreateOrderOptions{
 		AuthOption: option,
 		TickSize:   types.TickSize(tickSize),
 		NegRisk:    negRisk,
-	})
-	if err != nil {
-		return nil, errors.WithMessage(err, "create order buildOrder")
-	}
-
-	return c.postOrder(ctx, signedOrder, orderType, deferExec, option)
+	}, nil
 }
 
 func (c *Client) postOrder(ctx context.Context, order *model.SignedOrder, orderType types.OrderType, deferExec bool, option *sdktypes.AuthOption) (*types.OrderResponse, error) {
@@ -195,6 +246,9 @@ func (c *Client) GetOrders(ctx context.Context, req types.GetActiveOrdersRequest
 	if req.AssetID != "" {
 		params["asset_id"] = req.AssetID
 	}
+	if req.NextCursor != "" {
+		params["next_cursor"] = req.NextCursor
+	}
 
 	var resp types.OpenOrders
 	res, err := c.client.DoRequest(ctx, http.MethodGet, types.GET_OPEN_ORDERS, &http2.RequestOptions{
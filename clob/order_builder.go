@@ -77,17 +77,51 @@ func (o *OrderBuilder) createOrder(order types.UserOrder, orderType types.OrderT
 	return buildOrder(o.signFn, exchangeContract, o.chaindId, orderData)
 }
 
+// BuildSafeOrder builds an order signed by the Safe in options.AuthOption.FunderAddress
+// through EIP-1271 (signature type POLY_1271): every owner signs the order hash
+// as a SafeMessage and the signatures are packed in Safe's sorted format.
+func (o *OrderBuilder) BuildSafeOrder(order types.UserOrder, orderType types.OrderType, options types.CreateOrderOptions, owners []string) (*model.SignedOrder, error) {
+	if options.AuthOption == nil || options.AuthOption.FunderAddress == "" {
+		return nil, errors.New("safe order requires the safe as FunderAddress")
+	}
+	option := *options.AuthOption
+	option.SignatureType = types.POLY_1271
+	orderData := o.buildOrderCreationArgs(order, orderType, roundingConfig[options.TickSize], &option)
+
+	exchangeContract := model.CTFExchange
+	if options.NegRisk {
+		exchangeContract = model.NegRiskCTFExchange
+	}
+	cTFExchangeOrderBuilder := builder.NewExchangeOrderBuilderImpl(o.chaindId, nil)
+	signedOrder, err := cTFExchangeOrderBuilder.BuildOrder(orderData)
+	if err != nil {
+		return nil, err
+	}
+	orderHash, err := cTFExchangeOrderBuilder.BuildOrderHash(signedOrder, exchangeContract)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signing.BuildSafeMessageSignature(o.signFn, o.chaindId.Int64(), option.FunderAddress, owners, orderHash)
+	if err != nil {
+		return nil, err
+	}
+	return &model.SignedOrder{
+		Order:     *signedOrder,
+		Signature: signature,
+	}, nil
+}
+
 func buildOrder(signFn signing.SignatureFunc, exchangeAddress model.VerifyingContract, chainId *big.Int, orderData *model.OrderData) (*model.SignedOrder, error) {
 	cTFExchangeOrderBuilder := builder.NewExchangeOrderBuilderImpl(chainId, nil)
 	order, err := cTFExchangeOrderBuilder.BuildOrder(orderData)
 	if err != nil {
 		return nil, err
 	}
-	orderHash, err := cTFExchangeOrderBuilder.BuildOrderHash(order, exchangeAddress)
+	typedData, err := signing.OrderTypedData(order, chainId, exchangeAddress)
 	if err != nil {
 		return nil, err
 	}
-	signature, err := signFn(order.Signer.String(), orderHash.Bytes())
+	signature, err := signing.SignTypedData(signFn, order.Signer.String(), typedData)
 	if err != nil {
 		return nil, err
 	}
@@ -115,6 +149,10 @@ func (o *OrderBuilder) buildOrderCreationArgs(order types.UserOrder, orderType t
 	if !strings.EqualFold(option.FunderAddress, "") {
 		maker = option.FunderAddress
 	}
+	signer := option.SingerAddress
+	if option.SignatureType == types.POLY_1271 {
+		signer = maker
+	}
 	var (
 		taker      = sdktypes.ZeroAddress
 		feeRateBps = "0"
@@ -142,7 +180,7 @@ func (o *OrderBuilder) buildOrderCreationArgs(order types.UserOrder, orderType t
 		TakerAmount:   takerAmount.String(),
 		FeeRateBps:    feeRateBps,
 		Nonce:         nonce,
-		Signer:        option.SingerAddress,
+		Signer:        signer,
 		Expiration:    expiration,
 		Side:          side,
 		SignatureType: option.SignatureType,
@@ -196,3 +234,19 @@ func getMarketOrderRawAmounts(side types.Side, size float64, price float64, roun
 	}
 	return model.SELL, rawMakerAmt, rawTakerAmt
 }
+
+// RawAmounts returns the maker and taker amounts OrderBuilder signs for order,
+// rounded with the rounding config of tickSize. Market (FOK and FAK) buys are
+// sized in USDC, all other orders in shares.
+func RawAmounts(order types.UserOrder, orderType types.OrderType, tickSize types.TickSize) (makerAmt, takerAmt float64, err error) {
+	roundConfig, ok := roundingConfig[tickSize]
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid tick size %q", tickSize)
+	}
+	if orderType == types.OrderTypeFAK || orderType == types.OrderTypeFOK {
+		_, makerAmt, takerAmt = getMarketOrderRawAmounts(order.Side, order.Size, order.Price, roundConfig)
+	} else {
+		_, makerAmt, takerAmt = getOrderRawAmounts(order.Side, order.Size, order.Price, roundConfig)
+	}
+	return makerAmt, takerAmt, nil
+}
@@ -0,0 +1,605 @@
+package clob
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/override-coder/go-polymarket-sdk/types/utils"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// ManagedOrder is the local state of an order placed through an
+// OrderManager.
+type ManagedOrder struct {
+	ID          string
+	Market      string // condition ID, empty until the exchange reports it
+	AssetID     string
+	Side        types.Side
+	Price       decimal.Decimal
+	Size        decimal.Decimal // shares
+	SizeMatched decimal.Decimal
+	OrderType   types.OrderType
+	Status      string // one of the types.OrderStatus* values
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (o ManagedOrder) Remaining() decimal.Decimal {
+	if remaining := o.Size.Sub(o.SizeMatched); remaining.IsPositive() {
+		return remaining
+	}
+	return decimal.Zero
+}
+
+// OrderFill is an increase of the matched size of a managed order. TradeID is
+// empty when the fill was seen through the order's size_matched rather than
+// a trade.
+type OrderFill struct {
+	Order   ManagedOrder // after the fill
+	Size    decimal.Decimal
+	Price   decimal.Decimal
+	TradeID string
+}
+
+// OrderFilter selects managed orders; empty fields match any order.
+type OrderFilter struct {
+	Market  string
+	AssetID string
+	Side    types.Side
+	// All includes orders that are no longer live.
+	All bool
+}
+
+type OrderManagerOptions struct {
+	OnFill func(OrderFill)
+	// OnCancel is called once per managed order that is canceled, through
+	// the manager, elsewhere, or found orphaned by Reconcile.
+	OnCancel func(ManagedOrder)
+	// OnError receives the errors of the reconciliations of Run.
+	OnError func(error)
+	// Interval between the reconciliations of Run, default 10s.
+	Interval time.Duration
+	// Retention is how long orders stay managed once they are no longer
+	// live, default 24h so the fills of the day are kept. Reconcile forgets
+	// older ones.
+	Retention time.Duration
+	Now       func() time.Time
+}
+
+// ReconcileReport is the outcome of OrderManager.Reconcile.
+type ReconcileReport struct {
+	// Unknown are open orders of the account the manager did not place.
+	Unknown []types.OpenOrder
+	// Orphaned are managed orders the exchange no longer lists although
+	// they did not fill. They are marked canceled.
+	Orphaned []ManagedOrder
+}
+
+// OrderManager tracks the orders of one account. It wraps an OrderClient,
+// records every order placed through its CreateOrder and keeps their state
+// from order responses, user channel events (HandleOrderEvent, HandleTrade)
+// and reconciliations against GetOrders and GetTrades.
+//
+// Fills are counted from both the exchange's size_matched and the trades,
+// whichever is larger, so the same fill seen twice is counted once.
+type OrderManager struct {
+	client OrderClient
+	option *sdktypes.AuthOption
+	opts   OrderManagerOptions
+
+	mu        sync.Mutex
+	orders    map[string]*managedOrder
+	trades    map[string]bool            // IDs of the trades applied
+	positions map[string]decimal.Decimal // net shares matched per token
+	unknown   []types.OpenOrder
+}
+
+type managedOrder struct {
+	ManagedOrder
+	reported   decimal.Decimal // size_matched reported by the exchange
+	fromTrades decimal.Decimal // sum of the trades of the order
+	trades     []string        // IDs of the trades of the order
+}
+
+var _ OrderClient = (*OrderManager)(nil)
+
+// NewOrderManager returns a manager of the orders placed through client.
+// option authenticates the requests of Reconcile and the calls passing a nil
+// option.
+func NewOrderManager(client OrderClient, option *sdktypes.AuthOption, opts OrderManagerOptions) *OrderManager {
+	if opts.Interval <= 0 {
+		opts.Interval = 10 * time.Second
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = 24 * time.Hour
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &OrderManager{
+		client:    client,
+		option:    option,
+		opts:      opts,
+		orders:    make(map[string]*managedOrder),
+		trades:    make(map[string]bool),
+		positions: make(map[string]decimal.Decimal),
+	}
+}
+
+func (m *OrderManager) auth(option *sdktypes.AuthOption) *sdktypes.AuthOption {
+	if option == nil {
+		return m.option
+	}
+	return option
+}
+
+// Order returns the managed order id.
+func (m *OrderManager) Order(id string) (ManagedOrder, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, ok := m.orders[id]
+	if !ok {
+		return ManagedOrder{}, false
+	}
+	return o.ManagedOrder, true
+}
+
+// Orders returns the managed orders matching f, oldest first.
+func (m *OrderManager) Orders(f OrderFilter) []ManagedOrder {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []ManagedOrder
+	for _, o := range m.orders {
+		switch {
+		case !f.All && o.Status != types.OrderStatusLive:
+		case f.Market != "" && o.Market != f.Market:
+		case f.AssetID != "" && o.AssetID != f.AssetID:
+		case f.Side != "" && o.Side != f.Side:
+		default:
+			out = append(out, o.ManagedOrder)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// Position returns the net shares of tokenID bought by the managed orders,
+// including the orders forgotten after Retention.
+func (m *OrderManager) Position(tokenID string) decimal.Decimal {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.positions[tokenID]
+}
+
+// Unknown returns the open orders the manager did not place, as of the last
+// Reconcile.
+func (m *OrderManager) Unknown() []types.OpenOrder {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]types.OpenOrder{}, m.unknown...)
+}
+
+// CreateOrder places the order through the wrapped client and tracks it.
+func (m *OrderManager) CreateOrder(ctx context.Context, userOrder types.UserOrder, orderType types.OrderType, deferExec bool, option *sdktypes.AuthOption) (*types.OrderResponse, error) {
+	resp, fills, canceled, err := m.createOrder(ctx, userOrder, orderType, deferExec, option)
+	m.notify(fills, canceled)
+	return resp, err
+}
+
+// createOrder places and tracks the order, and returns the fills and
+// cancellations to notify.
+func (m *OrderManager) createOrder(ctx context.Context, userOrder types.UserOrder, orderType types.OrderType, deferExec bool, option *sdktypes.AuthOption) (*types.OrderResponse, []OrderFill, []ManagedOrder, error) {
+	resp, err := m.client.CreateOrder(ctx, userOrder, orderType, deferExec, m.auth(option))
+	if err != nil || resp == nil || resp.OrderID == "" {
+		return resp, nil, nil, err
+	}
+
+	price := decimal.NewFromFloat(userOrder.Price)
+	size := decimal.NewFromFloat(userOrder.Size)
+	making, taking := utils.StringToDecimal(resp.MakingAmount), utils.StringToDecimal(resp.TakingAmount)
+	shares, collateral := taking, making
+	if userOrder.Side == types.SELL {
+		shares, collateral = making, taking
+	}
+	market := orderType == types.OrderTypeFOK || orderType == types.OrderTypeFAK
+	if market && userOrder.Side == types.BUY {
+		// Market buys are sized in USDC.
+		if shares.IsPositive() {
+			size = shares
+		} else if price.IsPositive() {
+			size = size.Div(price).Truncate(2)
+		}
+	}
+
+	now := m.opts.Now()
+	o := &managedOrder{ManagedOrder: ManagedOrder{
+		ID:        resp.OrderID,
+		AssetID:   userOrder.TokenID,
+		Side:      userOrder.Side,
+		Price:     price,
+		Size:      size,
+		OrderType: orderType,
+		Status:    types.OrderStatusLive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}}
+	o.reported = shares
+
+	var fills []OrderFill
+	var canceled []ManagedOrder
+	m.mu.Lock()
+	m.orders[o.ID] = o
+	fillPrice := price
+	if shares.IsPositive() {
+		fillPrice = collateral.Div(shares)
+	}
+	if fill, ok := m.observe(o, fillPrice, ""); ok {
+		fills = append(fills, fill)
+	}
+	switch strings.ToLower(resp.Status) {
+	case "unmatched":
+		canceled = m.cancel(o, canceled)
+	case "matched":
+		if market && o.Status == types.OrderStatusLive {
+			// The unmatched part of market orders is killed.
+			canceled = m.cancel(o, canceled)
+		}
+	}
+	m.mu.Unlock()
+	return resp, fills, canceled, nil
+}
+
+// HandleOrderEvent applies an order message of the user channel. Messages
+// of orders the manager did not place are ignored.
+func (m *OrderManager) HandleOrderEvent(ev types.UserOrderEvent) {
+	var fills []OrderFill
+	var canceled []ManagedOrder
+	m.mu.Lock()
+	if o, ok := m.orders[ev.ID]; ok {
+		if fill, ok := m.apply(o, ev.Market, ev.OriginalSize, ev.SizeMatched); ok {
+			fills = append(fills, fill)
+		}
+		if strings.EqualFold(ev.Type, "CANCELLATION") {
+			canceled = m.cancel(o, canceled)
+		}
+	}
+	m.mu.Unlock()
+	m.notify(fills, canceled)
+}
+
+// HandleTrade applies a trade, from the user channel or GetTrades, to the
+// managed orders on either side of it. Each trade is applied once. A trade
+// is applied as soon as it is MATCHED; if it later ends FAILED, its fill is
+// not reversed.
+func (m *OrderManager) HandleTrade(t types.Trade) {
+	m.mu.Lock()
+	fills := m.applyTrade(t, nil)
+	m.mu.Unlock()
+	m.notify(fills, nil)
+}
+
+func (m *OrderManager) applyTrade(t types.Trade, fills []OrderFill) []OrderFill {
+	if t.ID == "" || m.trades[t.ID] || strings.EqualFold(t.Status, "FAILED") {
+		return fills
+	}
+	m.trades[t.ID] = true
+	if o, ok := m.orders[t.TakerOrderID]; ok {
+		o.fromTrades = o.fromTrades.Add(utils.StringToDecimal(t.Size))
+		o.trades = append(o.trades, t.ID)
+		if o.Market == "" {
+			o.Market = t.Market
+		}
+		if fill, ok := m.observe(o, utils.StringToDecimal(t.Price), t.ID); ok {
+			fills = append(fills, fill)
+		}
+	}
+	for _, maker := range t.MakerOrders {
+		id, _ := maker["order_id"].(string)
+		o, ok := m.orders[id]
+		if !ok {
+			continue
+		}
+		amount, _ := maker["matched_amount"].(string)
+		o.fromTrades = o.fromTrades.Add(utils.StringToDecimal(amount))
+		o.trades = append(o.trades, t.ID)
+		if o.Market == "" {
+			o.Market = t.Market
+		}
+		price, _ := maker["price"].(string)
+		if fill, ok := m.observe(o, utils.StringToDecimal(price), t.ID); ok {
+			fills = append(fills, fill)
+		}
+	}
+	return fills
+}
+
+// apply updates o from the exchange's view of it.
+func (m *OrderManager) apply(o *managedOrder, market, originalSize, sizeMatched string) (OrderFill, bool) {
+	if o.Market == "" {
+		o.Market = market
+	}
+	if size := utils.StringToDecimal(originalSize); size.IsPositive() {
+		o.Size = size
+	}
+	o.reported = decimal.Max(o.reported, utils.StringToDecimal(sizeMatched))
+	return m.observe(o, o.Price, "")
+}
+
+// observe raises the matched size of o to what the exchange reported or its
+// trades add up to, and returns the fill if it grew.
+func (m *OrderManager) observe(o *managedOrder, price decimal.Decimal, tradeID string) (OrderFill, bool) {
+	matched := decimal.Max(o.reported, o.fromTrades)
+	if o.Size.IsPositive() && matched.GreaterThan(o.Size) {
+		matched = o.Size
+	}
+	if !matched.GreaterThan(o.SizeMatched) {
+		return OrderFill{}, false
+	}
+	size := matched.Sub(o.SizeMatched)
+	o.SizeMatched = matched
+	if o.Side == types.BUY {
+		m.positions[o.AssetID] = m.positions[o.AssetID].Add(size)
+	} else {
+		m.positions[o.AssetID] = m.positions[o.AssetID].Sub(size)
+	}
+	o.UpdatedAt = m.opts.Now()
+	if o.Status == types.OrderStatusLive && o.Remaining().IsZero() {
+		o.Status = types.OrderStatusMatched
+	}
+	return OrderFill{Order: o.ManagedOrder, Size: size, Price: price, TradeID: tradeID}, true
+}
+
+// cancel marks a live order canceled.
+func (m *OrderManager) cancel(o *managedOrder, canceled []ManagedOrder) []ManagedOrder {
+	if o.Status != types.OrderStatusLive {
+		return canceled
+	}
+	o.Status = types.OrderStatusCanceled
+	o.UpdatedAt = m.opts.Now()
+	return append(canceled, o.ManagedOrder)
+}
+
+// notify runs the callbacks, outside of the lock.
+func (m *OrderManager) notify(fills []OrderFill, canceled []ManagedOrder) {
+	if m.opts.OnFill != nil {
+		for _, fill := range fills {
+			m.opts.OnFill(fill)
+		}
+	}
+	if m.opts.OnCancel != nil {
+		for _, o := range canceled {
+			m.opts.OnCancel(o)
+		}
+	}
+}
+
+func (m *OrderManager) CancelOrder(ctx context.Context, orderId string, option *sdktypes.AuthOption) (*types.CancelOrder, error) {
+	resp, err := m.client.CancelOrder(ctx, orderId, m.auth(option))
+	if err == nil {
+		m.canceled(resp)
+	}
+	return resp, err
+}
+
+func (m *OrderManager) CancelOrders(ctx context.Context, orderId []string, option *sdktypes.AuthOption) (*types.CancelOrder, error) {
+	resp, err := m.client.CancelOrders(ctx, orderId, m.auth(option))
+	if err == nil {
+		m.canceled(resp)
+	}
+	return resp, err
+}
+
+func (m *OrderManager) canceled(resp *types.CancelOrder) {
+	var canceled []ManagedOrder
+	m.mu.Lock()
+	for _, id := range resp.Canceled {
+		if o, ok := m.orders[id]; ok {
+			canceled = m.cancel(o, canceled)
+		}
+	}
+	m.mu.Unlock()
+	m.notify(nil, canceled)
+}
+
+// CancelOrderAll cancels every open order of the account, when the wrapped
+// client supports it.
+func (m *OrderManager) CancelOrderAll(ctx context.Context, option *sdktypes.AuthOption) (*types.CancelOrder, error) {
+	client, ok := m.client.(interface {
+		CancelOrderAll(ctx context.Context, option *sdktypes.AuthOption) (*types.CancelOrder, error)
+	})
+	if !ok {
+		return nil, errors.New("cancel order all: not supported by the client")
+	}
+	resp, err := client.CancelOrderAll(ctx, m.auth(option))
+	if err == nil {
+		m.canceled(resp)
+	}
+	return resp, err
+}
+
+// GetOrders lists open orders through the wrapped client and applies them
+// to the managed orders.
+func (m *OrderManager) GetOrders(ctx context.Context, req types.GetActiveOrdersRequest, option *sdktypes.AuthOption) (*types.OpenOrders, error) {
+	resp, err := m.client.GetOrders(ctx, req, m.auth(option))
+	if err != nil {
+		return nil, err
+	}
+	var fills []OrderFill
+	m.mu.Lock()
+	for _, open := range resp.Data {
+		if o, ok := m.orders[open.ID]; ok {
+			if fill, ok := m.apply(o, open.Market, open.OriginalSize, open.SizeMatched); ok {
+				fills = append(fills, fill)
+			}
+		}
+	}
+	m.mu.Unlock()
+	m.notify(fills, nil)
+	return resp, nil
+}
+
+// GetTrades lists trades through the wrapped client and applies them to the
+// managed orders.
+func (m *OrderManager) GetTrades(ctx context.Context, req types.GetTradesRequest, option *sdktypes.AuthOption) (*types.Trades, error) {
+	resp, err := m.client.GetTrades(ctx, req, m.auth(option))
+	if err != nil {
+		return nil, err
+	}
+	var fills []OrderFill
+	m.mu.Lock()
+	for _, t := range resp.Data {
+		fills = m.applyTrade(t, fills)
+	}
+	m.mu.Unlock()
+	m.notify(fills, nil)
+	return resp, nil
+}
+
+func (m *OrderManager) GetBalanceAllowance(option *sdktypes.AuthOption) (*types.BalanceAllowanceResponse, error) {
+	return m.client.GetBalanceAllowance(m.auth(option))
+}
+
+// maxReconcilePages bounds the pages of orders and trades read by Reconcile.
+const maxReconcilePages = 100
+
+// Reconcile reads every open order of the account and the trades since the
+// oldest live managed order, and updates the managed orders from them. Live
+// orders placed before the call that the exchange no longer lists are marked
+// matched if their trades add up, and orphaned otherwise. Orders done for
+// longer than Retention are forgotten.
+func (m *OrderManager) Reconcile(ctx context.Context) (*ReconcileReport, error) {
+	start := m.opts.Now()
+
+	var open []types.OpenOrder
+	req := types.GetActiveOrdersRequest{}
+	for page := 0; page < maxReconcilePages; page++ {
+		resp, err := m.client.GetOrders(ctx, req, m.option)
+		if err != nil {
+			return nil, errors.WithMessage(err, "reconcile get orders")
+		}
+		open = append(open, resp.Data...)
+		if resp.NextCursor == "" || resp.NextCursor == types.END_CURSOR || resp.NextCursor == req.NextCursor {
+			break
+		}
+		req.NextCursor = resp.NextCursor
+	}
+
+	var trades []types.Trade
+	if since, ok := m.oldestLive(); ok {
+		after := strconv.FormatInt(since.Unix()-1, 10)
+		req := types.GetTradesRequest{After: &after}
+		for page := 0; page < maxReconcilePages; page++ {
+			resp, err := m.client.GetTrades(ctx, req, m.option)
+			if err != nil {
+				return nil, errors.WithMessage(err, "reconcile get trades")
+			}
+			trades = append(trades, resp.Data...)
+			if resp.NextCursor == "" || resp.NextCursor == types.END_CURSOR || (req.NextCursor != nil && resp.NextCursor == *req.NextCursor) {
+				break
+			}
+			next := resp.NextCursor
+			req.NextCursor = &next
+		}
+	}
+
+	report := &ReconcileReport{}
+	var fills []OrderFill
+	var canceled []ManagedOrder
+	m.mu.Lock()
+	listed := make(map[string]bool, len(open))
+	for _, oo := range open {
+		listed[oo.ID] = true
+		o, ok := m.orders[oo.ID]
+		if !ok {
+			report.Unknown = append(report.Unknown, oo)
+			continue
+		}
+		if fill, ok := m.apply(o, oo.Market, oo.OriginalSize, oo.SizeMatched); ok {
+			fills = append(fills, fill)
+		}
+	}
+	for _, t := range trades {
+		fills = m.applyTrade(t, fills)
+	}
+	for _, o := range m.orders {
+		if o.Status != types.OrderStatusLive || listed[o.ID] || o.CreatedAt.After(start) {
+			continue
+		}
+		canceled = m.cancel(o, canceled)
+		report.Orphaned = append(report.Orphaned, o.ManagedOrder)
+	}
+	m.prune(start)
+	m.unknown = report.Unknown
+	m.mu.Unlock()
+	m.notify(fills, canceled)
+	return report, nil
+}
+
+// prune forgets the orders that stopped being live more than Retention
+// before now, and the trades they were filled by unless a remaining order,
+// e.g. the other side of a trade of the account with itself, was too.
+func (m *OrderManager) prune(now time.Time) {
+	cutoff := now.Add(-m.opts.Retention)
+	var pruned []string
+	for id, o := range m.orders {
+		if o.Status == types.OrderStatusLive || !o.UpdatedAt.Before(cutoff) {
+			continue
+		}
+		pruned = append(pruned, o.trades...)
+		delete(m.orders, id)
+	}
+	if len(pruned) == 0 {
+		return
+	}
+	kept := make(map[string]bool)
+	for _, o := range m.orders {
+		for _, tradeID := range o.trades {
+			kept[tradeID] = true
+		}
+	}
+	for _, tradeID := range pruned {
+		if !kept[tradeID] {
+			delete(m.trades, tradeID)
+		}
+	}
+}
+
+func (m *OrderManager) oldestLive() (time.Time, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var oldest time.Time
+	for _, o := range m.orders {
+		if o.Status == types.OrderStatusLive && (oldest.IsZero() || o.CreatedAt.Before(oldest)) {
+			oldest = o.CreatedAt
+		}
+	}
+	return oldest, !oldest.IsZero()
+}
+
+// Run reconciles every Interval until ctx is done.
+func (m *OrderManager) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.Reconcile(ctx); err != nil && ctx.Err() == nil && m.opts.OnError != nil {
+			m.opts.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
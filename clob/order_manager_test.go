@@ -0,0 +1,182 @@
+package clob_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/override-coder/go-polymarket-sdk/clob"
+	"github.com/override-coder/go-polymarket-sdk/clob/clobtest"
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// newAccount returns a client of server for a new key with an API key.
+func newAccount(t *testing.T, server *clobtest.Server) (*clob.Client, *sdktypes.AuthOption) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	signer := signing.NewPrivateKeySigner(key)
+	client := clob.NewClient(server.URL, chaindId, signing.ToSignatureFunc(signer), nil)
+	option := &sdktypes.AuthOption{SignatureType: model.EOA, SingerAddress: signer.Address().Hex()}
+	_, err = client.EnsureAPIKey(context.Background(), big.NewInt(0), option)
+	assert.NoError(t, err)
+	return client, option
+}
+
+func TestOrderManager(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := newServer(t)
+	client, option := newAccount(t, server)
+	other, otherOption := newAccount(t, server)
+
+	var fills []clob.OrderFill
+	var canceled []clob.ManagedOrder
+	m := clob.NewOrderManager(client, option, clob.OrderManagerOptions{
+		OnFill:   func(f clob.OrderFill) { fills = append(fills, f) },
+		OnCancel: func(o clob.ManagedOrder) { canceled = append(canceled, o) },
+	})
+	order := func(side types.Side, price, size float64) types.UserOrder {
+		return types.UserOrder{TokenID: tokenID, Side: side, Price: price, Size: size}
+	}
+
+	bid, err := m.CreateOrder(ctx, order(types.BUY, 0.4, 10), types.OrderTypeGTC, false, nil)
+	assert.NoError(t, err)
+	ask, err := m.CreateOrder(ctx, order(types.SELL, 0.6, 5), types.OrderTypeGTC, false, nil)
+	assert.NoError(t, err)
+	assert.Len(t, m.Orders(clob.OrderFilter{}), 2)
+
+	// Filled by another account, canceled elsewhere and placed elsewhere.
+	_, err = other.CreateOrder(ctx, order(types.SELL, 0.4, 4), types.OrderTypeGTC, false, otherOption)
+	assert.NoError(t, err)
+	_, err = client.CancelOrder(ctx, ask.OrderID, option)
+	assert.NoError(t, err)
+	unknown, err := client.CreateOrder(ctx, order(types.BUY, 0.3, 5), types.OrderTypeGTC, false, option)
+	assert.NoError(t, err)
+
+	report, err := m.Reconcile(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, report.Unknown, 1) {
+		assert.Equal(t, unknown.OrderID, report.Unknown[0].ID)
+	}
+	if assert.Len(t, report.Orphaned, 1) {
+		assert.Equal(t, ask.OrderID, report.Orphaned[0].ID)
+	}
+	if assert.Len(t, fills, 1) {
+		assert.Equal(t, "4", fills[0].Size.String())
+		assert.Equal(t, "0.4", fills[0].Price.String())
+	}
+	assert.Len(t, canceled, 1)
+
+	o, ok := m.Order(bid.OrderID)
+	assert.True(t, ok)
+	assert.Equal(t, "0xc0", o.Market)
+	assert.Equal(t, "6", o.Remaining().String())
+	assert.Len(t, m.Orders(clob.OrderFilter{Market: "0xc0", Side: types.BUY}), 1)
+	assert.Empty(t, m.Orders(clob.OrderFilter{Side: types.SELL}))
+	assert.Len(t, m.Orders(clob.OrderFilter{All: true}), 2)
+
+	// The same fill seen again through trades and events is not counted twice.
+	_, err = m.GetTrades(ctx, types.GetTradesRequest{}, nil)
+	assert.NoError(t, err)
+	m.HandleOrderEvent(types.UserOrderEvent{ID: bid.OrderID, SizeMatched: "4", Type: "UPDATE"})
+	assert.Len(t, fills, 1)
+	m.HandleOrderEvent(types.UserOrderEvent{ID: bid.OrderID, SizeMatched: "10", Type: "UPDATE"})
+	if assert.Len(t, fills, 2) {
+		assert.Equal(t, "6", fills[1].Size.String())
+		assert.Equal(t, types.OrderStatusMatched, fills[1].Order.Status)
+	}
+
+	// Market buys are tracked in shares.
+	_, err = other.CreateOrder(ctx, order(types.SELL, 0.5, 10), types.OrderTypeGTC, false, otherOption)
+	assert.NoError(t, err)
+	resp, err := m.CreateOrder(ctx, order(types.BUY, 0.5, 2.5), types.OrderTypeFOK, false, nil)
+	assert.NoError(t, err)
+	o, _ = m.Order(resp.OrderID)
+	assert.Equal(t, types.OrderStatusMatched, o.Status)
+	assert.Equal(t, "5", o.SizeMatched.String())
+
+	live, err := m.CreateOrder(ctx, order(types.BUY, 0.2, 5), types.OrderTypeGTC, false, nil)
+	assert.NoError(t, err)
+	_, err = m.CancelOrders(ctx, []string{live.OrderID}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, canceled, 2)
+	m.HandleOrderEvent(types.UserOrderEvent{ID: live.OrderID, Type: "CANCELLATION"})
+	assert.Len(t, canceled, 2, "cancellations are reported once")
+}
+
+func TestOrderManagerRetention(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := newServer(t)
+	client, option := newAccount(t, server)
+	other, otherOption := newAccount(t, server)
+	now := time.Now()
+	m := clob.NewOrderManager(client, option, clob.OrderManagerOptions{Now: func() time.Time { return now }})
+	order := func(side types.Side, price, size float64) types.UserOrder {
+		return types.UserOrder{TokenID: tokenID, Side: side, Price: price, Size: size}
+	}
+
+	filled, err := m.CreateOrder(ctx, order(types.BUY, 0.4, 5), types.OrderTypeGTC, false, nil)
+	assert.NoError(t, err)
+	_, err = other.CreateOrder(ctx, order(types.SELL, 0.4, 5), types.OrderTypeGTC, false, otherOption)
+	assert.NoError(t, err)
+	canceled, err := m.CreateOrder(ctx, order(types.BUY, 0.3, 5), types.OrderTypeGTC, false, nil)
+	assert.NoError(t, err)
+	_, err = m.CancelOrder(ctx, canceled.OrderID, nil)
+	assert.NoError(t, err)
+	live, err := m.CreateOrder(ctx, order(types.BUY, 0.2, 5), types.OrderTypeGTC, false, nil)
+	assert.NoError(t, err)
+
+	_, err = m.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, m.Orders(clob.OrderFilter{All: true}), 3)
+
+	now = now.Add(25 * time.Hour)
+	_, err = m.Reconcile(ctx)
+	assert.NoError(t, err)
+	orders := m.Orders(clob.OrderFilter{All: true})
+	if assert.Len(t, orders, 1) {
+		assert.Equal(t, live.OrderID, orders[0].ID)
+	}
+	_, ok := m.Order(filled.OrderID)
+	assert.False(t, ok)
+}
+
+func TestOrderManagerRetentionSelfTrade(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := newServer(t)
+	client, option := newAccount(t, server)
+	now := time.Now()
+	var fills []clob.OrderFill
+	m := clob.NewOrderManager(client, option, clob.OrderManagerOptions{
+		OnFill: func(f clob.OrderFill) { fills = append(fills, f) },
+		Now:    func() time.Time { return now },
+	})
+
+	// The account's buy takes its own sell: one trade fills both orders.
+	sell, err := m.CreateOrder(ctx, types.UserOrder{TokenID: tokenID, Side: types.SELL, Price: 0.4, Size: 5}, types.OrderTypeGTC, false, nil)
+	assert.NoError(t, err)
+	buy, err := m.CreateOrder(ctx, types.UserOrder{TokenID: tokenID, Side: types.BUY, Price: 0.4, Size: 10}, types.OrderTypeGTC, false, nil)
+	assert.NoError(t, err)
+	_, err = m.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, fills, 2)
+
+	// The sell is forgotten, the trade still fills the live buy: fetching
+	// it again does not count it twice.
+	now = now.Add(25 * time.Hour)
+	for i := 0; i < 2; i++ {
+		_, err = m.Reconcile(ctx)
+		assert.NoError(t, err)
+	}
+	_, ok := m.Order(sell.OrderID)
+	assert.False(t, ok)
+	o, ok := m.Order(buy.OrderID)
+	assert.True(t, ok)
+	assert.Equal(t, "5", o.SizeMatched.String())
+	assert.Len(t, fills, 2)
+}
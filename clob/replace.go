@@ -0,0 +1,181 @@
+package clob
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/override-coder/go-polymarket-sdk/types/utils"
+	"github.com/pkg/errors"
+)
+
+// ReplaceOrder replaces the resting order orderID with userOrder, see
+// ReplaceOrders.
+func (c *Client) ReplaceOrder(ctx context.Context, orderID string, userOrder types.UserOrder, orderType types.OrderType, opts types.ReplaceOptions, option *sdktypes.AuthOption) (*types.ReplaceResult, error) {
+	results, err := c.ReplaceOrders(ctx, []types.ReplaceRequest{{OrderID: orderID, Order: userOrder, OrderType: orderType}}, opts, option)
+	if len(results) == 0 {
+		return nil, err
+	}
+	return &results[0], err
+}
+
+// ReplaceOrders cancels the orders of reqs in one request and posts their
+// replacements, or the other way around with opts.PostFirst. A replacement
+// is only posted once its old order is canceled, so a filled order is never
+// doubled, and with PostFirst only replaced orders are canceled.
+//
+// The results, in reqs order, tell the final state of each replacement. An
+// error means the cancel request failed and its outcome is unknown; the
+// results then hold what was done before it, and with PostFirst the posted
+// replacements are in ReplaceStateBoth.
+//
+// With opts.Rollback, an old order canceled without a replacement is read
+// again after the cancel and its unmatched remainder re-posted.
+func (c *Client) ReplaceOrders(ctx context.Context, reqs []types.ReplaceRequest, opts types.ReplaceOptions, option *sdktypes.AuthOption) ([]types.ReplaceResult, error) {
+	if len(reqs) == 0 {
+		return nil, errors.New("replace orders: no orders")
+	}
+	results := make([]types.ReplaceResult, len(reqs))
+	for i, req := range reqs {
+		if req.OrderID == "" {
+			return nil, errors.New("replace orders: order id is empty")
+		}
+		results[i].OrderID = req.OrderID
+	}
+
+	if opts.PostFirst {
+		var posted []string
+		for i, req := range reqs {
+			if c.postReplacement(ctx, req, &results[i], opts, option) {
+				posted = append(posted, req.OrderID)
+			}
+		}
+		var err error
+		if len(posted) > 0 {
+			err = c.cancelReplaced(ctx, posted, results, option)
+		}
+		for i := range results {
+			r := &results[i]
+			switch {
+			case r.Order == nil:
+				r.State = types.ReplaceStateUnchanged
+			case r.Canceled:
+				r.State = types.ReplaceStateReplaced
+			default:
+				r.State = types.ReplaceStateBoth
+			}
+		}
+		return results, err
+	}
+
+	ids := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		ids = append(ids, req.OrderID)
+	}
+	if err := c.cancelReplaced(ctx, ids, results, option); err != nil {
+		return results, err
+	}
+	for i, req := range reqs {
+		r := &results[i]
+		switch {
+		case !r.Canceled:
+			r.State = types.ReplaceStateUnchanged
+		case c.postReplacement(ctx, req, r, opts, option):
+			r.State = types.ReplaceStateReplaced
+		default:
+			r.State = types.ReplaceStateFlat
+			if opts.Rollback {
+				c.rollback(ctx, r, opts, option)
+			}
+		}
+	}
+	return results, nil
+}
+
+// postReplacement posts the new order of req and records the outcome in r.
+func (c *Client) postReplacement(ctx context.Context, req types.ReplaceRequest, r *types.ReplaceResult, opts types.ReplaceOptions, option *sdktypes.AuthOption) bool {
+	resp, err := c.CreateOrder(ctx, req.Order, req.OrderType, opts.DeferExec, option)
+	if msg := postError(resp, err); msg != "" {
+		r.OrderError = msg
+		return false
+	}
+	r.Order = resp
+	return true
+}
+
+// rollback re-posts the unmatched remainder of the canceled order of r. The
+// order is read after the cancel, so fills up to the cancel are not re-posted.
+func (c *Client) rollback(ctx context.Context, r *types.ReplaceResult, opts types.ReplaceOptions, option *sdktypes.AuthOption) {
+	original, err := c.GetOrder(ctx, r.OrderID, types.GetOrderRequest{}, option)
+	if err != nil {
+		r.RollbackError = errors.WithMessage(err, "get order").Error()
+		return
+	}
+	size := utils.StringToDecimal(original.OriginalSize).Sub(utils.StringToDecimal(original.SizeMatched))
+	if !size.IsPositive() {
+		return
+	}
+	userOrder := types.UserOrder{
+		TokenID: original.AssetID,
+		Price:   utils.StringToDecimal(original.Price).InexactFloat64(),
+		Size:    size.InexactFloat64(),
+		Side:    types.Side(original.Side),
+	}
+	orderType := types.OrderType(original.Type)
+	if orderType == "" {
+		orderType = types.OrderTypeGTC
+	}
+	if expiration, err := strconv.ParseInt(original.Expiration, 10, 64); err == nil && expiration > 0 {
+		userOrder.Expiration = &expiration
+	}
+	resp, err := c.CreateOrder(ctx, userOrder, orderType, opts.DeferExec, option)
+	if msg := postError(resp, err); msg != "" {
+		r.RollbackError = msg
+		return
+	}
+	r.Rollback = resp
+	r.State = types.ReplaceStateRolledBack
+}
+
+// cancelReplaced cancels ids and records the outcome in their results.
+func (c *Client) cancelReplaced(ctx context.Context, ids []string, results []types.ReplaceResult, option *sdktypes.AuthOption) error {
+	resp, err := c.CancelOrders(ctx, ids, option)
+	if err != nil {
+		return errors.WithMessage(err, "replace orders cancel")
+	}
+	requested := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		requested[id] = true
+	}
+	canceled := make(map[string]bool, len(resp.Canceled))
+	for _, id := range resp.Canceled {
+		canceled[id] = true
+	}
+	for i := range results {
+		r := &results[i]
+		if !requested[r.OrderID] {
+			continue
+		}
+		r.Canceled = canceled[r.OrderID]
+		if !r.Canceled {
+			r.CancelError = resp.NotCanceled[r.OrderID]
+			if r.CancelError == "" {
+				r.CancelError = "not canceled"
+			}
+		}
+	}
+	return nil
+}
+
+func postError(resp *types.OrderResponse, err error) string {
+	switch {
+	case err != nil:
+		return err.Error()
+	case !resp.Success && resp.ErrorMsg != "":
+		return resp.ErrorMsg
+	case !resp.Success:
+		return "order not placed"
+	}
+	return ""
+}
@@ -0,0 +1,173 @@
+package clob_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/override-coder/go-polymarket-sdk/clob"
+	"github.com/override-coder/go-polymarket-sdk/clob/clobtest"
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReplaceClient(t *testing.T) (*clob.Client, *sdktypes.AuthOption, func(price float64) string) {
+	_, client, authOption := newServer(t)
+	_, err := client.EnsureAPIKey(context.Background(), big.NewInt(0), authOption)
+	assert.NoError(t, err)
+	place := func(price float64) string {
+		resp, err := client.CreateOrder(context.Background(), bid(tokenID, price), types.OrderTypeGTC, false, authOption)
+		assert.NoError(t, err)
+		return resp.OrderID
+	}
+	return client, authOption, place
+}
+
+func bid(tokenID string, price float64) types.UserOrder {
+	return types.UserOrder{TokenID: tokenID, Price: price, Size: 10, Side: types.BUY}
+}
+
+func openPrices(t *testing.T, client *clob.Client, authOption *sdktypes.AuthOption) []string {
+	orders, err := client.GetOrders(context.Background(), types.GetActiveOrdersRequest{}, authOption)
+	assert.NoError(t, err)
+	var prices []string
+	for _, o := range orders.Data {
+		prices = append(prices, o.Price)
+	}
+	return prices
+}
+
+func TestReplaceOrder(t *testing.T) {
+	ctx := context.Background()
+	client, authOption, place := newReplaceClient(t)
+
+	old := place(0.4)
+	result, err := client.ReplaceOrder(ctx, old, bid(tokenID, 0.42), types.OrderTypeGTC, types.ReplaceOptions{}, authOption)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReplaceStateReplaced, result.State)
+	assert.True(t, result.Canceled)
+	assert.Equal(t, []string{"0.42"}, openPrices(t, client, authOption))
+
+	// The old order is gone, so nothing is posted.
+	result, err = client.ReplaceOrder(ctx, old, bid(tokenID, 0.43), types.OrderTypeGTC, types.ReplaceOptions{}, authOption)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReplaceStateUnchanged, result.State)
+	assert.NotEmpty(t, result.CancelError)
+	assert.Nil(t, result.Order)
+
+	// The new order fails after the cancel.
+	current := place(0.41)
+	result, err = client.ReplaceOrder(ctx, current, bid("1", 0.42), types.OrderTypeGTC, types.ReplaceOptions{}, authOption)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReplaceStateFlat, result.State)
+	assert.NotEmpty(t, result.OrderError)
+	assert.Equal(t, []string{"0.42"}, openPrices(t, client, authOption))
+
+	current = place(0.41)
+	result, err = client.ReplaceOrder(ctx, current, bid("1", 0.42), types.OrderTypeGTC, types.ReplaceOptions{Rollback: true}, authOption)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReplaceStateRolledBack, result.State)
+	assert.NotNil(t, result.Rollback)
+	assert.ElementsMatch(t, []string{"0.42", "0.41"}, openPrices(t, client, authOption))
+}
+
+func TestReplaceOrdersPostFirst(t *testing.T) {
+	ctx := context.Background()
+	client, authOption, place := newReplaceClient(t)
+	a, b := place(0.3), place(0.31)
+
+	results, err := client.ReplaceOrders(ctx, []types.ReplaceRequest{
+		{OrderID: a, Order: bid(tokenID, 0.35), OrderType: types.OrderTypeGTC},
+		{OrderID: b, Order: bid("1", 0.36), OrderType: types.OrderTypeGTC},
+		{OrderID: "missing", Order: bid(tokenID, 0.37), OrderType: types.OrderTypeGTC},
+	}, types.ReplaceOptions{PostFirst: true}, authOption)
+	assert.NoError(t, err)
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, types.ReplaceStateReplaced, results[0].State)
+		assert.Equal(t, types.ReplaceStateUnchanged, results[1].State, "the old order stays when the new one fails")
+		assert.False(t, results[1].Canceled)
+		assert.Equal(t, types.ReplaceStateBoth, results[2].State)
+		assert.Equal(t, "order not found", results[2].CancelError)
+	}
+	assert.ElementsMatch(t, []string{"0.35", "0.31", "0.37"}, openPrices(t, client, authOption))
+
+	_, err = client.ReplaceOrders(ctx, nil, types.ReplaceOptions{}, authOption)
+	assert.Error(t, err)
+}
+
+// newCancelProxy returns an account whose requests reach server through a
+// proxy that calls onCancel before forwarding a cancel of several orders, and
+// drops the request when it returns false.
+func newCancelProxy(t *testing.T, server *clobtest.Server, onCancel func(w http.ResponseWriter) bool) (*clob.Client, *sdktypes.AuthOption) {
+	target, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	forward := httputil.NewSingleHostReverseProxy(target)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete && r.URL.Path == types.CANCEL_ORDERS && !onCancel(w) {
+			return
+		}
+		forward.ServeHTTP(w, r)
+	}))
+	t.Cleanup(proxy.Close)
+
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	signer := signing.NewPrivateKeySigner(key)
+	client := clob.NewClient(proxy.URL, chaindId, signing.ToSignatureFunc(signer), nil)
+	option := &sdktypes.AuthOption{SignatureType: model.EOA, SingerAddress: signer.Address().Hex()}
+	_, err = client.EnsureAPIKey(context.Background(), big.NewInt(0), option)
+	assert.NoError(t, err)
+	return client, option
+}
+
+func TestReplaceOrderRollbackFilled(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := newServer(t)
+	other, otherOption := newAccount(t, server)
+	// 4 of the old order fill just before it is canceled.
+	client, option := newCancelProxy(t, server, func(http.ResponseWriter) bool {
+		_, err := other.CreateOrder(ctx, types.UserOrder{TokenID: tokenID, Price: 0.41, Size: 4, Side: types.SELL}, types.OrderTypeGTC, false, otherOption)
+		assert.NoError(t, err)
+		return true
+	})
+
+	old, err := client.CreateOrder(ctx, bid(tokenID, 0.41), types.OrderTypeGTC, false, option)
+	assert.NoError(t, err)
+	result, err := client.ReplaceOrder(ctx, old.OrderID, bid("1", 0.42), types.OrderTypeGTC, types.ReplaceOptions{Rollback: true}, option)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReplaceStateRolledBack, result.State)
+	orders, err := client.GetOrders(ctx, types.GetActiveOrdersRequest{}, option)
+	assert.NoError(t, err)
+	if assert.Len(t, orders.Data, 1) {
+		assert.Equal(t, "6", orders.Data[0].OriginalSize)
+	}
+}
+
+func TestReplaceOrdersPostFirstCancelFails(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := newServer(t)
+	client, option := newCancelProxy(t, server, func(w http.ResponseWriter) bool {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return false
+	})
+
+	old, err := client.CreateOrder(ctx, bid(tokenID, 0.3), types.OrderTypeGTC, false, option)
+	assert.NoError(t, err)
+	results, err := client.ReplaceOrders(ctx, []types.ReplaceRequest{
+		{OrderID: old.OrderID, Order: bid(tokenID, 0.35), OrderType: types.OrderTypeGTC},
+		{OrderID: "missing", Order: bid("1", 0.36), OrderType: types.OrderTypeGTC},
+	}, types.ReplaceOptions{PostFirst: true}, option)
+	assert.Error(t, err)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, types.ReplaceStateBoth, results[0].State)
+		assert.Equal(t, types.ReplaceStateUnchanged, results[1].State)
+	}
+}
@@ -0,0 +1,305 @@
+package clob
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// ErrKillSwitch is returned for orders placed while the kill switch of a
+// RiskClient is engaged.
+var ErrKillSwitch = errors.New("kill switch engaged")
+
+type RiskLimit string
+
+const (
+	RiskLimitOrderNotional RiskLimit = "order_notional"
+	RiskLimitOpenOrders    RiskLimit = "open_orders"
+	RiskLimitPosition      RiskLimit = "position"
+	RiskLimitDailyLoss     RiskLimit = "daily_loss"
+	RiskLimitOrderRate     RiskLimit = "order_rate"
+)
+
+// RiskError is returned for an order that would break Limit. Value is what
+// the order would bring the limited quantity to.
+type RiskError struct {
+	Limit   RiskLimit
+	TokenID string
+	Value   decimal.Decimal
+	Max     decimal.Decimal
+}
+
+func (e *RiskError) Error() string {
+	return fmt.Sprintf("risk limit %s for token %s: %s, max %s", e.Limit, e.TokenID, e.Value, e.Max)
+}
+
+// RiskOptions are the limits of a RiskClient; zero values disable a limit.
+// Amounts are in USDC and sizes in shares.
+type RiskOptions struct {
+	MaxOrderNotional decimal.Decimal
+	// MaxOpenOrders limits the live orders per token.
+	MaxOpenOrders int
+	// MaxPosition limits the shares held per token, counting live buys. The
+	// shares held are the manager's Position.
+	MaxPosition decimal.Decimal
+	// MaxDailyLoss limits the loss of the orders placed since 00:00 UTC.
+	MaxDailyLoss decimal.Decimal
+	// MaxOrderRate limits the orders placed per RateInterval, default 1s.
+	MaxOrderRate int
+	RateInterval time.Duration
+	// Mark prices a token to value positions for the daily loss. It defaults
+	// to the price of the last fill of the token.
+	Mark func(tokenID string) (decimal.Decimal, bool)
+}
+
+// RiskClient checks orders against RiskOptions before they are signed and
+// posted through an OrderManager, whose orders and fills it reads for open
+// orders, positions and losses. Positions and losses only count the orders
+// of the manager.
+//
+// Orders are checked and placed one at a time per limit: an order being
+// placed counts against the open orders and position of its token until the
+// manager tracks it. The fill and cancel callbacks of the manager run after
+// that, so they may call Kill.
+//
+// Kill cancels every order of the account and rejects further orders with
+// ErrKillSwitch until Reset.
+type RiskClient struct {
+	manager *OrderManager
+	opts    RiskOptions
+
+	mu      sync.Mutex
+	placed  *sync.Cond // signaled when a placement ends
+	killed  bool
+	sent    []time.Time // times of the orders of the current rate interval
+	placing map[*placement]bool
+}
+
+// placement is an order that passed the checks and is being placed.
+type placement struct {
+	tokenID string
+	open    bool            // the order can rest on the book
+	bought  decimal.Decimal // shares the order can buy
+}
+
+var _ OrderClient = (*RiskClient)(nil)
+
+func NewRiskClient(manager *OrderManager, opts RiskOptions) *RiskClient {
+	if opts.RateInterval <= 0 {
+		opts.RateInterval = time.Second
+	}
+	r := &RiskClient{manager: manager, opts: opts, placing: make(map[*placement]bool)}
+	r.placed = sync.NewCond(&r.mu)
+	return r
+}
+
+func (r *RiskClient) Manager() *OrderManager {
+	return r.manager
+}
+
+// Kill engages the kill switch, waits for the orders being placed and
+// cancels every order of the account. The switch stays engaged when the
+// cancel fails.
+func (r *RiskClient) Kill(ctx context.Context, option *sdktypes.AuthOption) (*types.CancelOrder, error) {
+	r.mu.Lock()
+	r.killed = true
+	for len(r.placing) > 0 {
+		r.placed.Wait()
+	}
+	r.mu.Unlock()
+	resp, err := r.manager.CancelOrderAll(ctx, option)
+	if err != nil {
+		return nil, errors.WithMessage(err, "kill switch")
+	}
+	return resp, nil
+}
+
+// Reset disengages the kill switch.
+func (r *RiskClient) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.killed = false
+}
+
+func (r *RiskClient) Killed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.killed
+}
+
+// CreateOrder places the order through the manager if it breaks no limit,
+// and returns ErrKillSwitch or a *RiskError otherwise.
+func (r *RiskClient) CreateOrder(ctx context.Context, userOrder types.UserOrder, orderType types.OrderType, deferExec bool, option *sdktypes.AuthOption) (*types.OrderResponse, error) {
+	p, err := r.check(userOrder, orderType)
+	if err != nil {
+		return nil, err
+	}
+	resp, fills, canceled, err := r.manager.createOrder(ctx, userOrder, orderType, deferExec, option)
+	r.release(p)
+	r.manager.notify(fills, canceled)
+	return resp, err
+}
+
+// check checks userOrder and, when it passes, counts it against the rate and
+// returns its placement, to release once the manager tracks the order.
+func (r *RiskClient) check(userOrder types.UserOrder, orderType types.OrderType) (*placement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.killed {
+		return nil, ErrKillSwitch
+	}
+	price := decimal.NewFromFloat(userOrder.Price)
+	size := decimal.NewFromFloat(userOrder.Size)
+	market := orderType == types.OrderTypeFOK || orderType == types.OrderTypeFAK
+	notional, shares := price.Mul(size), size
+	if market && userOrder.Side == types.BUY {
+		// Market buys are sized in USDC.
+		notional = size
+		if price.IsPositive() {
+			shares = size.Div(price)
+		}
+	}
+	limitError := func(limit RiskLimit, value, max decimal.Decimal) (*placement, error) {
+		return nil, &RiskError{Limit: limit, TokenID: userOrder.TokenID, Value: value, Max: max}
+	}
+	p := &placement{tokenID: userOrder.TokenID, open: !market}
+	if userOrder.Side == types.BUY {
+		p.bought = shares
+	}
+
+	if max := r.opts.MaxOrderNotional; max.IsPositive() && notional.GreaterThan(max) {
+		return limitError(RiskLimitOrderNotional, notional, max)
+	}
+
+	live := r.manager.Orders(OrderFilter{AssetID: userOrder.TokenID})
+	if max := r.opts.MaxOpenOrders; max > 0 && p.open {
+		open := len(live)
+		for other := range r.placing {
+			if other.tokenID == p.tokenID && other.open {
+				open++
+			}
+		}
+		if open+1 > max {
+			return limitError(RiskLimitOpenOrders, decimal.NewFromInt(int64(open+1)), decimal.NewFromInt(int64(max)))
+		}
+	}
+	if max := r.opts.MaxPosition; max.IsPositive() && userOrder.Side == types.BUY {
+		position := shares.Add(r.manager.Position(p.tokenID))
+		for other := range r.placing {
+			if other.tokenID == p.tokenID {
+				position = position.Add(other.bought)
+			}
+		}
+		for _, o := range live {
+			if o.Side == types.BUY {
+				position = position.Add(o.Remaining())
+			}
+		}
+		if position.GreaterThan(max) {
+			return limitError(RiskLimitPosition, position, max)
+		}
+	}
+	if max := r.opts.MaxDailyLoss; max.IsPositive() {
+		if loss := r.dailyLoss(); loss.GreaterThanOrEqual(max) {
+			return limitError(RiskLimitDailyLoss, loss, max)
+		}
+	}
+
+	if max := r.opts.MaxOrderRate; max > 0 {
+		now := r.manager.opts.Now()
+		since := now.Add(-r.opts.RateInterval)
+		kept := r.sent[:0]
+		for _, t := range r.sent {
+			if t.After(since) {
+				kept = append(kept, t)
+			}
+		}
+		r.sent = kept
+		if len(r.sent)+1 > max {
+			return limitError(RiskLimitOrderRate, decimal.NewFromInt(int64(len(r.sent)+1)), decimal.NewFromInt(int64(max)))
+		}
+		r.sent = append(r.sent, now)
+	}
+	r.placing[p] = true
+	return p, nil
+}
+
+// release ends the placement p.
+func (r *RiskClient) release(p *placement) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.placing, p)
+	r.placed.Broadcast()
+}
+
+// dailyLoss is the loss of the fills of the orders placed today, with the
+// shares they leave marked at Mark or the last fill price. Fills are valued
+// at the order price, which is never better than the fill price.
+func (r *RiskClient) dailyLoss() decimal.Decimal {
+	day := r.manager.opts.Now().UTC().Truncate(24 * time.Hour)
+	type book struct {
+		cash, shares, last decimal.Decimal
+		at                 time.Time
+	}
+	books := make(map[string]*book)
+	for _, o := range r.manager.Orders(OrderFilter{All: true}) {
+		if o.CreatedAt.Before(day) || !o.SizeMatched.IsPositive() {
+			continue
+		}
+		b, ok := books[o.AssetID]
+		if !ok {
+			b = &book{}
+			books[o.AssetID] = b
+		}
+		value := o.SizeMatched.Mul(o.Price)
+		if o.Side == types.BUY {
+			b.cash, b.shares = b.cash.Sub(value), b.shares.Add(o.SizeMatched)
+		} else {
+			b.cash, b.shares = b.cash.Add(value), b.shares.Sub(o.SizeMatched)
+		}
+		if !o.UpdatedAt.Before(b.at) {
+			b.last, b.at = o.Price, o.UpdatedAt
+		}
+	}
+	pnl := decimal.Zero
+	for tokenID, b := range books {
+		mark := b.last
+		if r.opts.Mark != nil {
+			if price, ok := r.opts.Mark(tokenID); ok {
+				mark = price
+			}
+		}
+		pnl = pnl.Add(b.cash).Add(b.shares.Mul(mark))
+	}
+	return pnl.Neg()
+}
+
+func (r *RiskClient) CancelOrder(ctx context.Context, orderId string, option *sdktypes.AuthOption) (*types.CancelOrder, error) {
+	return r.manager.CancelOrder(ctx, orderId, option)
+}
+
+func (r *RiskClient) CancelOrders(ctx context.Context, orderId []string, option *sdktypes.AuthOption) (*types.CancelOrder, error) {
+	return r.manager.CancelOrders(ctx, orderId, option)
+}
+
+func (r *RiskClient) CancelOrderAll(ctx context.Context, option *sdktypes.AuthOption) (*types.CancelOrder, error) {
+	return r.manager.CancelOrderAll(ctx, option)
+}
+
+func (r *RiskClient) GetOrders(ctx context.Context, req types.GetActiveOrdersRequest, option *sdktypes.AuthOption) (*types.OpenOrders, error) {
+	return r.manager.GetOrders(ctx, req, option)
+}
+
+func (r *RiskClient) GetTrades(ctx context.Context, req types.GetTradesRequest, option *sdktypes.AuthOption) (*types.Trades, error) {
+	return r.manager.GetTrades(ctx, req, option)
+}
+
+func (r *RiskClient) GetBalanceAllowance(option *sdktypes.AuthOption) (*types.BalanceAllowanceResponse, error) {
+	return r.manager.GetBalanceAllowance(option)
+}
@@ -0,0 +1,226 @@
+package clob_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/clob"
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRiskClient(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := newServer(t)
+	client, option := newAccount(t, server)
+	other, otherOption := newAccount(t, server)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	manager := clob.NewOrderManager(client, option, clob.OrderManagerOptions{Now: func() time.Time { return now }})
+	risk := clob.NewRiskClient(manager, clob.RiskOptions{
+		MaxOrderNotional: decimal.NewFromInt(10),
+		MaxOpenOrders:    2,
+		MaxPosition:      decimal.NewFromInt(25),
+		MaxDailyLoss:     decimal.NewFromInt(1),
+		MaxOrderRate:     3,
+	})
+	order := func(side types.Side, price, size float64) types.UserOrder {
+		return types.UserOrder{TokenID: tokenID, Side: side, Price: price, Size: size}
+	}
+	rejected := func(limit clob.RiskLimit, err error) {
+		t.Helper()
+		var riskErr *clob.RiskError
+		if assert.True(t, errors.As(err, &riskErr), "%v", err) {
+			assert.Equal(t, limit, riskErr.Limit)
+		}
+	}
+
+	_, err := risk.CreateOrder(ctx, order(types.BUY, 0.5, 30), types.OrderTypeGTC, false, nil)
+	rejected(clob.RiskLimitOrderNotional, err)
+	_, err = risk.CreateOrder(ctx, order(types.BUY, 0.5, 11), types.OrderTypeFOK, false, nil)
+	rejected(clob.RiskLimitOrderNotional, err)
+
+	for _, price := range []float64{0.3, 0.2} {
+		_, err = risk.CreateOrder(ctx, order(types.BUY, price, 10), types.OrderTypeGTC, false, nil)
+		assert.NoError(t, err)
+	}
+	_, err = risk.CreateOrder(ctx, order(types.BUY, 0.1, 10), types.OrderTypeGTC, false, nil)
+	rejected(clob.RiskLimitOpenOrders, err)
+	_, err = risk.CreateOrder(ctx, order(types.BUY, 0.5, 3), types.OrderTypeFOK, false, nil)
+	rejected(clob.RiskLimitPosition, err)
+	_, err = risk.CreateOrder(ctx, order(types.SELL, 0.9, 1), types.OrderTypeFOK, false, nil)
+	assert.Error(t, err, "unfilled")
+	assert.False(t, errors.As(err, new(*clob.RiskError)), "the rejected orders are not counted against the rate")
+	_, err = risk.CreateOrder(ctx, order(types.SELL, 0.9, 1), types.OrderTypeFOK, false, nil)
+	rejected(clob.RiskLimitOrderRate, err)
+	now = now.Add(time.Second)
+
+	// Killing cancels every order, also those placed elsewhere.
+	_, err = client.CreateOrder(ctx, order(types.BUY, 0.1, 10), types.OrderTypeGTC, false, option)
+	assert.NoError(t, err)
+	resp, err := risk.Kill(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Canceled, 3)
+	assert.Empty(t, manager.Orders(clob.OrderFilter{}))
+	_, err = risk.CreateOrder(ctx, order(types.BUY, 0.1, 1), types.OrderTypeGTC, false, nil)
+	assert.ErrorIs(t, err, clob.ErrKillSwitch)
+	open, err := client.GetOrders(ctx, types.GetActiveOrdersRequest{}, option)
+	assert.NoError(t, err)
+	assert.Empty(t, open.Data, "killed orders are not posted")
+	risk.Reset()
+
+	// Buying 10 at 0.6 and selling them at 0.4 loses 2.
+	_, err = other.CreateOrder(ctx, order(types.SELL, 0.6, 10), types.OrderTypeGTC, false, otherOption)
+	assert.NoError(t, err)
+	_, err = risk.CreateOrder(ctx, order(types.BUY, 0.6, 6), types.OrderTypeFOK, false, nil)
+	assert.NoError(t, err)
+	_, err = other.CreateOrder(ctx, order(types.BUY, 0.4, 10), types.OrderTypeGTC, false, otherOption)
+	assert.NoError(t, err)
+	_, err = risk.CreateOrder(ctx, order(types.SELL, 0.4, 10), types.OrderTypeFOK, false, nil)
+	assert.NoError(t, err)
+	_, err = risk.CreateOrder(ctx, order(types.BUY, 0.1, 1), types.OrderTypeGTC, false, nil)
+	rejected(clob.RiskLimitDailyLoss, err)
+
+	now = now.Add(24 * time.Hour)
+	_, err = risk.CreateOrder(ctx, order(types.BUY, 0.1, 1), types.OrderTypeGTC, false, nil)
+	assert.NoError(t, err, "the daily loss resets at midnight")
+}
+
+// blockingClient holds every order it places until release is closed, then
+// places them one at a time.
+type blockingClient struct {
+	*clob.Client
+	mu      sync.Mutex
+	placing chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingClient) CreateOrder(ctx context.Context, userOrder types.UserOrder, orderType types.OrderType, deferExec bool, option *sdktypes.AuthOption) (*types.OrderResponse, error) {
+	c.placing <- struct{}{}
+	<-c.release
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Client.CreateOrder(ctx, userOrder, orderType, deferExec, option)
+}
+
+func TestRiskClientConcurrent(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := newServer(t)
+	client, option := newAccount(t, server)
+	blocking := &blockingClient{Client: client, placing: make(chan struct{}, 10), release: make(chan struct{})}
+	manager := clob.NewOrderManager(blocking, option, clob.OrderManagerOptions{})
+	risk := clob.NewRiskClient(manager, clob.RiskOptions{MaxOpenOrders: 3, MaxPosition: decimal.NewFromInt(25)})
+
+	// Orders being placed count against the limits of the next ones.
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := risk.CreateOrder(ctx, types.UserOrder{TokenID: tokenID, Side: types.BUY, Price: 0.1, Size: 10}, types.OrderTypeGTC, false, nil)
+			errs <- err
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		<-blocking.placing
+	}
+
+	// Kill waits for the orders being placed, then cancels them.
+	killed := make(chan *types.CancelOrder)
+	go func() {
+		resp, err := risk.Kill(ctx, nil)
+		assert.NoError(t, err)
+		killed <- resp
+	}()
+	assert.Eventually(t, risk.Killed, time.Second, time.Millisecond)
+	select {
+	case <-killed:
+		t.Fatal("kill did not wait for the orders being placed")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(blocking.release)
+	wg.Wait()
+	close(errs)
+
+	placed := 0
+	for err := range errs {
+		if err == nil {
+			placed++
+			continue
+		}
+		var riskErr *clob.RiskError
+		if !errors.Is(err, clob.ErrKillSwitch) && assert.True(t, errors.As(err, &riskErr), "%v", err) {
+			assert.Equal(t, clob.RiskLimitPosition, riskErr.Limit)
+		}
+	}
+	assert.Equal(t, 2, placed)
+	resp := <-killed
+	assert.Len(t, resp.Canceled, 2)
+	assert.Empty(t, manager.Orders(clob.OrderFilter{}))
+}
+
+func TestRiskClientKillOnFill(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := newServer(t)
+	client, option := newAccount(t, server)
+	other, otherOption := newAccount(t, server)
+	var risk *clob.RiskClient
+	killed := make(chan error, 1)
+	manager := clob.NewOrderManager(client, option, clob.OrderManagerOptions{
+		OnFill: func(clob.OrderFill) {
+			_, err := risk.Kill(ctx, nil)
+			killed <- err
+		},
+	})
+	risk = clob.NewRiskClient(manager, clob.RiskOptions{})
+
+	_, err := other.CreateOrder(ctx, types.UserOrder{TokenID: tokenID, Side: types.SELL, Price: 0.5, Size: 5}, types.OrderTypeGTC, false, otherOption)
+	assert.NoError(t, err)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := risk.CreateOrder(ctx, types.UserOrder{TokenID: tokenID, Side: types.BUY, Price: 0.5, Size: 5}, types.OrderTypeGTC, false, nil)
+		assert.NoError(t, err)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Kill from OnFill deadlocked")
+	}
+	assert.NoError(t, <-killed)
+	assert.True(t, risk.Killed())
+}
+
+func TestRiskClientPositionAfterRetention(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := newServer(t)
+	client, option := newAccount(t, server)
+	other, otherOption := newAccount(t, server)
+	now := time.Now()
+	manager := clob.NewOrderManager(client, option, clob.OrderManagerOptions{Now: func() time.Time { return now }})
+	risk := clob.NewRiskClient(manager, clob.RiskOptions{MaxPosition: decimal.NewFromInt(10)})
+	buy := types.UserOrder{TokenID: tokenID, Side: types.BUY, Price: 0.5, Size: 6}
+
+	_, err := other.CreateOrder(ctx, types.UserOrder{TokenID: tokenID, Side: types.SELL, Price: 0.5, Size: 6}, types.OrderTypeGTC, false, otherOption)
+	assert.NoError(t, err)
+	_, err = risk.CreateOrder(ctx, buy, types.OrderTypeGTC, false, nil)
+	assert.NoError(t, err)
+
+	// The filled buy is forgotten, the shares it bought still count.
+	now = now.Add(25 * time.Hour)
+	_, err = manager.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, manager.Orders(clob.OrderFilter{All: true}))
+	assert.Equal(t, "6", manager.Position(tokenID).String())
+	_, err = risk.CreateOrder(ctx, buy, types.OrderTypeGTC, false, nil)
+	var riskErr *clob.RiskError
+	if assert.True(t, errors.As(err, &riskErr), "%v", err) {
+		assert.Equal(t, clob.RiskLimitPosition, riskErr.Limit)
+		assert.Equal(t, "12", riskErr.Value.String())
+	}
+}
@@ -1,6 +1,26 @@
 package types
 
+import "github.com/polymarket/go-order-utils/pkg/model"
+
 var (
 	CollateralTokenDecimals  = uint8(6)
 	ConditionalTokenDecimals = uint8(6)
 )
+
+// POLY_1271 is the signature type of orders signed by a contract wallet (e.g. a
+// multi-owner Safe) and checked by the exchange through EIP-1271; maker and
+// signer are both the contract. go-order-utils only defines 0-2.
+const POLY_1271 model.SignatureType = 3
+
+const (
+	INITIAL_CURSOR = "MA=="
+	// END_CURSOR is the next_cursor of the last page.
+	END_CURSOR = "LTE="
+)
+
+// Statuses of OpenOrder.Status.
+const (
+	OrderStatusLive     = "LIVE"
+	OrderStatusMatched  = "MATCHED"
+	OrderStatusCanceled = "CANCELED"
+)
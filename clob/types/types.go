@@ -178,6 +178,8 @@ type GetActiveOrdersRequest struct {
 	ID      string `json:"id,omitempty" url:"id,omitempty"`
 	Market  string `json:"market,omitempty" url:"market,omitempty"`
 	AssetID string `json:"asset_id,omitempty" url:"asset_id,omitempty"`
+	// NextCursor is the cursor returned by the previous page.
+	NextCursor string `json:"next_cursor,omitempty" url:"next_cursor,omitempty"`
 }
 
 // OpenOrders 响应体
@@ -188,6 +190,10 @@ type OpenOrders struct {
 	Count      int         `json:"count"`
 }
 
+type BookParams struct {
+	TokenId string `json:"token_id"`
+}
+
 type PricesRequest struct {
 	TokenId string `json:"token_id"`
 	Side    string `json:"side"`
@@ -203,6 +209,70 @@ type CancelOrder struct {
 	NotCanceled map[string]string `json:"not_canceled"`
 }
 
+// UserOrderEvent is an order message of the CLOB user WebSocket channel.
+// Trade messages of the channel decode into Trade.
+type UserOrderEvent struct {
+	EventType    string `json:"event_type"` // "order"
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	Market       string `json:"market"`
+	AssetID      string `json:"asset_id"`
+	Side         string `json:"side"`
+	Price        string `json:"price"`
+	OriginalSize string `json:"original_size"`
+	SizeMatched  string `json:"size_matched"`
+	Type         string `json:"type"` // PLACEMENT, UPDATE or CANCELLATION
+	Timestamp    string `json:"timestamp"`
+}
+
+// ReplaceOptions configures Client.ReplaceOrder and ReplaceOrders.
+type ReplaceOptions struct {
+	// PostFirst posts the new order before canceling the old one, so the
+	// quote never leaves the book. The balance must cover both orders.
+	PostFirst bool
+	// Rollback re-posts the unmatched remainder of the old order when it was
+	// canceled but the new order failed. It does not apply to PostFirst.
+	Rollback  bool
+	DeferExec bool
+}
+
+// ReplaceRequest replaces the order OrderID with Order.
+type ReplaceRequest struct {
+	OrderID   string
+	Order     UserOrder
+	OrderType OrderType
+}
+
+type ReplaceState string
+
+const (
+	// ReplaceStateReplaced: the old order is canceled and the new one posted.
+	ReplaceStateReplaced ReplaceState = "replaced"
+	// ReplaceStateUnchanged: the old order was not canceled, e.g. because it
+	// already filled, and no new order was posted.
+	ReplaceStateUnchanged ReplaceState = "unchanged"
+	// ReplaceStateBoth: with PostFirst, the new order was posted but the old
+	// one could not be canceled.
+	ReplaceStateBoth ReplaceState = "both"
+	// ReplaceStateRolledBack: the old order was canceled, the new one failed
+	// and the old one was re-posted.
+	ReplaceStateRolledBack ReplaceState = "rolled_back"
+	// ReplaceStateFlat: the old order was canceled and nothing replaced it.
+	ReplaceStateFlat ReplaceState = "flat"
+)
+
+// ReplaceResult is the outcome of replacing OrderID.
+type ReplaceResult struct {
+	OrderID       string         `json:"orderID"`
+	State         ReplaceState   `json:"state"`
+	Canceled      bool           `json:"canceled"`
+	CancelError   string         `json:"cancelError,omitempty"`
+	Order         *OrderResponse `json:"order,omitempty"` // the new order, nil if it failed
+	OrderError    string         `json:"orderError,omitempty"`
+	Rollback      *OrderResponse `json:"rollback,omitempty"`
+	RollbackError string         `json:"rollbackError,omitempty"`
+}
+
 type BalanceAllowanceResponse struct {
 	Balance    string `json:"balance"`
 	Allowances struct {
@@ -249,3 +319,20 @@ type Trades struct {
 	Limit      int     `json:"limit"`
 	Count      int     `json:"count"`
 }
+
+type PricesHistoryRequest struct {
+	Market   string  // token ID
+	StartTs  *int64  // unix seconds, with EndTs; exclusive with Interval
+	EndTs    *int64  // unix seconds
+	Interval *string // 1m, 1h, 6h, 1d, 1w or max
+	Fidelity *int    // resolution in minutes
+}
+
+type PriceHistoryPoint struct {
+	T int64   `json:"t"`
+	P float64 `json:"p"`
+}
+
+type PricesHistory struct {
+	History []PriceHistoryPoint `json:"history"`
+}
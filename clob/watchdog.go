@@ -0,0 +1,226 @@
+package clob
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// Canceler cancels orders in bulk; Client implements it.
+type Canceler interface {
+	CancelOrderAll(ctx context.Context, option *sdktypes.AuthOption) (*types.CancelOrder, error)
+	CancelOrderByMarket(ctx context.Context, request *types.CancelOrderRequest, option *sdktypes.AuthOption) (*types.CancelOrder, error)
+}
+
+var _ Canceler = (*Client)(nil)
+
+type WatchdogReason string
+
+const (
+	WatchdogMissedHeartbeat WatchdogReason = "missed_heartbeat"
+	WatchdogDisconnected    WatchdogReason = "disconnected"
+	WatchdogManual          WatchdogReason = "manual"
+)
+
+type WatchdogOptions struct {
+	// Timeout is the longest time between two Feed calls, default 30s.
+	Timeout time.Duration
+	// DisconnectTimeout is the longest time the market WebSocket may stay
+	// disconnected, default Timeout.
+	DisconnectTimeout time.Duration
+	// Markets restricts the cancel to these condition IDs. By default every
+	// order of the account is canceled.
+	Markets []string
+	// Retries of a failed cancel, default 3, RetryDelay apart, default 1s.
+	Retries    int
+	RetryDelay time.Duration
+	// Interval between the checks of Run, default 1s.
+	Interval time.Duration
+	// OnTrip receives the report of every cancel.
+	OnTrip func(WatchdogReport)
+	Now    func() time.Time
+}
+
+// WatchdogReport is the outcome of a watchdog cancel. Err is the last error
+// of the markets, or of the account, that could not be canceled.
+type WatchdogReport struct {
+	Reason      WatchdogReason
+	At          time.Time
+	Attempts    int
+	Canceled    []string
+	NotCanceled map[string]string
+	Err         error
+}
+
+// Watchdog is a dead man's switch for the orders of one account. The
+// strategy calls Feed periodically, and the market WebSocket reports its
+// state through Connected and Disconnected. When Feed is not called for
+// Timeout, or the socket stays down for DisconnectTimeout, the watchdog
+// cancels the orders of the account, or of its Markets, with retries.
+//
+// After a successful cancel the watchdog stays quiet until fed again; a
+// failed cancel is tried again on the next check. Only one cancel runs at a
+// time.
+type Watchdog struct {
+	client Canceler
+	option *sdktypes.AuthOption
+	opts   WatchdogOptions
+
+	mu           sync.Mutex
+	fed          time.Time
+	disconnected time.Time // zero while connected
+	tripped      bool
+	tripping     bool // a cancel is running
+}
+
+func NewWatchdog(client Canceler, option *sdktypes.AuthOption, opts WatchdogOptions) *Watchdog {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	if opts.DisconnectTimeout <= 0 {
+		opts.DisconnectTimeout = opts.Timeout
+	}
+	if opts.Retries <= 0 {
+		opts.Retries = 3
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = time.Second
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Watchdog{client: client, option: option, opts: opts, fed: opts.Now()}
+}
+
+// Feed records a heartbeat of the strategy and re-arms a tripped watchdog.
+func (w *Watchdog) Feed() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.fed = w.opts.Now()
+	w.tripped = false
+}
+
+// Disconnected records that the market WebSocket went down.
+func (w *Watchdog) Disconnected() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.disconnected.IsZero() {
+		w.disconnected = w.opts.Now()
+	}
+}
+
+// Connected records that the market WebSocket is up again.
+func (w *Watchdog) Connected() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.disconnected = time.Time{}
+}
+
+// Check cancels the orders if a deadline passed, and returns the report of
+// the cancel, or nil when the watchdog did not trip or a cancel is already
+// running.
+func (w *Watchdog) Check(ctx context.Context) *WatchdogReport {
+	w.mu.Lock()
+	now := w.opts.Now()
+	var reason WatchdogReason
+	switch {
+	case w.tripped || w.tripping:
+	case now.Sub(w.fed) >= w.opts.Timeout:
+		reason = WatchdogMissedHeartbeat
+	case !w.disconnected.IsZero() && now.Sub(w.disconnected) >= w.opts.DisconnectTimeout:
+		reason = WatchdogDisconnected
+	}
+	if reason == "" {
+		w.mu.Unlock()
+		return nil
+	}
+	w.tripping = true
+	w.mu.Unlock()
+	return w.trip(ctx, reason)
+}
+
+// Trip cancels the orders now, and returns nil when a cancel is already
+// running.
+func (w *Watchdog) Trip(ctx context.Context, reason WatchdogReason) *WatchdogReport {
+	w.mu.Lock()
+	if w.tripping {
+		w.mu.Unlock()
+		return nil
+	}
+	w.tripping = true
+	w.mu.Unlock()
+	return w.trip(ctx, reason)
+}
+
+// trip runs the cancel claimed by setting tripping.
+func (w *Watchdog) trip(ctx context.Context, reason WatchdogReason) *WatchdogReport {
+	report := &WatchdogReport{Reason: reason, At: w.opts.Now(), NotCanceled: map[string]string{}}
+	if len(w.opts.Markets) == 0 {
+		w.cancel(ctx, report, func() (*types.CancelOrder, error) {
+			return w.client.CancelOrderAll(ctx, w.option)
+		})
+	}
+	for _, market := range w.opts.Markets {
+		req := &types.CancelOrderRequest{ConditionID: &market}
+		w.cancel(ctx, report, func() (*types.CancelOrder, error) {
+			return w.client.CancelOrderByMarket(ctx, req, w.option)
+		})
+	}
+
+	w.mu.Lock()
+	w.tripping = false
+	if report.Err == nil {
+		w.tripped = true
+	}
+	w.mu.Unlock()
+	if w.opts.OnTrip != nil {
+		w.opts.OnTrip(*report)
+	}
+	return report
+}
+
+// cancel runs fn until it succeeds or the retries are spent.
+func (w *Watchdog) cancel(ctx context.Context, report *WatchdogReport, fn func() (*types.CancelOrder, error)) {
+	var err error
+	for attempt := 0; attempt <= w.opts.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				report.Err = errors.WithMessage(ctx.Err(), "watchdog cancel")
+				return
+			case <-time.After(w.opts.RetryDelay):
+			}
+		}
+		report.Attempts++
+		var resp *types.CancelOrder
+		if resp, err = fn(); err == nil {
+			report.Canceled = append(report.Canceled, resp.Canceled...)
+			for id, msg := range resp.NotCanceled {
+				report.NotCanceled[id] = msg
+			}
+			return
+		}
+	}
+	report.Err = errors.WithMessage(err, "watchdog cancel")
+}
+
+// Run checks every Interval until ctx is done.
+func (w *Watchdog) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			w.Check(ctx)
+		}
+	}
+}
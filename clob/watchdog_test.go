@@ -0,0 +1,156 @@
+package clob_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/clob"
+	"github.com/override-coder/go-polymarket-sdk/clob/clobtest"
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyCanceler fails the first fails cancels.
+type flakyCanceler struct {
+	*clob.Client
+	fails int
+}
+
+func (c *flakyCanceler) CancelOrderAll(ctx context.Context, option *sdktypes.AuthOption) (*types.CancelOrder, error) {
+	if c.fails > 0 {
+		c.fails--
+		return nil, errors.New("connection reset")
+	}
+	return c.Client.CancelOrderAll(ctx, option)
+}
+
+func TestWatchdog(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := newServer(t)
+	server.AddMarket(clobtest.Market{TokenID: "2002", ConditionID: "0xc1", Outcome: "Yes"})
+	client, option := newAccount(t, server)
+	other, otherOption := newAccount(t, server)
+	place := func(client *clob.Client, option *sdktypes.AuthOption, tokenID string) string {
+		resp, err := client.CreateOrder(ctx, types.UserOrder{TokenID: tokenID, Side: types.BUY, Price: 0.2, Size: 10}, types.OrderTypeGTC, false, option)
+		assert.NoError(t, err)
+		return resp.OrderID
+	}
+	live := func(client *clob.Client, option *sdktypes.AuthOption) int {
+		open, err := client.GetOrders(ctx, types.GetActiveOrdersRequest{}, option)
+		assert.NoError(t, err)
+		return len(open.Data)
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	canceler := &flakyCanceler{Client: client, fails: 2}
+	var reports []clob.WatchdogReport
+	w := clob.NewWatchdog(canceler, option, clob.WatchdogOptions{
+		Timeout:           10 * time.Second,
+		DisconnectTimeout: 5 * time.Second,
+		RetryDelay:        time.Millisecond,
+		OnTrip:            func(r clob.WatchdogReport) { reports = append(reports, r) },
+		Now:               func() time.Time { return now },
+	})
+	id := place(client, option, tokenID)
+	place(other, otherOption, tokenID)
+
+	now = now.Add(9 * time.Second)
+	assert.Nil(t, w.Check(ctx))
+	w.Feed()
+	now = now.Add(9 * time.Second)
+	assert.Nil(t, w.Check(ctx), "fed in time")
+
+	now = now.Add(time.Second)
+	report := w.Check(ctx)
+	if assert.NotNil(t, report) {
+		assert.Equal(t, clob.WatchdogMissedHeartbeat, report.Reason)
+		assert.NoError(t, report.Err)
+		assert.Equal(t, 3, report.Attempts)
+		assert.Equal(t, []string{id}, report.Canceled)
+	}
+	assert.Len(t, reports, 1)
+	assert.Equal(t, 0, live(client, option))
+	assert.Equal(t, 1, live(other, otherOption), "only the orders of the account are canceled")
+	assert.Nil(t, w.Check(ctx), "a tripped watchdog stays quiet until fed")
+
+	// Retries are spent.
+	w.Feed()
+	canceler.fails = 10
+	report = w.Trip(ctx, clob.WatchdogManual)
+	assert.Error(t, report.Err)
+	assert.Equal(t, 4, report.Attempts)
+
+	// Disconnects cancel per market.
+	w = clob.NewWatchdog(client, option, clob.WatchdogOptions{
+		Timeout:           time.Minute,
+		DisconnectTimeout: 5 * time.Second,
+		Markets:           []string{"0xc1"},
+		Now:               func() time.Time { return now },
+	})
+	place(client, option, tokenID)
+	id = place(client, option, "2002")
+	w.Disconnected()
+	now = now.Add(4 * time.Second)
+	w.Connected()
+	w.Disconnected()
+	now = now.Add(4 * time.Second)
+	assert.Nil(t, w.Check(ctx), "reconnecting resets the disconnect timer")
+	now = now.Add(time.Second)
+	report = w.Check(ctx)
+	if assert.NotNil(t, report) {
+		assert.Equal(t, clob.WatchdogDisconnected, report.Reason)
+		assert.Equal(t, []string{id}, report.Canceled)
+	}
+	assert.Equal(t, 1, live(client, option))
+}
+
+// slowCanceler counts its cancels and holds each until release is closed.
+type slowCanceler struct {
+	clob.Canceler
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (c *slowCanceler) CancelOrderAll(ctx context.Context, option *sdktypes.AuthOption) (*types.CancelOrder, error) {
+	c.calls.Add(1)
+	<-c.release
+	return &types.CancelOrder{Canceled: []string{}, NotCanceled: map[string]string{}}, nil
+}
+
+func TestWatchdogConcurrentTrips(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	canceler := &slowCanceler{release: make(chan struct{})}
+	var trips atomic.Int32
+	w := clob.NewWatchdog(canceler, nil, clob.WatchdogOptions{
+		Timeout: time.Second,
+		OnTrip:  func(clob.WatchdogReport) { trips.Add(1) },
+		Now:     func() time.Time { return now },
+	})
+	now = now.Add(time.Second)
+
+	reports := make(chan *clob.WatchdogReport, 4)
+	go func() { reports <- w.Check(ctx) }()
+	assert.Eventually(t, func() bool { return canceler.calls.Load() == 1 }, time.Second, time.Millisecond)
+	for i := 0; i < 3; i++ {
+		go func() { reports <- w.Check(ctx) }()
+	}
+	go func() { reports <- w.Trip(ctx, clob.WatchdogManual) }()
+	for i := 0; i < 4; i++ {
+		select {
+		case report := <-reports:
+			assert.Nil(t, report, "a cancel is already running")
+		case <-time.After(time.Second):
+			t.Fatal("a second cancel started")
+		}
+	}
+	close(canceler.release)
+	assert.NotNil(t, <-reports)
+	assert.Equal(t, int32(1), canceler.calls.Load())
+	assert.Equal(t, int32(1), trips.Load())
+	assert.Nil(t, w.Check(ctx), "tripped until fed")
+}
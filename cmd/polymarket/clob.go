@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/override-coder/go-polymarket-sdk/clob"
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+)
+
+// publicClob returns a client for the unauthenticated endpoints.
+func (a *app) publicClob() *clob.Client {
+	return clob.NewClient(a.cfg.ClobURL, a.cfg.chainId(), nil, a.cfg.BuilderApiKeyCreds)
+}
+
+// authClob returns a client signing with the configured key. Unless
+// withoutCreds is set, the API key is derived or created when the config
+// has none.
+func (a *app) authClob(ctx context.Context, withoutCreds bool) (*clob.Client, *sdktypes.AuthOption, error) {
+	signer, err := a.cfg.signer()
+	if err != nil {
+		return nil, nil, err
+	}
+	client := clob.NewClient(a.cfg.ClobURL, a.cfg.chainId(), signing.ToSignatureFunc(signer), a.cfg.BuilderApiKeyCreds)
+	option := a.cfg.authOption(signer)
+	if option.ApiKeyCreds == nil && !withoutCreds {
+		if _, err := client.EnsureAPIKey(ctx, big.NewInt(0), option); err != nil {
+			return nil, nil, fmt.Errorf("api key: %w", err)
+		}
+	}
+	return client, option, nil
+}
+
+func runKeys(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("keys")
+	nonce := fs.Int64("nonce", 0, "key nonce")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, option, err := a.authClob(ctx, true)
+	if err != nil {
+		return err
+	}
+	creds, err := client.EnsureAPIKey(ctx, big.NewInt(*nonce), option)
+	if err != nil {
+		return err
+	}
+	return a.out.fields(creds, "address", option.SingerAddress, "apiKey", creds.ApiKey, "secret", creds.Secret, "passphrase", creds.Passphrase)
+}
+
+func runPlace(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("place")
+	tokenID := fs.String("token", "", "token ID")
+	side := fs.String("side", "", "BUY or SELL")
+	price := fs.Float64("price", 0, "limit price")
+	size := fs.Float64("size", 0, "shares, or USDC for FOK and FAK buys")
+	orderType := fs.String("type", string(types.OrderTypeGTC), "GTC, GTD, FOK or FAK")
+	expiration := fs.Int64("expiration", 0, "unix expiration of GTD orders")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *tokenID == "" || *price <= 0 || *size <= 0 {
+		return errors.New("-token, -price and -size are required")
+	}
+	userOrder := types.UserOrder{TokenID: *tokenID, Price: *price, Size: *size, Side: types.Side(strings.ToUpper(*side))}
+	if userOrder.Side != types.BUY && userOrder.Side != types.SELL {
+		return fmt.Errorf("invalid side %q", *side)
+	}
+	if *expiration != 0 {
+		userOrder.Expiration = expiration
+	}
+	typ := types.OrderType(strings.ToUpper(*orderType))
+
+	client, option, err := a.authClob(ctx, a.dryRun)
+	if err != nil {
+		return err
+	}
+	if a.dryRun {
+		payload, err := client.BuildOrder(ctx, userOrder, typ, false, option)
+		if err != nil {
+			return err
+		}
+		return a.out.raw(payload)
+	}
+	resp, err := client.CreateOrder(ctx, userOrder, typ, false, option)
+	if err != nil {
+		return err
+	}
+	return a.out.fields(resp, "orderID", resp.OrderID, "status", resp.Status,
+		"making", resp.MakingAmount, "taking", resp.TakingAmount, "error", resp.ErrorMsg)
+}
+
+func runCancel(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("cancel")
+	all := fs.Bool("all", false, "cancel all open orders")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *all == (fs.NArg() > 0) {
+		return errors.New("pass either -all or order IDs")
+	}
+	if a.dryRun {
+		return errors.New("cancellations are not signed, nothing to print")
+	}
+	client, option, err := a.authClob(ctx, false)
+	if err != nil {
+		return err
+	}
+	var resp *types.CancelOrder
+	if *all {
+		resp, err = client.CancelOrderAll(ctx, option)
+	} else {
+		resp, err = client.CancelOrders(ctx, fs.Args(), option)
+	}
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(resp.Canceled)+len(resp.NotCanceled))
+	for _, id := range resp.Canceled {
+		rows = append(rows, []string{id, "canceled"})
+	}
+	for id, reason := range resp.NotCanceled {
+		rows = append(rows, []string{id, reason})
+	}
+	return a.out.table(resp, []string{"ORDER", "RESULT"}, rows)
+}
+
+func runOrders(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("orders")
+	market := fs.String("market", "", "condition ID")
+	tokenID := fs.String("token", "", "token ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, option, err := a.authClob(ctx, false)
+	if err != nil {
+		return err
+	}
+	orders, err := client.GetOrders(ctx, types.GetActiveOrdersRequest{Market: *market, AssetID: *tokenID}, option)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(orders.Data))
+	for _, o := range orders.Data {
+		rows = append(rows, []string{o.ID, o.AssetID, o.Side, o.Price, o.OriginalSize, o.SizeMatched, o.Type, o.Status})
+	}
+	return a.out.table(orders.Data, []string{"ID", "TOKEN", "SIDE", "PRICE", "SIZE", "MATCHED", "TYPE", "STATUS"}, rows)
+}
+
+func runTrades(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("trades")
+	market := fs.String("market", "", "condition ID")
+	tokenID := fs.String("token", "", "token ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, option, err := a.authClob(ctx, false)
+	if err != nil {
+		return err
+	}
+	req := types.GetTradesRequest{}
+	if *market != "" {
+		req.Market = market
+	}
+	if *tokenID != "" {
+		req.AssetID = tokenID
+	}
+	trades, err := client.GetTrades(ctx, req, option)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(trades.Data))
+	for _, t := range trades.Data {
+		rows = append(rows, []string{t.ID, t.AssetID, t.Side, t.Price, t.Size, t.TraderSide, t.Status, t.MatchTime})
+	}
+	return a.out.table(trades.Data, []string{"ID", "TOKEN", "SIDE", "PRICE", "SIZE", "ROLE", "STATUS", "TIME"}, rows)
+}
+
+func runBook(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("book")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("expected a token ID")
+	}
+	book, err := a.publicClob().GetOrderBook(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	// Bids and asks side by side, best first; the API lists both worst
+	// first.
+	n := max(len(book.Bids), len(book.Asks))
+	rows := make([][]string, 0, n)
+	for i := 0; i < n; i++ {
+		row := []string{"", "", "", ""}
+		if i < len(book.Bids) {
+			bid := book.Bids[len(book.Bids)-1-i]
+			row[0], row[1] = bid.Size, bid.Price
+		}
+		if i < len(book.Asks) {
+			ask := book.Asks[len(book.Asks)-1-i]
+			row[2], row[3] = ask.Price, ask.Size
+		}
+		rows = append(rows, row)
+	}
+	return a.out.table(book, []string{"BID SIZE", "BID", "ASK", "ASK SIZE"}, rows)
+}
+
+func runPrice(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("price")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("expected a token ID")
+	}
+	tokenID := fs.Arg(0)
+	client := a.publicClob()
+	mid, err := client.GetMidpoint(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	buy, err := client.GetMarketPrice(ctx, tokenID, string(types.BUY))
+	if err != nil {
+		return err
+	}
+	sell, err := client.GetMarketPrice(ctx, tokenID, string(types.SELL))
+	if err != nil {
+		return err
+	}
+	out := map[string]string{"token_id": tokenID, "mid": mid, "buy": buy, "sell": sell}
+	return a.out.fields(out, "token", tokenID, "mid", mid, "buy", buy, "sell", sell)
+}
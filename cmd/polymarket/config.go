@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/polymarket/go-order-utils/pkg/model"
+)
+
+const (
+	defaultClobURL    = "https://clob.polymarket.com"
+	defaultRelayerURL = "https://relayer-v2.polymarket.com"
+	defaultGammaURL   = "https://gamma-api.polymarket.com"
+	defaultDataURL    = "https://data-api.polymarket.com"
+)
+
+// Config holds the endpoints and credentials of the CLI. It is read from a
+// JSON file, then overridden by POLYMARKET_* environment variables.
+type Config struct {
+	ChainID    int64  `json:"chain_id"`
+	ClobURL    string `json:"clob_url"`
+	RelayerURL string `json:"relayer_url"`
+	GammaURL   string `json:"gamma_url"`
+	DataURL    string `json:"data_url"`
+
+	PrivateKey string `json:"private_key"`
+	// SignatureType is 0 for EOA, 1 for POLY_PROXY and 2 for POLY_GNOSIS_SAFE.
+	SignatureType int    `json:"signature_type"`
+	FunderAddress string `json:"funder_address"`
+
+	ApiKeyCreds        *sdktypes.ApiKeyCreds        `json:"api_key_creds,omitempty"`
+	BuilderApiKeyCreds *sdktypes.BuilderApiKeyCreds `json:"builder_api_key_creds,omitempty"`
+}
+
+// defaultConfigPath is $POLYMARKET_CONFIG, or ~/.config/polymarket/config.json.
+func defaultConfigPath() string {
+	if path := os.Getenv("POLYMARKET_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "polymarket", "config.json")
+}
+
+// loadConfig reads path, which may be missing, and applies the environment
+// and defaults.
+func loadConfig(path string, getenv func(string) string) (*Config, error) {
+	cfg := &Config{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, fmt.Errorf("read config: %w", err)
+		default:
+			if err := json.Unmarshal(data, cfg); err != nil {
+				return nil, fmt.Errorf("parse config %s: %w", path, err)
+			}
+		}
+	}
+
+	setString := func(dst *string, key string) {
+		if v := getenv(key); v != "" {
+			*dst = v
+		}
+	}
+	setString(&cfg.ClobURL, "POLYMARKET_CLOB_URL")
+	setString(&cfg.RelayerURL, "POLYMARKET_RELAYER_URL")
+	setString(&cfg.GammaURL, "POLYMARKET_GAMMA_URL")
+	setString(&cfg.DataURL, "POLYMARKET_DATA_URL")
+	setString(&cfg.PrivateKey, "POLYMARKET_PRIVATE_KEY")
+	setString(&cfg.FunderAddress, "POLYMARKET_FUNDER_ADDRESS")
+	if v := getenv("POLYMARKET_CHAIN_ID"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid POLYMARKET_CHAIN_ID: %w", err)
+		}
+		cfg.ChainID = id
+	}
+	if v := getenv("POLYMARKET_SIGNATURE_TYPE"); v != "" {
+		t, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid POLYMARKET_SIGNATURE_TYPE: %w", err)
+		}
+		cfg.SignatureType = t
+	}
+	if key := getenv("POLYMARKET_API_KEY"); key != "" {
+		cfg.ApiKeyCreds = &sdktypes.ApiKeyCreds{
+			ApiKey:     key,
+			Secret:     getenv("POLYMARKET_API_SECRET"),
+			Passphrase: getenv("POLYMARKET_API_PASSPHRASE"),
+		}
+	}
+	if key := getenv("POLYMARKET_BUILDER_API_KEY"); key != "" {
+		cfg.BuilderApiKeyCreds = &sdktypes.BuilderApiKeyCreds{
+			Key:        key,
+			Secret:     getenv("POLYMARKET_BUILDER_API_SECRET"),
+			Passphrase: getenv("POLYMARKET_BUILDER_API_PASSPHRASE"),
+		}
+	}
+
+	if cfg.ChainID == 0 {
+		cfg.ChainID = int64(sdktypes.POLYGON)
+	}
+	if cfg.ClobURL == "" {
+		cfg.ClobURL = defaultClobURL
+	}
+	if cfg.RelayerURL == "" {
+		cfg.RelayerURL = defaultRelayerURL
+	}
+	if cfg.GammaURL == "" {
+		cfg.GammaURL = defaultGammaURL
+	}
+	if cfg.DataURL == "" {
+		cfg.DataURL = defaultDataURL
+	}
+	return cfg, nil
+}
+
+func (c *Config) chainId() *big.Int {
+	return big.NewInt(c.ChainID)
+}
+
+func (c *Config) signer() (*signing.PrivateKeySigner, error) {
+	if c.PrivateKey == "" {
+		return nil, errors.New("no private key: set POLYMARKET_PRIVATE_KEY or private_key in the config file")
+	}
+	return signing.NewPrivateKeySignerFromHex(c.PrivateKey)
+}
+
+func (c *Config) authOption(signer signing.Signer) *sdktypes.AuthOption {
+	return &sdktypes.AuthOption{
+		SignatureType:      model.SignatureType(c.SignatureType),
+		SingerAddress:      signer.Address().Hex(),
+		FunderAddress:      c.FunderAddress,
+		ApiKeyCreds:        c.ApiKeyCreds,
+		BuilderApiKeyCreds: c.BuilderApiKeyCreds,
+	}
+}
@@ -0,0 +1,112 @@
+// Command polymarket is a command line client for the Polymarket CLOB,
+// relayer, Gamma and Data APIs.
+//
+//	polymarket [-config file] [-o table|json] [-dry-run] <command> [flags] [args]
+//
+// Credentials come from the config file (see Config) and POLYMARKET_*
+// environment variables. With -dry-run, commands that would sign and post
+// print the signed payload instead.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+)
+
+type app struct {
+	cfg    *Config
+	out    *printer
+	dryRun bool
+	stderr io.Writer
+}
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, a *app, args []string) error
+}
+
+var commands = map[string]command{
+	"keys":      {"keys [-nonce n]: derive or create the CLOB API key of the signer", runKeys},
+	"place":     {"place -token id -side BUY|SELL -price p -size s [-type GTC|GTD|FOK|FAK] [-expiration ts]: place an order", runPlace},
+	"cancel":    {"cancel [-all] [order ids...]: cancel orders", runCancel},
+	"orders":    {"orders [-market id] [-token id]: list open orders", runOrders},
+	"trades":    {"trades [-market id] [-token id]: list trades", runTrades},
+	"book":      {"book <token id>: show the order book", runBook},
+	"price":     {"price <token id>: show the midpoint and best prices", runPrice},
+	"safe":      {"safe address|deploy|execute -file txns.json|redeem -condition id [-neg-risk -amounts a,b]: manage the Safe through the relayer", runSafe},
+	"search":    {"search [-limit n] <query>: search markets", runSearch},
+	"positions": {"positions [-user address]: show positions", runPositions},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv)
+	stop()
+	os.Exit(code)
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	fs := flag.NewFlagSet("polymarket", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", defaultConfigPath(), "config file")
+	output := fs.String("o", "table", "output format: table or json")
+	dryRun := fs.Bool("dry-run", false, "print signed payloads instead of posting them")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: polymarket [flags] <command> [flags] [args]\n\nflags:")
+		fs.PrintDefaults()
+		fmt.Fprintln(stderr, "\ncommands:")
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintln(stderr, "  "+commands[name].usage)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(stderr, "invalid output format %q\n", *output)
+		return 2
+	}
+
+	cfg, err := loadConfig(*configPath, getenv)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	a := &app{cfg: cfg, out: &printer{w: stdout, json: *output == "json"}, dryRun: *dryRun, stderr: stderr}
+	if err := cmd.run(ctx, a, fs.Args()[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintf(stderr, "%s: %v\n", fs.Arg(0), err)
+		return 1
+	}
+	return 0
+}
+
+// flags returns the flag set of a command.
+func (a *app) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
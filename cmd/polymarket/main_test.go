@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/override-coder/go-polymarket-sdk/clob/clobtest"
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/gamma/gammatest"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenID = "1001"
+
+type cli struct {
+	t   *testing.T
+	env map[string]string
+}
+
+func newCLI(t *testing.T) (*cli, *clobtest.Server) {
+	server := clobtest.NewServer(big.NewInt(137), clobtest.Options{})
+	t.Cleanup(server.Close)
+	server.AddMarket(clobtest.Market{TokenID: tokenID, ConditionID: "0xc0", Outcome: "Yes"})
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	return &cli{t: t, env: map[string]string{
+		"POLYMARKET_CONFIG":      filepath.Join(t.TempDir(), "missing.json"),
+		"POLYMARKET_CLOB_URL":    server.URL,
+		"POLYMARKET_PRIVATE_KEY": hexutil.Encode(crypto.FromECDSA(key)),
+	}}, server
+}
+
+// run runs the command line and returns its exit code and output.
+func (c *cli) run(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-config", c.env["POLYMARKET_CONFIG"]}, args...)
+	code := run(context.Background(), args, &stdout, &stderr, func(key string) string { return c.env[key] })
+	return code, stdout.String(), stderr.String()
+}
+
+func TestOrders(t *testing.T) {
+	c, _ := newCLI(t)
+
+	code, out, stderr := c.run("-dry-run", "place", "-token", tokenID, "-side", "buy", "-price", "0.4", "-size", "10")
+	assert.Equal(t, 0, code, stderr)
+	var payload types.NewOrder
+	assert.NoError(t, json.Unmarshal([]byte(out), &payload))
+	assert.Equal(t, "4000000", payload.Order.MakerAmount)
+	assert.NotEmpty(t, payload.Order.Signature)
+
+	code, out, _ = c.run("-o", "json", "orders")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "[]", strings.TrimSpace(out), "dry runs do not post")
+
+	code, out, stderr = c.run("place", "-token", tokenID, "-side", "BUY", "-price", "0.4", "-size", "10")
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, out, "live")
+
+	code, out, _ = c.run("orders")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out, "STATUS")
+	assert.Contains(t, out, "0.4")
+
+	code, out, _ = c.run("book", tokenID)
+	assert.Equal(t, 0, code)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, []string{"10", "0.4"}, strings.Fields(lines[1]))
+	}
+
+	code, out, _ = c.run("cancel", "-all")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out, "canceled")
+
+	code, _, stderr = c.run("place", "-token", tokenID, "-side", "HOLD", "-price", "0.4", "-size", "10")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "invalid side")
+	code, _, _ = c.run("unknown")
+	assert.Equal(t, 2, code)
+}
+
+func TestPositions(t *testing.T) {
+	c, _ := newCLI(t)
+	server, err := gammatest.NewDataServer(os.DirFS("../../dataapi/testdata"))
+	assert.NoError(t, err)
+	t.Cleanup(server.Close)
+	c.env["POLYMARKET_DATA_URL"] = server.URL
+
+	code, out, stderr := c.run("positions", "-user", "0x0f863d92dd2b960e3eb6a23a35fd92a91981404e")
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, out, "Will the Fed cut rates in December?")
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"clob_url":"http://file","signature_type":2,"api_key_creds":{"apiKey":"file-key"}}`), 0o600))
+	env := map[string]string{"POLYMARKET_API_KEY": "env-key", "POLYMARKET_API_SECRET": "secret"}
+
+	cfg, err := loadConfig(path, func(key string) string { return env[key] })
+	assert.NoError(t, err)
+	assert.Equal(t, "http://file", cfg.ClobURL)
+	assert.Equal(t, defaultGammaURL, cfg.GammaURL)
+	assert.Equal(t, int64(137), cfg.ChainID)
+	assert.Equal(t, 2, cfg.SignatureType)
+	assert.Equal(t, "env-key", cfg.ApiKeyCreds.ApiKey, "the environment overrides the file")
+	assert.Equal(t, "secret", cfg.ApiKeyCreds.Secret)
+}
+
+func TestSafeRedeemCondition(t *testing.T) {
+	c, _ := newCLI(t)
+	for _, condition := range []string{"0xc0", "c0ffee", "0x" + strings.Repeat("zz", 32)} {
+		code, _, stderr := c.run("safe", "redeem", "-condition", condition)
+		assert.NotEqual(t, 0, code)
+		assert.Contains(t, stderr, "invalid condition")
+	}
+}
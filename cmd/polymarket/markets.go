@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/override-coder/go-polymarket-sdk/dataapi"
+	datatypes "github.com/override-coder/go-polymarket-sdk/dataapi/types"
+	"github.com/override-coder/go-polymarket-sdk/gamma"
+	gammatypes "github.com/override-coder/go-polymarket-sdk/gamma/types"
+)
+
+func runSearch(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("search")
+	limit := fs.Int("limit", 10, "events to return")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("expected a query")
+	}
+	client := gamma.NewClient(a.cfg.GammaURL, a.cfg.chainId())
+	resp, err := client.Search(ctx, &gammatypes.SearchParams{Q: strings.Join(fs.Args(), " "), LimitPerType: limit})
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, ev := range resp.Events {
+		for _, m := range ev.Markets {
+			rows = append(rows, []string{deref(ev.Slug), deref(m.Question), m.ConditionID, deref(m.ClobTokenIds)})
+		}
+	}
+	return a.out.table(resp.Events, []string{"EVENT", "MARKET", "CONDITION", "TOKENS"}, rows)
+}
+
+func runPositions(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("positions")
+	user := fs.String("user", "", "wallet address, defaults to the funder or signer address")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *user == "" {
+		*user = a.cfg.FunderAddress
+	}
+	if *user == "" {
+		signer, err := a.cfg.signer()
+		if err != nil {
+			return errors.New("-user is required without a funder address or private key")
+		}
+		*user = signer.Address().Hex()
+	}
+	client := dataapi.NewClient(a.cfg.DataURL, a.cfg.chainId())
+	positions, err := client.GetPositions(ctx, datatypes.PositionsQuery{User: *user})
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(positions))
+	for _, p := range positions {
+		rows = append(rows, []string{p.Title, p.Outcome, formatFloat(p.Size), formatFloat(p.AvgPrice),
+			formatFloat(p.CurPrice), formatFloat(p.CurrentValue), formatFloat(p.CashPnl), strconv.FormatBool(p.Redeemable)})
+	}
+	return a.out.table(positions, []string{"MARKET", "OUTCOME", "SIZE", "AVG", "PRICE", "VALUE", "PNL", "REDEEMABLE"}, rows)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// printer writes results as aligned tables, or as indented JSON when json is
+// set.
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+// table prints v as JSON, or headers and rows as a table.
+func (p *printer) table(v any, headers []string, rows [][]string) error {
+	if p.json {
+		return p.raw(v)
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// fields prints v as JSON, or the name/value pairs kv as a two-column table.
+func (p *printer) fields(v any, kv ...string) error {
+	if p.json {
+		return p.raw(v)
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	for i := 0; i+1 < len(kv); i += 2 {
+		fmt.Fprintf(tw, "%s\t%s\n", kv[i], kv[i+1])
+	}
+	return tw.Flush()
+}
+
+// raw prints v as indented JSON whatever the output format, e.g. for signed
+// payloads.
+func (p *printer) raw(v any) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/override-coder/go-polymarket-sdk/relayer"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+)
+
+func (a *app) relayer() (*relayer.Client, *sdktypes.AuthOption, error) {
+	signer, err := a.cfg.signer()
+	if err != nil {
+		return nil, nil, err
+	}
+	client := relayer.NewClient(a.cfg.RelayerURL, a.cfg.chainId(), signing.ToSignatureFunc(signer), a.cfg.BuilderApiKeyCreds)
+	return client, a.cfg.authOption(signer), nil
+}
+
+func runSafe(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return errors.New("expected address, deploy, execute or redeem")
+	}
+	switch args[0] {
+	case "address":
+		return runSafeAddress(a, args[1:])
+	case "deploy":
+		return runSafeDeploy(a, args[1:])
+	case "execute":
+		return runSafeExecute(a, args[1:])
+	case "redeem":
+		return runSafeRedeem(a, args[1:])
+	default:
+		return fmt.Errorf("unknown safe command %q", args[0])
+	}
+}
+
+func runSafeAddress(a *app, args []string) error {
+	fs := a.flags("safe address")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, option, err := a.relayer()
+	if err != nil {
+		return err
+	}
+	safe, err := client.GetExpectedSafe(option.SingerAddress)
+	if err != nil {
+		return err
+	}
+	deployed, err := client.GetDeployed(safe)
+	if err != nil {
+		return err
+	}
+	out := map[string]any{"owner": option.SingerAddress, "safe": safe, "deployed": deployed.Deployed}
+	return a.out.fields(out, "owner", option.SingerAddress, "safe", safe, "deployed", fmt.Sprint(deployed.Deployed))
+}
+
+func runSafeDeploy(a *app, args []string) error {
+	fs := a.flags("safe deploy")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, option, err := a.relayer()
+	if err != nil {
+		return err
+	}
+	if a.dryRun {
+		req, err := client.BuildDeployTx(option)
+		if err != nil {
+			return err
+		}
+		return a.out.raw(req)
+	}
+	resp, err := client.Deploy(option)
+	if err != nil {
+		return err
+	}
+	return a.printRelayerResponse(resp)
+}
+
+func runSafeExecute(a *app, args []string) error {
+	fs := a.flags("safe execute")
+	file := fs.String("file", "", "JSON array of {to, operation, data, value} transactions, - for stdin")
+	metadata := fs.String("metadata", "", "relayer metadata")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("-file is required")
+	}
+	var r io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	var txns []types.SafeTransaction
+	if err := json.NewDecoder(r).Decode(&txns); err != nil {
+		return fmt.Errorf("parse transactions: %w", err)
+	}
+	if len(txns) == 0 {
+		return errors.New("no transactions")
+	}
+	return a.execute(txns, *metadata)
+}
+
+func runSafeRedeem(a *app, args []string) error {
+	fs := a.flags("safe redeem")
+	condition := fs.String("condition", "", "condition ID of the resolved market")
+	negRisk := fs.Bool("neg-risk", false, "redeem on the NegRiskAdapter")
+	amounts := fs.String("amounts", "", "comma separated token amounts per outcome, in base units, for neg risk markets")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *condition == "" {
+		return errors.New("-condition is required")
+	}
+	if b, err := hexutil.Decode(*condition); err != nil || len(b) != common.HashLength {
+		return fmt.Errorf("invalid condition %s (must be 0x + 64 hex chars)", *condition)
+	}
+	var parsed []*big.Int
+	if *amounts != "" {
+		for _, s := range strings.Split(*amounts, ",") {
+			n, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+			if !ok {
+				return fmt.Errorf("invalid amount %q", s)
+			}
+			parsed = append(parsed, n)
+		}
+	}
+	txn, err := relayer.RedeemPositionsTx(a.cfg.chainId(), common.HexToHash(*condition), *negRisk, parsed)
+	if err != nil {
+		return err
+	}
+	return a.execute([]types.SafeTransaction{txn}, "redeem")
+}
+
+// execute submits txns from the Safe, or prints the signed request on a dry
+// run.
+func (a *app) execute(txns []types.SafeTransaction, metadata string) error {
+	client, option, err := a.relayer()
+	if err != nil {
+		return err
+	}
+	if a.dryRun {
+		req, err := client.BuildTx(txns, nil, metadata, option)
+		if err != nil {
+			return err
+		}
+		client.Discard(req)
+		return a.out.raw(req)
+	}
+	resp, err := client.Execute(txns, metadata, option)
+	if err != nil {
+		return err
+	}
+	return a.printRelayerResponse(resp)
+}
+
+func (a *app) printRelayerResponse(resp *types.RelayerTransactionResponse) error {
+	return a.out.fields(resp, "transactionID", resp.TransactionID, "state", resp.State, "hash", resp.TransactionHash)
+}
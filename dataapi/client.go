@@ -27,6 +27,13 @@ func NewClient(host string, chainId *big.Int) *Client {
 	}
 }
 
+// WithTransport routes the client's requests through rt, e.g. an
+// http.Recorder or http.Replayer.
+func (c *Client) WithTransport(rt http.RoundTripper) error {
+	c.client.SetTransport(rt)
+	return nil
+}
+
 func (c *Client) GetPositions(ctx context.Context, q types.PositionsQuery) ([]types.Position, error) {
 	if strings.TrimSpace(q.User) == "" {
 		return nil, fmt.Errorf("user is required")
@@ -1,36 +1,44 @@
 package dataapi_test
 
 import (
+	"context"
+	"math/big"
+	"os"
+	"testing"
+
 	"github.com/override-coder/go-polymarket-sdk/dataapi"
 	"github.com/override-coder/go-polymarket-sdk/dataapi/types"
+	"github.com/override-coder/go-polymarket-sdk/gamma/gammatest"
 	"github.com/stretchr/testify/assert"
-	"math/big"
-	"testing"
 )
 
-var (
-	PolymarketRelayURL = "https://data-api.polymarket.com"
-	chaindId           = big.NewInt(137)
-)
+var chaindId = big.NewInt(137)
 
 func TestGetPositions(t *testing.T) {
-	client := dataapi.NewClient(PolymarketRelayURL, chaindId)
+	server, err := gammatest.NewDataServer(os.DirFS("testdata"))
+	assert.NoError(t, err)
+	defer server.Close()
+	client := dataapi.NewClient(server.URL, chaindId)
 
-	positions, err := client.GetPositions(types.PositionsQuery{
+	positions, err := client.GetPositions(context.Background(), types.PositionsQuery{
 		User: "0x0f863d92dd2b960e3eb6a23a35fd92a91981404e",
 	})
-	assert.Equal(t, nil, err)
-	t.Logf("positions: %v", positions)
+	assert.NoError(t, err)
+	if assert.Len(t, positions, 1) {
+		assert.Equal(t, "fed-decision-in-december", positions[0].EventSlug)
+	}
 
-	activity, err := client.GetUserActivity(types.ActivityQuery{
+	activity, err := client.GetUserActivity(context.Background(), types.ActivityQuery{
 		User: "0x0f863d92dd2b960e3eb6a23a35fd92a91981404e",
 	})
-	assert.Equal(t, nil, err)
-	t.Logf("activitys: %v", activity)
+	assert.NoError(t, err)
+	assert.Len(t, activity, 1)
 
-	value, err := client.GetPositionValue(types.PositionValueQuery{
+	value, err := client.GetPositionValue(context.Background(), types.PositionValueQuery{
 		User: "0x4b5bB26F866d98B2C92096fD6d80D6D01B6313f5",
 	})
-	assert.Equal(t, nil, err)
-	t.Logf("value: %v", value)
+	assert.NoError(t, err)
+	if assert.Len(t, value, 1) {
+		assert.Equal(t, float64(0), value[0].Value)
+	}
 }
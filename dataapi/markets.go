@@ -0,0 +1,250 @@
+package dataapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/override-coder/go-polymarket-sdk/dataapi/types"
+	http2 "github.com/override-coder/go-polymarket-sdk/http"
+)
+
+func validateConditionIDs(markets []string) error {
+	re := regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+	for _, m := range markets {
+		if !re.MatchString(m) {
+			return fmt.Errorf("invalid conditionId: %s (must be 0x + 64 hex chars)", m)
+		}
+	}
+	return nil
+}
+
+func joinEventIDs(ids []int64) string {
+	strIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strIDs = append(strIDs, fmt.Sprintf("%d", id))
+	}
+	return strings.Join(strIDs, ",")
+}
+
+// GetTrades returns the public trades feed, newest first.
+func (c *Client) GetTrades(ctx context.Context, q types.TradesQuery) ([]types.Trade, error) {
+	if len(q.Market) > 0 && len(q.EventID) > 0 {
+		return nil, fmt.Errorf("market and eventId are mutually exclusive")
+	}
+	if err := validateConditionIDs(q.Market); err != nil {
+		return nil, err
+	}
+	if q.User != nil && strings.TrimSpace(*q.User) != "" {
+		re := regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+		if !re.MatchString(*q.User) {
+			return nil, fmt.Errorf("invalid user address: %s", *q.User)
+		}
+	}
+	if (q.FilterType == nil) != (q.FilterAmount == nil) {
+		return nil, fmt.Errorf("filterType and filterAmount must be set together")
+	}
+	if q.FilterAmount != nil && *q.FilterAmount < 0 {
+		return nil, fmt.Errorf("filterAmount must be >= 0")
+	}
+
+	limit := 100
+	if q.Limit != nil {
+		if *q.Limit < 0 || *q.Limit > 10000 {
+			return nil, fmt.Errorf("limit out of range (0..10000)")
+		}
+		limit = *q.Limit
+	}
+	offset := 0
+	if q.Offset != nil {
+		if *q.Offset < 0 || *q.Offset > 10000 {
+			return nil, fmt.Errorf("offset out of range (0..10000)")
+		}
+		offset = *q.Offset
+	}
+
+	params := map[string]any{
+		"limit":  limit,
+		"offset": offset,
+	}
+	if q.User != nil && strings.TrimSpace(*q.User) != "" {
+		params["user"] = *q.User
+	}
+	if len(q.Market) > 0 {
+		params["market"] = strings.Join(q.Market, ",")
+	}
+	if len(q.EventID) > 0 {
+		params["eventId"] = joinEventIDs(q.EventID)
+	}
+	if q.Side != nil {
+		params["side"] = string(*q.Side)
+	}
+	if q.TakerOnly != nil {
+		params["takerOnly"] = *q.TakerOnly
+	}
+	if q.FilterType != nil {
+		params["filterType"] = string(*q.FilterType)
+		params["filterAmount"] = *q.FilterAmount
+	}
+
+	var out []types.Trade
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, types.GET_TRADES, &http2.RequestOptions{
+		Params: params,
+	}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return out, nil
+}
+
+// GetHolders returns the top holders of each outcome token of the markets.
+func (c *Client) GetHolders(ctx context.Context, q types.HoldersQuery) ([]types.MarketHolders, error) {
+	if len(q.Market) == 0 {
+		return nil, fmt.Errorf("market is required")
+	}
+	if err := validateConditionIDs(q.Market); err != nil {
+		return nil, err
+	}
+
+	limit := 20
+	if q.Limit != nil {
+		if *q.Limit < 0 || *q.Limit > 500 {
+			return nil, fmt.Errorf("limit out of range (0..500)")
+		}
+		limit = *q.Limit
+	}
+	minBalance := 1
+	if q.MinBalance != nil {
+		if *q.MinBalance < 0 || *q.MinBalance > 999999 {
+			return nil, fmt.Errorf("minBalance out of range (0..999999)")
+		}
+		minBalance = *q.MinBalance
+	}
+
+	params := map[string]any{
+		"market":     strings.Join(q.Market, ","),
+		"limit":      limit,
+		"minBalance": minBalance,
+	}
+
+	var out []types.MarketHolders
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, types.GET_HOLDERS, &http2.RequestOptions{
+		Params: params,
+	}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return out, nil
+}
+
+// GetOpenInterest returns the open interest of each market, or of all markets
+// when none is given.
+func (c *Client) GetOpenInterest(ctx context.Context, markets []string) ([]types.MarketValue, error) {
+	if err := validateConditionIDs(markets); err != nil {
+		return nil, err
+	}
+	params := map[string]any{}
+	if len(markets) > 0 {
+		params["market"] = strings.Join(markets, ",")
+	}
+
+	var out []types.MarketValue
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, types.GET_OPEN_INTEREST, &http2.RequestOptions{
+		Params: params,
+	}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return out, nil
+}
+
+// GetLiveVolume returns the live volume of an event and of each of its markets.
+func (c *Client) GetLiveVolume(ctx context.Context, eventID int64) (*types.LiveVolume, error) {
+	if eventID < 1 {
+		return nil, fmt.Errorf("eventId must be >= 1")
+	}
+
+	var out []types.LiveVolume
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, types.GET_LIVE_VOLUME, &http2.RequestOptions{
+		Params: map[string]any{"id": eventID},
+	}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	if len(out) == 0 {
+		return &types.LiveVolume{}, nil
+	}
+	return &out[0], nil
+}
+
+// GetClosedPositions returns the user's closed positions, by realized PnL by
+// default.
+func (c *Client) GetClosedPositions(ctx context.Context, q types.ClosedPositionsQuery) ([]types.ClosedPosition, error) {
+	if strings.TrimSpace(q.User) == "" {
+		return nil, fmt.Errorf("user is required")
+	}
+	re := regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	if !re.MatchString(q.User) {
+		return nil, fmt.Errorf("invalid user address: %s", q.User)
+	}
+	if len(q.Market) > 0 && len(q.EventID) > 0 {
+		return nil, fmt.Errorf("market and eventId are mutually exclusive")
+	}
+	if err := validateConditionIDs(q.Market); err != nil {
+		return nil, err
+	}
+
+	limit := 10
+	if q.Limit != nil {
+		if *q.Limit < 0 || *q.Limit > 50 {
+			return nil, fmt.Errorf("limit out of range (0..50)")
+		}
+		limit = *q.Limit
+	}
+	offset := 0
+	if q.Offset != nil {
+		if *q.Offset < 0 || *q.Offset > 100000 {
+			return nil, fmt.Errorf("offset out of range (0..100000)")
+		}
+		offset = *q.Offset
+	}
+	sortBy := types.ClosedSortREALIZEDPNL
+	if q.SortBy != nil {
+		sortBy = *q.SortBy
+	}
+	sortDir := types.SortDESC
+	if q.SortDirection != nil {
+		sortDir = *q.SortDirection
+	}
+	if q.Title != nil && len(*q.Title) > 100 {
+		return nil, fmt.Errorf("title too long (max 100)")
+	}
+
+	params := map[string]any{
+		"user":          q.User,
+		"limit":         limit,
+		"offset":        offset,
+		"sortBy":        string(sortBy),
+		"sortDirection": string(sortDir),
+	}
+	if q.Title != nil && *q.Title != "" {
+		params["title"] = *q.Title
+	}
+	if len(q.Market) > 0 {
+		params["market"] = strings.Join(q.Market, ",")
+	}
+	if len(q.EventID) > 0 {
+		params["eventId"] = joinEventIDs(q.EventID)
+	}
+
+	var out []types.ClosedPosition
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, types.GET_CLOSED_POSITIONS, &http2.RequestOptions{
+		Params: params,
+	}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return out, nil
+}
@@ -0,0 +1,97 @@
+package dataapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/override-coder/go-polymarket-sdk/dataapi"
+	"github.com/override-coder/go-polymarket-sdk/dataapi/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const conditionID = "0x9c1a953fe92c8357f1b646ba25d983aa83e90c525992db14fb726fa895cb5763"
+
+func TestMarketEndpoints(t *testing.T) {
+	queries := map[string]url.Values{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		queries[req.URL.Path] = req.URL.Query()
+		switch req.URL.Path {
+		case types.GET_TRADES:
+			_, _ = w.Write([]byte(`[{"proxyWallet":"0xabc","side":"BUY","size":25,"price":0.41,"timestamp":1730000000,"conditionId":"` + conditionID + `"}]`))
+		case types.GET_HOLDERS:
+			_, _ = w.Write([]byte(`[{"token":"123","holders":[{"proxyWallet":"0xdef","amount":1500.5,"outcomeIndex":0}]}]`))
+		case types.GET_OPEN_INTEREST:
+			_, _ = w.Write([]byte(`[{"market":"` + conditionID + `","value":98765.4}]`))
+		case types.GET_LIVE_VOLUME:
+			_, _ = w.Write([]byte(`[{"total":1200,"markets":[{"market":"` + conditionID + `","value":1200}]}]`))
+		case types.GET_CLOSED_POSITIONS:
+			_, _ = w.Write([]byte(`[{"conditionId":"` + conditionID + `","avgPrice":0.3,"totalBought":100,"realizedPnl":70,"curPrice":1}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := dataapi.NewClient(server.URL, chaindId)
+	ctx := context.Background()
+
+	side := types.SideBUY
+	filter := types.TradeFilterCASH
+	amount := 10.0
+	trades, err := client.GetTrades(ctx, types.TradesQuery{Market: []string{conditionID}, Side: &side, FilterType: &filter, FilterAmount: &amount})
+	assert.NoError(t, err)
+	if assert.Len(t, trades, 1) {
+		assert.Equal(t, 0.41, trades[0].Price)
+	}
+	assert.Equal(t, "BUY", queries[types.GET_TRADES].Get("side"))
+	assert.Equal(t, "CASH", queries[types.GET_TRADES].Get("filterType"))
+	assert.Equal(t, "100", queries[types.GET_TRADES].Get("limit"))
+
+	holders, err := client.GetHolders(ctx, types.HoldersQuery{Market: []string{conditionID}})
+	assert.NoError(t, err)
+	if assert.Len(t, holders, 1) && assert.Len(t, holders[0].Holders, 1) {
+		assert.Equal(t, 1500.5, holders[0].Holders[0].Amount)
+	}
+	assert.Equal(t, "20", queries[types.GET_HOLDERS].Get("limit"))
+
+	oi, err := client.GetOpenInterest(ctx, []string{conditionID})
+	assert.NoError(t, err)
+	assert.Equal(t, []types.MarketValue{{Market: conditionID, Value: 98765.4}}, oi)
+
+	volume, err := client.GetLiveVolume(ctx, 16085)
+	assert.NoError(t, err)
+	assert.Equal(t, 1200.0, volume.Total)
+	assert.Equal(t, "16085", queries[types.GET_LIVE_VOLUME].Get("id"))
+
+	closed, err := client.GetClosedPositions(ctx, types.ClosedPositionsQuery{User: "0x0f863d92dd2b960e3eb6a23a35fd92a91981404e"})
+	assert.NoError(t, err)
+	if assert.Len(t, closed, 1) {
+		assert.Equal(t, 70.0, closed[0].RealizedPnl)
+	}
+	assert.Equal(t, "REALIZEDPNL", queries[types.GET_CLOSED_POSITIONS].Get("sortBy"))
+}
+
+func TestMarketEndpointsValidation(t *testing.T) {
+	client := dataapi.NewClient("http://127.0.0.1:0", chaindId)
+	ctx := context.Background()
+	amount := 5.0
+	limit := 51
+
+	_, err := client.GetTrades(ctx, types.TradesQuery{Market: []string{conditionID}, EventID: []int64{1}})
+	assert.Error(t, err)
+	_, err = client.GetTrades(ctx, types.TradesQuery{FilterAmount: &amount})
+	assert.Error(t, err)
+	_, err = client.GetHolders(ctx, types.HoldersQuery{})
+	assert.Error(t, err)
+	_, err = client.GetOpenInterest(ctx, []string{"0x1234"})
+	assert.Error(t, err)
+	_, err = client.GetLiveVolume(ctx, 0)
+	assert.Error(t, err)
+	_, err = client.GetClosedPositions(ctx, types.ClosedPositionsQuery{User: "0x0f863d92dd2b960e3eb6a23a35fd92a91981404e", Limit: &limit})
+	assert.ErrorContains(t, err, "limit out of range")
+	_, err = client.GetClosedPositions(ctx, types.ClosedPositionsQuery{User: "0xabc"})
+	assert.ErrorContains(t, err, "invalid user address")
+}
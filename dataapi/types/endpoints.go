@@ -6,3 +6,11 @@ const (
 	GET_VALUE       = "/value"
 	GET_LEADERBOARD = "/v1/leaderboard"
 )
+
+const (
+	GET_TRADES           = "/trades"
+	GET_HOLDERS          = "/holders"
+	GET_OPEN_INTEREST    = "/oi"
+	GET_LIVE_VOLUME      = "/live-volume"
+	GET_CLOSED_POSITIONS = "/closed-positions"
+)
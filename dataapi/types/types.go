@@ -189,3 +189,121 @@ type TraderLeaderboard struct {
 	PnL           float64 `json:"pnl"`
 	ProfileImage  string  `json:"profileImage"`
 }
+
+type TradeFilterType string
+
+const (
+	TradeFilterCASH   TradeFilterType = "CASH"
+	TradeFilterTOKENS TradeFilterType = "TOKENS"
+)
+
+type TradesQuery struct {
+	User         *string          // optional: 0x + 40 hex
+	Market       []string         // conditionIds (0x + 64 hex), mutually exclusive with EventID
+	EventID      []int64          // mutually exclusive with Market
+	Side         *Side            // BUY / SELL
+	TakerOnly    *bool            // default: true
+	FilterType   *TradeFilterType // CASH / TOKENS, requires FilterAmount
+	FilterAmount *float64         // >= 0, requires FilterType
+	Limit        *int             // default 100, range 0..10000
+	Offset       *int             // default 0,   range 0..10000
+}
+
+type Trade struct {
+	ProxyWallet           string  `json:"proxyWallet"`
+	Side                  string  `json:"side"` // BUY/SELL
+	Asset                 string  `json:"asset"`
+	ConditionID           string  `json:"conditionId"`
+	Size                  float64 `json:"size"`
+	Price                 float64 `json:"price"`
+	Timestamp             int64   `json:"timestamp"`
+	Title                 string  `json:"title"`
+	Slug                  string  `json:"slug"`
+	Icon                  string  `json:"icon"`
+	EventSlug             string  `json:"eventSlug"`
+	Outcome               string  `json:"outcome"`
+	OutcomeIndex          int64   `json:"outcomeIndex"`
+	Name                  string  `json:"name"`
+	Pseudonym             string  `json:"pseudonym"`
+	Bio                   string  `json:"bio"`
+	ProfileImage          string  `json:"profileImage"`
+	ProfileImageOptimized string  `json:"profileImageOptimized"`
+	TransactionHash       string  `json:"transactionHash"`
+}
+
+type HoldersQuery struct {
+	Market     []string // required, conditionIds (0x + 64 hex)
+	Limit      *int     // default 20, range 0..500
+	MinBalance *int     // default 1, range 0..999999
+}
+
+// MarketHolders are the top holders of one outcome token.
+type MarketHolders struct {
+	Token   string   `json:"token"`
+	Holders []Holder `json:"holders"`
+}
+
+type Holder struct {
+	ProxyWallet           string  `json:"proxyWallet"`
+	Bio                   string  `json:"bio"`
+	Asset                 string  `json:"asset"`
+	Pseudonym             string  `json:"pseudonym"`
+	Amount                float64 `json:"amount"`
+	DisplayUsernamePublic bool    `json:"displayUsernamePublic"`
+	OutcomeIndex          int64   `json:"outcomeIndex"`
+	Name                  string  `json:"name"`
+	ProfileImage          string  `json:"profileImage"`
+	ProfileImageOptimized string  `json:"profileImageOptimized"`
+}
+
+// MarketValue is a per-market amount, e.g. open interest or live volume.
+type MarketValue struct {
+	Market string  `json:"market"` // conditionId
+	Value  float64 `json:"value"`
+}
+
+type LiveVolume struct {
+	Total   float64       `json:"total"`
+	Markets []MarketValue `json:"markets"`
+}
+
+type ClosedPositionSortBy string
+
+const (
+	ClosedSortREALIZEDPNL ClosedPositionSortBy = "REALIZEDPNL" // default
+	ClosedSortTITLE       ClosedPositionSortBy = "TITLE"
+	ClosedSortPRICE       ClosedPositionSortBy = "PRICE"
+	ClosedSortAVGPRICE    ClosedPositionSortBy = "AVGPRICE"
+	ClosedSortTIMESTAMP   ClosedPositionSortBy = "TIMESTAMP"
+)
+
+type ClosedPositionsQuery struct {
+	User          string                // required
+	Market        []string              // conditionIds (0x+64hex), mutually exclusive with EventID
+	EventID       []int64               // mutually exclusive with Market
+	Title         *string               // optional, max len 100
+	Limit         *int                  // default: 10, range: 0..50
+	Offset        *int                  // default: 0,  range: 0..100000
+	SortBy        *ClosedPositionSortBy // default: REALIZEDPNL
+	SortDirection *SortDirection        // default: DESC
+}
+
+type ClosedPosition struct {
+	ProxyWallet     string  `json:"proxyWallet"`
+	Asset           string  `json:"asset"`
+	ConditionID     string  `json:"conditionId"`
+	AvgPrice        float64 `json:"avgPrice"`
+	TotalBought     float64 `json:"totalBought"`
+	RealizedPnl     float64 `json:"realizedPnl"`
+	CurPrice        float64 `json:"curPrice"`
+	Timestamp       int64   `json:"timestamp"`
+	Title           string  `json:"title"`
+	Slug            string  `json:"slug"`
+	Icon            string  `json:"icon"`
+	EventSlug       string  `json:"eventSlug"`
+	Outcome         string  `json:"outcome"`
+	OutcomeIndex    int64   `json:"outcomeIndex"`
+	OppositeOutcome string  `json:"oppositeOutcome"`
+	OppositeAsset   string  `json:"oppositeAsset"`
+	EndDate         string  `json:"endDate"`
+}
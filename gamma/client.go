@@ -28,6 +28,13 @@ func NewClient(host string, chainId *big.Int) *Client {
 	}
 }
 
+// WithTransport routes the client's requests through rt, e.g. an
+// http.Recorder or http.Replayer.
+func (c *Client) WithTransport(rt http.RoundTripper) error {
+	c.client.SetTransport(rt)
+	return nil
+}
+
 func (c *Client) Search(ctx context.Context, p *types.SearchParams) (*types.SearchResponse, error) {
 	if p == nil {
 		return nil, fmt.Errorf("search params is nil")
@@ -122,3 +129,40 @@ func (c *Client) GetPublicProfileByWalletAddress(ctx context.Context, p *types.P
 
 	return &out, nil
 }
+
+// defaultPageSize is the page size of the GetAll* helpers when the params
+// leave Limit unset.
+const defaultPageSize = 100
+
+// collectPages calls fetch with increasing offsets until a page comes back
+// empty. A short page does not end the listing, as the server may cap limit.
+func collectPages[T any](offset, limit int, fetch func(offset, limit int) ([]T, error)) ([]T, error) {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	var all []T
+	for {
+		page, err := fetch(offset, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			return all, nil
+		}
+		all = append(all, page...)
+		offset += len(page)
+	}
+}
+
+func setPagination(qs url.Values, limit, offset int) error {
+	if limit < 0 || offset < 0 {
+		return fmt.Errorf("limit/offset must be >= 0")
+	}
+	if limit > 0 {
+		qs.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		qs.Set("offset", strconv.Itoa(offset))
+	}
+	return nil
+}
@@ -1,22 +1,76 @@
 package gamma_test
 
 import (
-	"github.com/override-coder/go-polymarket-sdk/gamma"
-	"github.com/stretchr/testify/assert"
+	"context"
 	"math/big"
+	"os"
 	"testing"
-)
 
-var (
-	PolymarketGammaURL = "https://gamma-api.polymarket.com"
-	chaindId           = big.NewInt(137)
+	"github.com/override-coder/go-polymarket-sdk/gamma"
+	"github.com/override-coder/go-polymarket-sdk/gamma/gammatest"
+	"github.com/override-coder/go-polymarket-sdk/gamma/types"
+	"github.com/stretchr/testify/assert"
 )
 
-func TestGetPositions(t *testing.T) {
-	client := gamma.NewClient(PolymarketGammaURL, chaindId)
+var chaindId = big.NewInt(137)
+
+func newGamma(t *testing.T) (*gammatest.Server, *gamma.Client) {
+	server, err := gammatest.NewServer(os.DirFS("testdata"))
+	assert.NoError(t, err)
+	t.Cleanup(server.Close)
+	return server, gamma.NewClient(server.URL, chaindId)
+}
+
+func TestGetMarketsAndEvents(t *testing.T) {
+	_, client := newGamma(t)
+	ctx := context.Background()
+
+	market, err := client.GetMarketsBySlug(ctx, "us-x-venezuela-military-engagement-by-september-30-659")
+	assert.NoError(t, err)
+	tokens, err := market.TokenIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3333", "4444"}, tokens)
+
+	_, err = client.GetMarketsBySlug(ctx, "missing")
+	assert.Error(t, err)
+
+	closed := false
+	markets, err := client.GetMarkets(ctx, &types.GetMarketsParams{Closed: &closed, CLOBTokenIDs: []string{"2222"}})
+	assert.NoError(t, err)
+	if assert.Len(t, markets, 1) {
+		assert.Equal(t, "516711", markets[0].ID)
+	}
+
+	tag := "fed-rates"
+	limit := 1
+	events, err := client.GetEventsByKeyset(ctx, &types.GetEventsKeysetParams{TagSlug: &tag, Limit: &limit})
+	assert.NoError(t, err)
+	assert.Len(t, events.Events, 1)
+	assert.Nil(t, events.NextCursor)
+}
+
+func TestFeedWatcherFixtures(t *testing.T) {
+	server, client := newGamma(t)
+	ctx := context.Background()
 
-	positions, err := client.GetMarketsBySlug(nil, "us-x-venezuela-military-engagement-by-september-30-659")
-	assert.Equal(t, nil, err)
-	t.Logf("positions: %v", positions)
+	w := gamma.NewFeedWatcher(client, gamma.FeedWatcherOptions{})
+	_, err := w.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, w.Snapshot(), 3)
 
+	server.PutMarket(map[string]any{
+		"id":              "516711",
+		"conditionId":     "0x2f5e0b7c1d4a6e8f9b3c5d7e9f1a3b5c7d9e1f3a5b7c9d1e3f5a7b9c1d3e5f7a",
+		"outcomePrices":   `["0.31", "0.69"]`,
+		"volume":          402113.5,
+		"active":          true,
+		"closed":          false,
+		"acceptingOrders": true,
+	})
+	events, err := w.Poll(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, gamma.FeedUpdated, events[0].Kind)
+		assert.Equal(t, []string{gamma.FieldOutcomePrices}, events[0].Changed)
+	}
 }
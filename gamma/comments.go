@@ -0,0 +1,243 @@
+package gamma
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/override-coder/go-polymarket-sdk/gamma/types"
+	http2 "github.com/override-coder/go-polymarket-sdk/http"
+)
+
+// GetComments lists the comments of an event, series or market, replies
+// included; see BuildCommentThreads to nest them.
+func (c *Client) GetComments(ctx context.Context, p *types.GetCommentsParams) ([]*types.Comment, error) {
+	if p == nil {
+		return nil, fmt.Errorf("get comments params is nil")
+	}
+	if strings.TrimSpace(p.ParentEntityType) == "" {
+		return nil, fmt.Errorf("parent_entity_type is required")
+	}
+
+	u := url.URL{Path: types.GET_COMMENTS}
+	qs := url.Values{}
+	if err := setPagination(qs, p.Limit, p.Offset); err != nil {
+		return nil, err
+	}
+	qs.Set("parent_entity_type", strings.TrimSpace(p.ParentEntityType))
+	qs.Set("parent_entity_id", strconv.Itoa(p.ParentEntityID))
+	if p.Order != nil && strings.TrimSpace(*p.Order) != "" {
+		qs.Set("order", strings.TrimSpace(*p.Order))
+	}
+	if p.Ascending != nil {
+		qs.Set("ascending", strconv.FormatBool(*p.Ascending))
+	}
+	if p.GetPositions != nil {
+		qs.Set("get_positions", strconv.FormatBool(*p.GetPositions))
+	}
+	if p.HoldersOnly != nil {
+		qs.Set("holders_only", strconv.FormatBool(*p.HoldersOnly))
+	}
+	u.RawQuery = qs.Encode()
+
+	var out []*types.Comment
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, u.String(), &http2.RequestOptions{}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return out, nil
+}
+
+// GetCommentsByID returns a comment with its replies.
+func (c *Client) GetCommentsByID(ctx context.Context, id string, getPositions bool) ([]*types.Comment, error) {
+	u := url.URL{Path: types.GET_COMMENTS_ID + url.PathEscape(id)}
+	if getPositions {
+		u.RawQuery = url.Values{"get_positions": {"true"}}.Encode()
+	}
+
+	var out []*types.Comment
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, u.String(), &http2.RequestOptions{}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return out, nil
+}
+
+func (c *Client) GetCommentsByUser(ctx context.Context, userAddress string, p *types.GetCommentsByUserParams) ([]*types.Comment, error) {
+	if p == nil {
+		return nil, fmt.Errorf("get comments by user params is nil")
+	}
+	userAddress = strings.TrimSpace(userAddress)
+	if userAddress == "" {
+		return nil, fmt.Errorf("user address is required")
+	}
+
+	u := url.URL{Path: types.GET_COMMENTS_USER_ADDRESS + url.PathEscape(userAddress)}
+	qs := url.Values{}
+	if err := setPagination(qs, p.Limit, p.Offset); err != nil {
+		return nil, err
+	}
+	if p.Order != nil && strings.TrimSpace(*p.Order) != "" {
+		qs.Set("order", strings.TrimSpace(*p.Order))
+	}
+	if p.Ascending != nil {
+		qs.Set("ascending", strconv.FormatBool(*p.Ascending))
+	}
+	u.RawQuery = qs.Encode()
+
+	var out []*types.Comment
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, u.String(), &http2.RequestOptions{}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return out, nil
+}
+
+// BuildCommentThreads nests replies under their parent comment, keeping the
+// input order. Replies whose parent is not in comments become roots.
+func BuildCommentThreads(comments []*types.Comment) []*types.CommentThread {
+	threads := make(map[string]*types.CommentThread, len(comments))
+	for _, comment := range comments {
+		if comment.ID != nil {
+			threads[*comment.ID] = &types.CommentThread{Comment: comment}
+		}
+	}
+
+	var roots []*types.CommentThread
+	for _, comment := range comments {
+		thread := &types.CommentThread{Comment: comment}
+		if comment.ID != nil {
+			thread = threads[*comment.ID]
+		}
+		if comment.ParentCommentID != nil {
+			if parent, ok := threads[*comment.ParentCommentID]; ok && parent != thread {
+				parent.Replies = append(parent.Replies, thread)
+				continue
+			}
+		}
+		roots = append(roots, thread)
+	}
+	return roots
+}
+
+// CommentIterator walks comments page by page. It is offset paging with
+// dedupe, not keyset paging: Gamma only paginates comments by offset, so each
+// page after the first starts one comment early, on the last comment already
+// returned, and asks for one more comment to make up for it. When that comment is not a returned one,
+// comments before it were deleted and shifted the offsets, and the iterator
+// backs up a page at a time until it finds a returned comment. Comments
+// already returned are skipped by ID, so new comments shifting the offsets do
+// not produce duplicates. Orders that move comments other than by inserting or
+// deleting them, like reactionCount, can still skip comments. Use it as:
+//
+//	it := client.IterateComments(params)
+//	for it.Next(ctx) {
+//		comment := it.Comment()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type CommentIterator struct {
+	fetch  func(ctx context.Context, offset, limit int) ([]*types.Comment, error)
+	offset int
+	limit  int
+	seen   map[string]bool
+	// overlap is set when the last fetched comment has an ID and can anchor
+	// the next page.
+	overlap bool
+
+	page    []*types.Comment
+	current *types.Comment
+	done    bool
+	err     error
+}
+
+// IterateComments iterates GetComments from p.Offset, p.Limit new comments per request.
+func (c *Client) IterateComments(p types.GetCommentsParams) *CommentIterator {
+	return newCommentIterator(p.Offset, p.Limit, func(ctx context.Context, offset, limit int) ([]*types.Comment, error) {
+		page := p
+		page.Offset, page.Limit = offset, limit
+		return c.GetComments(ctx, &page)
+	})
+}
+
+// IterateCommentsByUser iterates GetCommentsByUser from p.Offset, p.Limit new comments per request.
+func (c *Client) IterateCommentsByUser(userAddress string, p types.GetCommentsByUserParams) *CommentIterator {
+	return newCommentIterator(p.Offset, p.Limit, func(ctx context.Context, offset, limit int) ([]*types.Comment, error) {
+		page := p
+		page.Offset, page.Limit = offset, limit
+		return c.GetCommentsByUser(ctx, userAddress, &page)
+	})
+}
+
+func newCommentIterator(offset, limit int, fetch func(ctx context.Context, offset, limit int) ([]*types.Comment, error)) *CommentIterator {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	return &CommentIterator{fetch: fetch, offset: offset, limit: limit, seen: make(map[string]bool)}
+}
+
+// Next advances to the next comment, fetching a page when needed. It returns
+// false when the comments are exhausted or a request failed, see Err.
+func (it *CommentIterator) Next(ctx context.Context) bool {
+	for {
+		for len(it.page) > 0 {
+			comment := it.page[0]
+			it.page = it.page[1:]
+			if comment.ID != nil {
+				if it.seen[*comment.ID] {
+					continue
+				}
+				it.seen[*comment.ID] = true
+			}
+			it.current = comment
+			return true
+		}
+		if it.done || it.err != nil {
+			return false
+		}
+
+		from, limit := it.offset, it.limit
+		if it.overlap {
+			from, limit = from-1, limit+1
+		}
+		var page []*types.Comment
+		for {
+			var err error
+			if page, err = it.fetch(ctx, from, limit); err != nil {
+				it.err = err
+				return false
+			}
+			if !it.overlap || from == 0 || it.anchored(page) {
+				break
+			}
+			from = max(from-it.limit, 0)
+		}
+		// Only an empty page, or the anchor alone, ends the comments: the
+		// server may return fewer than limit.
+		it.done = len(page) == 0 || (it.overlap && len(page) == 1)
+		it.offset = from + len(page)
+		it.overlap = len(page) > 0 && page[len(page)-1].ID != nil
+		it.page = page
+	}
+}
+
+// anchored reports whether page starts with a comment already returned.
+func (it *CommentIterator) anchored(page []*types.Comment) bool {
+	return len(page) > 0 && page[0].ID != nil && it.seen[*page[0].ID]
+}
+
+func (it *CommentIterator) Comment() *types.Comment {
+	return it.current
+}
+
+// Offset is the offset after the last fetched comment; pass it back in the
+// params to resume later.
+func (it *CommentIterator) Offset() int {
+	return it.offset
+}
+
+func (it *CommentIterator) Err() error {
+	return it.err
+}
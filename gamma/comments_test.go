@@ -0,0 +1,108 @@
+package gamma_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/override-coder/go-polymarket-sdk/gamma"
+	"github.com/override-coder/go-polymarket-sdk/gamma/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCommentServer serves the comments of event 42 by offset. change runs
+// before every page but the first.
+func newCommentServer(t *testing.T, comments []string, change func(comments []string) []string) *httptest.Server {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := req.URL.Query()
+		if req.URL.Path != types.GET_COMMENTS || q.Get("parent_entity_type") != types.CommentEntityEvent || q.Get("parent_entity_id") != "42" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if requests++; requests > 1 && change != nil {
+			comments = change(comments)
+		}
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		end := min(offset+limit, len(comments))
+		_, _ = w.Write([]byte("[" + strings.Join(comments[min(offset, end):end], ",") + "]"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCommentsIteratorAndThreads(t *testing.T) {
+	// a new comment arrives after the first page, shifting "2" onto page two
+	server := newCommentServer(t, []string{
+		`{"id":"3","body":"newest"}`,
+		`{"id":"2","body":"reply","parentCommentID":"1"}`,
+		`{"id":"1","body":"root","reactions":[{"reactionType":"HEART"},{"reactionType":"HEART"},{"reactionType":"LIKE"}]}`,
+	}, func(comments []string) []string {
+		if len(comments) == 3 {
+			comments = append([]string{`{"id":"4","body":"newer"}`}, comments...)
+		}
+		return comments
+	})
+	client := gamma.NewClient(server.URL, chaindId)
+
+	it := client.IterateComments(types.GetCommentsParams{Limit: 2, ParentEntityType: types.CommentEntityEvent, ParentEntityID: 42})
+	var comments []*types.Comment
+	for it.Next(context.Background()) {
+		comments = append(comments, it.Comment())
+	}
+	assert.NoError(t, it.Err())
+	assert.Len(t, comments, 3)
+	assert.Equal(t, 4, it.Offset())
+
+	threads := gamma.BuildCommentThreads(comments)
+	assert.Len(t, threads, 2)
+	assert.Equal(t, "3", *threads[0].Comment.ID)
+	assert.Equal(t, "1", *threads[1].Comment.ID)
+	assert.Equal(t, "2", *threads[1].Replies[0].Comment.ID)
+	assert.Equal(t, map[string]int{"HEART": 2, "LIKE": 1}, threads[1].Comment.ReactionCounts())
+
+	it = client.IterateComments(types.GetCommentsParams{ParentEntityType: types.CommentEntitySeries, ParentEntityID: 1})
+	assert.False(t, it.Next(context.Background()))
+	assert.Error(t, it.Err())
+}
+
+func TestCommentsIteratorDeleted(t *testing.T) {
+	// the two comments of the first page are deleted before the second page,
+	// shifting "3" and "2" below the next offset
+	server := newCommentServer(t, []string{`{"id":"5"}`, `{"id":"4"}`, `{"id":"3"}`, `{"id":"2"}`, `{"id":"1"}`},
+		func(comments []string) []string {
+			if len(comments) == 5 {
+				comments = comments[2:]
+			}
+			return comments
+		})
+	client := gamma.NewClient(server.URL, chaindId)
+
+	it := client.IterateComments(types.GetCommentsParams{Limit: 2, ParentEntityType: types.CommentEntityEvent, ParentEntityID: 42})
+	var ids []string
+	for it.Next(context.Background()) {
+		ids = append(ids, *it.Comment().ID)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"5", "4", "3", "2", "1"}, ids)
+	assert.Equal(t, 3, it.Offset())
+}
+
+func TestCommentsIteratorLimitOne(t *testing.T) {
+	server := newCommentServer(t, []string{`{"id":"3"}`, `{"id":"2"}`, `{"id":"1"}`}, nil)
+	client := gamma.NewClient(server.URL, chaindId)
+
+	it := client.IterateComments(types.GetCommentsParams{Limit: 1, ParentEntityType: types.CommentEntityEvent, ParentEntityID: 42})
+	var ids []string
+	for it.Next(context.Background()) && len(ids) < 10 {
+		ids = append(ids, *it.Comment().ID)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"3", "2", "1"}, ids)
+	assert.Equal(t, 3, it.Offset())
+}
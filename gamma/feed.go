@@ -0,0 +1,467 @@
+package gamma
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/gamma/types"
+	"github.com/shopspring/decimal"
+)
+
+type FeedEventKind string
+
+const (
+	FeedCreated  FeedEventKind = "created"
+	FeedUpdated  FeedEventKind = "updated"
+	FeedClosed   FeedEventKind = "closed"
+	FeedResolved FeedEventKind = "resolved"
+)
+
+// Fields reported in FeedEvent.Changed.
+const (
+	FieldOutcomePrices   = "outcomePrices"
+	FieldVolume          = "volume"
+	FieldClosed          = "closed"
+	FieldAcceptingOrders = "acceptingOrders"
+	FieldResolution      = "umaResolutionStatus"
+)
+
+const resolvedStatus = "resolved"
+
+// MarketState is the part of a market the FeedWatcher diffs between polls.
+type MarketState struct {
+	MarketID         string          `json:"marketId"`
+	EventID          string          `json:"eventId,omitempty"`
+	Slug             string          `json:"slug,omitempty"`
+	ConditionID      string          `json:"conditionId,omitempty"`
+	OutcomePrices    []string        `json:"outcomePrices,omitempty"`
+	Volume           decimal.Decimal `json:"volume"`
+	Closed           bool            `json:"closed"`
+	AcceptingOrders  bool            `json:"acceptingOrders"`
+	ResolutionStatus string          `json:"umaResolutionStatus,omitempty"`
+}
+
+// FeedEvent is a change observed for one market. Previous is nil for
+// FeedCreated. A failed poll is reported as an event with only Err and At set.
+type FeedEvent struct {
+	Kind     FeedEventKind
+	MarketID string
+	Previous *MarketState
+	Current  MarketState
+	// Changed lists the fields that differ from Previous.
+	Changed []string
+	Market  *types.Market
+	// Event is the parent event when polling events, nil when polling markets.
+	Event *types.Event
+	At    time.Time
+	Err   error
+}
+
+// SnapshotStore persists the FeedWatcher snapshot so a restarted watcher only
+// emits what changed while it was down.
+type SnapshotStore interface {
+	Load(ctx context.Context) (map[string]MarketState, error)
+	Save(ctx context.Context, snapshot map[string]MarketState) error
+}
+
+// FileSnapshotStore keeps the snapshot in a JSON file.
+type FileSnapshotStore struct {
+	Path string
+}
+
+func NewFileSnapshotStore(path string) *FileSnapshotStore {
+	return &FileSnapshotStore{Path: path}
+}
+
+// Load returns an empty snapshot when the file does not exist yet.
+func (s *FileSnapshotStore) Load(_ context.Context) (map[string]MarketState, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return map[string]MarketState{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshot := map[string]MarketState{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("decode snapshot %s: %w", s.Path, err)
+	}
+	return snapshot, nil
+}
+
+// Save writes to a temporary file first so a crash never leaves a truncated snapshot.
+func (s *FileSnapshotStore) Save(_ context.Context, snapshot map[string]MarketState) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+type FeedWatcherOptions struct {
+	Interval time.Duration // default 1m
+	// Events polls GetEventsByKeyset with these filters (tag, active, volume
+	// and liquidity thresholds) and watches the markets of every event.
+	// Filtering out closed events hides the Closed and Resolved transitions.
+	Events *types.GetEventsKeysetParams
+	// Markets polls GetMarketsByKeyset instead of events when set.
+	Markets *types.GetMarketsKeysetParams
+	// MaxPages bounds the keyset pages walked per poll, default 50.
+	MaxPages int
+	// MinPriceChange ignores outcome price moves smaller than this.
+	MinPriceChange decimal.Decimal
+	// MinVolumeChange ignores volume changes smaller than this.
+	MinVolumeChange decimal.Decimal
+	// EmitInitial emits FeedCreated for every market of the first poll when
+	// the store is empty. By default the first poll only seeds the snapshot.
+	EmitInitial bool
+	// Store persists the snapshot after every poll, default in memory only.
+	Store SnapshotStore
+	// Buffer is the capacity of the events channel, default 64.
+	Buffer int
+}
+
+// FeedWatcher polls Gamma for the markets matching its filters, diffs them
+// against a local snapshot and emits the changes on Events. Markets that drop
+// out of the filtered feed are kept in the snapshot without an event.
+type FeedWatcher struct {
+	client *Client
+	opts   FeedWatcherOptions
+
+	events chan FeedEvent
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	pollMu   sync.Mutex
+	snapshot map[string]MarketState
+	loaded   bool
+
+	mu      sync.Mutex
+	started bool
+	closed  bool
+}
+
+func NewFeedWatcher(c *Client, opts FeedWatcherOptions) *FeedWatcher {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Minute
+	}
+	if opts.Events == nil && opts.Markets == nil {
+		active := true
+		opts.Events = &types.GetEventsKeysetParams{Active: &active}
+	}
+	if opts.MaxPages <= 0 {
+		opts.MaxPages = 50
+	}
+	if opts.Buffer <= 0 {
+		opts.Buffer = 64
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &FeedWatcher{
+		client: c,
+		opts:   opts,
+		events: make(chan FeedEvent, opts.Buffer),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Events returns the change stream. It is closed by Close.
+func (w *FeedWatcher) Events() <-chan FeedEvent {
+	return w.events
+}
+
+// Start polls immediately and then every Interval until Close.
+func (w *FeedWatcher) Start() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return fmt.Errorf("feed watcher closed")
+	}
+	if w.started {
+		return fmt.Errorf("feed watcher already started")
+	}
+	w.started = true
+	w.wg.Add(1)
+	go w.run()
+	return nil
+}
+
+// Close stops polling, waits for the poller to exit and closes Events.
+func (w *FeedWatcher) Close() {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	w.cancel()
+	w.wg.Wait()
+	close(w.events)
+}
+
+// Snapshot returns a copy of the current snapshot keyed by market ID.
+func (w *FeedWatcher) Snapshot() map[string]MarketState {
+	w.pollMu.Lock()
+	defer w.pollMu.Unlock()
+	out := make(map[string]MarketState, len(w.snapshot))
+	for id, state := range w.snapshot {
+		out[id] = state
+	}
+	return out
+}
+
+// Poll runs a single poll and returns its changes without emitting them.
+// The snapshot is updated and saved as in Start.
+func (w *FeedWatcher) Poll(ctx context.Context) ([]FeedEvent, error) {
+	w.pollMu.Lock()
+	defer w.pollMu.Unlock()
+
+	seed := false
+	if !w.loaded {
+		snapshot := map[string]MarketState{}
+		if w.opts.Store != nil {
+			loaded, err := w.opts.Store.Load(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("load feed snapshot: %w", err)
+			}
+			if loaded != nil {
+				snapshot = loaded
+			}
+		}
+		w.snapshot = snapshot
+		w.loaded = true
+		seed = len(snapshot) == 0 && !w.opts.EmitInitial
+	}
+
+	markets, err := w.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var out []FeedEvent
+	dirty := false
+	for _, fm := range markets {
+		current := marketState(fm.market, fm.event)
+		previous, ok := w.snapshot[current.MarketID]
+		if !ok {
+			w.snapshot[current.MarketID] = current
+			dirty = true
+			if !seed {
+				out = append(out, FeedEvent{Kind: FeedCreated, MarketID: current.MarketID, Current: current, Market: fm.market, Event: fm.event, At: now})
+			}
+			continue
+		}
+
+		changed := w.diff(previous, current)
+		if len(changed) == 0 {
+			continue
+		}
+		w.snapshot[current.MarketID] = current
+		dirty = true
+		prev := previous
+		ev := FeedEvent{MarketID: current.MarketID, Previous: &prev, Current: current, Changed: changed, Market: fm.market, Event: fm.event, At: now}
+		closed := !previous.Closed && current.Closed
+		resolved := previous.ResolutionStatus != resolvedStatus && current.ResolutionStatus == resolvedStatus
+		if closed {
+			ev.Kind = FeedClosed
+			out = append(out, ev)
+		}
+		if resolved {
+			ev.Kind = FeedResolved
+			out = append(out, ev)
+		}
+		if !closed && !resolved {
+			ev.Kind = FeedUpdated
+			out = append(out, ev)
+		}
+	}
+
+	if dirty && w.opts.Store != nil {
+		if err := w.opts.Store.Save(ctx, w.snapshot); err != nil {
+			return out, fmt.Errorf("save feed snapshot: %w", err)
+		}
+	}
+	return out, nil
+}
+
+func (w *FeedWatcher) run() {
+	defer w.wg.Done()
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		events, err := w.Poll(w.ctx)
+		for _, ev := range events {
+			if !w.emit(ev) {
+				return
+			}
+		}
+		if err != nil && w.ctx.Err() == nil {
+			if !w.emit(FeedEvent{At: time.Now(), Err: err}) {
+				return
+			}
+		}
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// emit delivers an event unless the watcher is closed first.
+func (w *FeedWatcher) emit(ev FeedEvent) bool {
+	select {
+	case w.events <- ev:
+		return true
+	case <-w.ctx.Done():
+		return false
+	}
+}
+
+type feedMarket struct {
+	market *types.Market
+	event  *types.Event
+}
+
+func (w *FeedWatcher) fetch(ctx context.Context) ([]feedMarket, error) {
+	var out []feedMarket
+	if w.opts.Markets != nil {
+		p := *w.opts.Markets
+		for page := 0; page < w.opts.MaxPages; page++ {
+			resp, err := w.client.GetMarketsByKeyset(ctx, &p)
+			if err != nil {
+				return nil, fmt.Errorf("get markets by keyset: %w", err)
+			}
+			for i := range resp.Markets {
+				out = append(out, feedMarket{market: &resp.Markets[i]})
+			}
+			if !nextCursor(resp.NextCursor, &p.AfterCursor) {
+				break
+			}
+		}
+		return out, nil
+	}
+
+	p := *w.opts.Events
+	for page := 0; page < w.opts.MaxPages; page++ {
+		resp, err := w.client.GetEventsByKeyset(ctx, &p)
+		if err != nil {
+			return nil, fmt.Errorf("get events by keyset: %w", err)
+		}
+		for i := range resp.Events {
+			event := &resp.Events[i]
+			for j := range event.Markets {
+				out = append(out, feedMarket{market: &event.Markets[j], event: event})
+			}
+		}
+		if !nextCursor(resp.NextCursor, &p.AfterCursor) {
+			break
+		}
+	}
+	return out, nil
+}
+
+// nextCursor advances after to next and reports whether there is another page.
+func nextCursor(next *string, after **string) bool {
+	if next == nil || strings.TrimSpace(*next) == "" {
+		return false
+	}
+	if *after != nil && **after == *next {
+		return false
+	}
+	cursor := *next
+	*after = &cursor
+	return true
+}
+
+func (w *FeedWatcher) diff(previous, current MarketState) []string {
+	var changed []string
+	if pricesMoved(previous.OutcomePrices, current.OutcomePrices, w.opts.MinPriceChange) {
+		changed = append(changed, FieldOutcomePrices)
+	}
+	if !previous.Volume.Equal(current.Volume) && previous.Volume.Sub(current.Volume).Abs().GreaterThanOrEqual(w.opts.MinVolumeChange) {
+		changed = append(changed, FieldVolume)
+	}
+	if previous.Closed != current.Closed {
+		changed = append(changed, FieldClosed)
+	}
+	if previous.AcceptingOrders != current.AcceptingOrders {
+		changed = append(changed, FieldAcceptingOrders)
+	}
+	if previous.ResolutionStatus != current.ResolutionStatus {
+		changed = append(changed, FieldResolution)
+	}
+	return changed
+}
+
+func pricesMoved(previous, current []string, min decimal.Decimal) bool {
+	if len(previous) != len(current) {
+		return true
+	}
+	for i := range previous {
+		a, errA := decimal.NewFromString(previous[i])
+		b, errB := decimal.NewFromString(current[i])
+		if errA != nil || errB != nil {
+			if previous[i] != current[i] {
+				return true
+			}
+			continue
+		}
+		if !a.Equal(b) && a.Sub(b).Abs().GreaterThanOrEqual(min) {
+			return true
+		}
+	}
+	return false
+}
+
+func marketState(m *types.Market, event *types.Event) MarketState {
+	state := MarketState{MarketID: m.ID, ConditionID: m.ConditionID}
+	if event != nil {
+		state.EventID = event.ID
+	} else if len(m.Events) > 0 {
+		state.EventID = m.Events[0].ID
+	}
+	if m.Slug != nil {
+		state.Slug = *m.Slug
+	}
+	if prices, err := types.DecodeStringArray(m.OutcomePrices); err == nil {
+		state.OutcomePrices = prices
+	} else {
+		state.OutcomePrices = []string{*m.OutcomePrices}
+	}
+	if m.VolumeNum != nil {
+		state.Volume = *m.VolumeNum
+	} else if m.Volume != nil {
+		state.Volume, _ = decimal.NewFromString(*m.Volume)
+	}
+	state.Closed = m.Closed != nil && *m.Closed
+	state.AcceptingOrders = m.AcceptingOrders != nil && *m.AcceptingOrders
+	if m.UmaResolutionStatus != nil {
+		state.ResolutionStatus = *m.UmaResolutionStatus
+	}
+	return state
+}
@@ -0,0 +1,128 @@
+package gamma_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/gamma"
+	"github.com/override-coder/go-polymarket-sdk/gamma/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+type feedServer struct {
+	mu      sync.Mutex
+	markets map[string]map[string]any
+}
+
+func (s *feedServer) set(id string, fields map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.markets[id]
+	if !ok {
+		m = map[string]any{"id": id, "conditionId": "0x" + id, "acceptingOrders": true, "closed": false}
+		s.markets[id] = m
+	}
+	for k, v := range fields {
+		m[k] = v
+	}
+}
+
+// ServeHTTP serves one market per keyset page, in ID order.
+func (s *feedServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if req.URL.Path != types.GET_EVENTS_KEYSET {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var ids []string
+	for _, id := range []string{"1", "2", "3"} {
+		if _, ok := s.markets[id]; ok {
+			ids = append(ids, id)
+		}
+	}
+	index := 0
+	if after := req.URL.Query().Get("after_cursor"); after != "" {
+		for i, id := range ids {
+			if id == after {
+				index = i + 1
+			}
+		}
+	}
+	resp := map[string]any{"events": []any{}}
+	if index < len(ids) {
+		id := ids[index]
+		resp["events"] = []any{map[string]any{"id": "e" + id, "markets": []any{s.markets[id]}}}
+		if index+1 < len(ids) {
+			resp["next_cursor"] = id
+		}
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func kinds(events []gamma.FeedEvent) map[string]gamma.FeedEventKind {
+	out := map[string]gamma.FeedEventKind{}
+	for _, ev := range events {
+		out[ev.MarketID+":"+string(ev.Kind)] = ev.Kind
+	}
+	return out
+}
+
+func TestFeedWatcherPoll(t *testing.T) {
+	fs := &feedServer{markets: map[string]map[string]any{}}
+	fs.set("1", map[string]any{"outcomePrices": `["0.5","0.5"]`, "volumeNum": 100})
+	fs.set("2", map[string]any{"outcomePrices": `["0.2","0.8"]`, "volumeNum": 10})
+	server := httptest.NewServer(fs)
+	defer server.Close()
+	client := gamma.NewClient(server.URL, chaindId)
+	store := gamma.NewFileSnapshotStore(filepath.Join(t.TempDir(), "feed.json"))
+	ctx := context.Background()
+
+	w := gamma.NewFeedWatcher(client, gamma.FeedWatcherOptions{Store: store})
+	events, err := w.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, events, "first poll only seeds the snapshot")
+	assert.Len(t, w.Snapshot(), 2)
+	assert.Equal(t, "e2", w.Snapshot()["2"].EventID)
+
+	fs.set("1", map[string]any{"outcomePrices": `["0.6","0.4"]`})
+	fs.set("2", map[string]any{"closed": true, "acceptingOrders": false})
+	fs.set("3", map[string]any{"outcomePrices": `["0.5","0.5"]`})
+	events, err = w.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]gamma.FeedEventKind{"1:updated": gamma.FeedUpdated, "2:closed": gamma.FeedClosed, "3:created": gamma.FeedCreated}, kinds(events))
+	for _, ev := range events {
+		if ev.Kind == gamma.FeedClosed {
+			assert.Equal(t, []string{gamma.FieldClosed, gamma.FieldAcceptingOrders}, ev.Changed)
+			assert.False(t, ev.Previous.Closed)
+		}
+	}
+
+	// a restarted watcher picks up the saved snapshot instead of re-seeding
+	fs.set("2", map[string]any{"umaResolutionStatus": "resolved"})
+	fs.set("1", map[string]any{"volumeNum": 100.5})
+	restarted := gamma.NewFeedWatcher(client, gamma.FeedWatcherOptions{Store: store, MinVolumeChange: decimal.NewFromInt(1)})
+	events, err = restarted.Poll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]gamma.FeedEventKind{"2:resolved": gamma.FeedResolved}, kinds(events))
+
+	fs.set("1", map[string]any{"volumeNum": 102})
+	assert.NoError(t, restarted.Start())
+	defer restarted.Close()
+	select {
+	case ev := <-restarted.Events():
+		assert.NoError(t, ev.Err)
+		assert.Equal(t, gamma.FeedUpdated, ev.Kind)
+		assert.Equal(t, []string{gamma.FieldVolume}, ev.Changed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no feed event")
+	}
+}
@@ -0,0 +1,110 @@
+package gammatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	datatypes "github.com/override-coder/go-polymarket-sdk/dataapi/types"
+)
+
+// dataFilters maps Data API query parameters to the fields they match.
+var dataFilters = map[string][]string{
+	"user":    {"proxyWallet", "user"},
+	"market":  {"conditionId", "market"},
+	"asset":   {"asset"},
+	"eventId": {"eventId"},
+	"side":    {"side"},
+	"type":    {"type"},
+}
+
+// DataServer is a fake Data API listening on URL. Each endpoint is served
+// from the fixture named after its path, e.g. positions.json for /positions
+// and v1-leaderboard.json for /v1/leaderboard. Arrays are filtered by the
+// user, market, asset, eventId, side and type parameters and paged by limit
+// and offset; other fixtures are served as they are.
+type DataServer struct {
+	URL string
+
+	server *httptest.Server
+}
+
+func NewDataServer(fixtures fs.FS) (*DataServer, error) {
+	mux := http.NewServeMux()
+	for _, path := range []string{
+		datatypes.GET_POSITIONS, datatypes.GET_Activity, datatypes.GET_VALUE, datatypes.GET_LEADERBOARD,
+		datatypes.GET_TRADES, datatypes.GET_HOLDERS, datatypes.GET_OPEN_INTEREST, datatypes.GET_LIVE_VOLUME,
+		datatypes.GET_CLOSED_POSITIONS,
+	} {
+		name := strings.ReplaceAll(strings.TrimPrefix(path, "/"), "/", "-") + ".json"
+		raw, err := fs.ReadFile(fixtures, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var fixture any
+		if err := dec.Decode(&fixture); err != nil {
+			return nil, fmt.Errorf("gammatest: decode %s: %w", name, err)
+		}
+		mux.HandleFunc("GET "+path, serveData(fixture))
+	}
+
+	s := &DataServer{server: httptest.NewServer(mux)}
+	s.URL = s.server.URL
+	return s, nil
+}
+
+func (s *DataServer) Close() {
+	s.server.Close()
+}
+
+func serveData(fixture any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		list, ok := fixture.([]any)
+		if !ok {
+			writeJSON(w, http.StatusOK, fixture)
+			return
+		}
+		q := r.URL.Query()
+		var objects []object
+		for _, item := range list {
+			if o, ok := item.(object); ok {
+				objects = append(objects, o)
+			}
+		}
+		var filters []filter
+		for param, fields := range dataFilters {
+			values := q[param]
+			if len(values) == 1 {
+				values = strings.Split(values[0], ",")
+			}
+			filters = append(filters, anyField(values, fields))
+		}
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		writeJSON(w, http.StatusOK, page(apply(objects, filters...), limit, offset))
+	}
+}
+
+// anyField matches entities where the first of fields they have is one of
+// values. Entities without any of the fields always match.
+func anyField(values []string, fields []string) filter {
+	return func(o object) bool {
+		for _, field := range fields {
+			if _, ok := o[field]; ok {
+				return oneOf(values, field)(o)
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,346 @@
+// Package gammatest provides in-process fakes of the Gamma and Data APIs
+// seeded from JSON fixture files, for offline tests of the gamma and dataapi
+// clients and of the discovery code built on them.
+//
+// Fixtures are served as written, so stringified fields reach the clients
+// exactly as the real APIs encode them.
+package gammatest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/override-coder/go-polymarket-sdk/gamma/types"
+)
+
+// object is one fixture entity, decoded with json.Number to keep numbers as
+// they were written.
+type object = map[string]any
+
+// Server is a fake Gamma API listening on URL. It loads markets.json,
+// events.json and tags.json from its fixture directory, each an array; files
+// that do not exist are served empty. Markets nested in events are served by
+// the market endpoints too.
+type Server struct {
+	URL string
+
+	server *httptest.Server
+
+	mu      sync.Mutex
+	markets []object
+	events  []object
+	tags    []object
+}
+
+func NewServer(fixtures fs.FS) (*Server, error) {
+	s := &Server{}
+	for name, dst := range map[string]*[]object{"markets.json": &s.markets, "events.json": &s.events, "tags.json": &s.tags} {
+		if err := load(fixtures, name, dst); err != nil {
+			return nil, err
+		}
+	}
+	for _, event := range s.events {
+		for _, m := range nested(event) {
+			if s.find(s.markets, "id", str(m["id"])) == nil {
+				s.markets = append(s.markets, m)
+			}
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+types.GET_MARKETS, s.handleMarkets)
+	mux.HandleFunc("GET "+types.GET_MARKETS_KEYSET, s.handleMarketsKeyset)
+	mux.HandleFunc("GET "+types.GET_MARKETS_ID+"{id}", s.handleByKey(&s.markets, "id"))
+	mux.HandleFunc("GET "+types.GET_MARKETS_SLUG+"{id}", s.handleByKey(&s.markets, "slug"))
+	mux.HandleFunc("GET "+types.GET_EVENTS_KEYSET, s.handleEventsKeyset)
+	mux.HandleFunc("GET "+types.GET_EVENTS_ID+"{id}", s.handleByKey(&s.events, "id"))
+	mux.HandleFunc("GET "+types.GET_EVENTS_SLUG+"{id}", s.handleByKey(&s.events, "slug"))
+	mux.HandleFunc("GET "+types.GET_TAGS, s.handleTags)
+	mux.HandleFunc("GET "+types.GET_TAGS_ID+"{id}", s.handleByKey(&s.tags, "id"))
+	mux.HandleFunc("GET "+types.GET_TAGS_SLUG+"{id}", s.handleByKey(&s.tags, "slug"))
+
+	s.server = httptest.NewServer(mux)
+	s.URL = s.server.URL
+	return s, nil
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// PutMarket adds market, or replaces the market with the same id, including
+// its copies nested in events.
+func (s *Server) PutMarket(market map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.markets = put(s.markets, market)
+	for _, event := range s.events {
+		list, ok := event["markets"].([]any)
+		if !ok {
+			continue
+		}
+		for i, m := range list {
+			if m, ok := m.(object); ok && str(m["id"]) == str(market["id"]) {
+				list[i] = market
+			}
+		}
+	}
+}
+
+// PutEvent adds event, or replaces the event with the same id.
+func (s *Server) PutEvent(event map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = put(s.events, event)
+	for _, m := range nested(event) {
+		s.markets = put(s.markets, m)
+	}
+}
+
+func load(fixtures fs.FS, name string, dst *[]object) error {
+	raw, err := fs.ReadFile(fixtures, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("gammatest: decode %s: %w", name, err)
+	}
+	return nil
+}
+
+func nested(event object) []object {
+	list, _ := event["markets"].([]any)
+	out := make([]object, 0, len(list))
+	for _, m := range list {
+		if m, ok := m.(object); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func put(list []object, obj object) []object {
+	for i, o := range list {
+		if str(o["id"]) == str(obj["id"]) {
+			list[i] = obj
+			return list
+		}
+	}
+	return append(list, obj)
+}
+
+// str formats a fixture value for comparison with a query parameter.
+func str(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (s *Server) find(list []object, key, value string) object {
+	for _, o := range list {
+		if str(o[key]) == value {
+			return o
+		}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) handleByKey(list *[]object, key string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if o := s.find(*list, key, r.PathValue("id")); o != nil {
+			writeJSON(w, http.StatusOK, o)
+			return
+		}
+		writeJSON(w, http.StatusNotFound, map[string]string{"type": "not found error", "error": key + " not found"})
+	}
+}
+
+// filter is a query parameter check on one fixture entity.
+type filter func(o object) bool
+
+// oneOf matches entities whose field is one of the values of param, if set.
+func oneOf(values []string, field string) filter {
+	return func(o object) bool {
+		if len(values) == 0 {
+			return true
+		}
+		for _, v := range values {
+			if strings.EqualFold(v, str(o[field])) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// flag matches entities whose boolean field equals param, if set.
+func flag(value, field string) filter {
+	return func(o object) bool {
+		if value == "" {
+			return true
+		}
+		b, _ := o[field].(bool)
+		return strconv.FormatBool(b) == value
+	}
+}
+
+// tokenIn matches markets listing one of ids in clobTokenIds, which Gamma
+// encodes as a JSON string.
+func tokenIn(ids []string) filter {
+	return func(o object) bool {
+		if len(ids) == 0 {
+			return true
+		}
+		raw := str(o["clobTokenIds"])
+		var tokens []string
+		if err := json.Unmarshal([]byte(raw), &tokens); err != nil {
+			if list, ok := o["clobTokenIds"].([]any); ok {
+				for _, t := range list {
+					tokens = append(tokens, str(t))
+				}
+			}
+		}
+		for _, t := range tokens {
+			for _, id := range ids {
+				if t == id {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+func apply(list []object, filters ...filter) []object {
+	out := []object{}
+next:
+	for _, o := range list {
+		for _, f := range filters {
+			if !f(o) {
+				continue next
+			}
+		}
+		out = append(out, o)
+	}
+	return out
+}
+
+func (s *Server) marketFilters(r *http.Request) []filter {
+	q := r.URL.Query()
+	return []filter{
+		oneOf(q["id"], "id"),
+		oneOf(q["slug"], "slug"),
+		oneOf(q["condition_ids"], "conditionId"),
+		oneOf(q["question_ids"], "questionID"),
+		tokenIn(q["clob_token_ids"]),
+		flag(q.Get("closed"), "closed"),
+		flag(q.Get("active"), "active"),
+	}
+}
+
+// page slices list by limit and offset; a missing or zero limit returns the
+// rest of the list.
+func page(list []object, limit, offset int) []object {
+	if offset >= len(list) {
+		return []object{}
+	}
+	list = list[offset:]
+	if limit > 0 && limit < len(list) {
+		list = list[:limit]
+	}
+	return list
+}
+
+// keyset pages list with an opaque cursor, returning the next cursor or nil.
+func keyset(r *http.Request, list []object) ([]object, *string) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 20
+	}
+	offset := 0
+	if after := r.URL.Query().Get("after_cursor"); after != "" {
+		raw, _ := base64.StdEncoding.DecodeString(after)
+		offset, _ = strconv.Atoi(string(raw))
+	}
+	out := page(list, limit, offset)
+	if offset+len(out) >= len(list) {
+		return out, nil
+	}
+	next := base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(offset + len(out))))
+	return out, &next
+}
+
+func (s *Server) handleMarkets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	writeJSON(w, http.StatusOK, page(apply(s.markets, s.marketFilters(r)...), limit, offset))
+}
+
+func (s *Server) handleMarketsKeyset(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	markets, next := keyset(r, apply(s.markets, s.marketFilters(r)...))
+	writeJSON(w, http.StatusOK, map[string]any{"markets": markets, "next_cursor": next})
+}
+
+func (s *Server) handleEventsKeyset(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q := r.URL.Query()
+	tagged := func(o object) bool {
+		slug := q.Get("tag_slug")
+		if slug == "" {
+			return true
+		}
+		tags, _ := o["tags"].([]any)
+		for _, t := range tags {
+			if t, ok := t.(object); ok && str(t["slug"]) == slug {
+				return true
+			}
+		}
+		return false
+	}
+	titled := func(o object) bool {
+		search := strings.ToLower(q.Get("title_search"))
+		return search == "" || strings.Contains(strings.ToLower(str(o["title"])), search)
+	}
+	events, next := keyset(r, apply(s.events, flag(q.Get("active"), "active"), flag(q.Get("closed"), "closed"), tagged, titled))
+	writeJSON(w, http.StatusOK, map[string]any{"events": events, "next_cursor": next})
+}
+
+func (s *Server) handleTags(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	writeJSON(w, http.StatusOK, page(s.tags, limit, offset))
+}
@@ -0,0 +1,89 @@
+package gamma
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/override-coder/go-polymarket-sdk/gamma/types"
+	http2 "github.com/override-coder/go-polymarket-sdk/http"
+)
+
+func (c *Client) GetSeries(ctx context.Context, p *types.GetSeriesParams) ([]*types.Series, error) {
+	if p == nil {
+		return nil, fmt.Errorf("get series params is nil")
+	}
+
+	u := url.URL{Path: types.GET_SERIES}
+	qs := url.Values{}
+	if err := setPagination(qs, p.Limit, p.Offset); err != nil {
+		return nil, err
+	}
+	if p.Order != nil && strings.TrimSpace(*p.Order) != "" {
+		qs.Set("order", strings.TrimSpace(*p.Order))
+	}
+	if p.Ascending != nil {
+		qs.Set("ascending", strconv.FormatBool(*p.Ascending))
+	}
+	for _, s := range p.Slug {
+		s = strings.TrimSpace(s)
+		if s != "" {
+			qs.Add("slug", s)
+		}
+	}
+	for _, id := range p.CategoriesIDs {
+		qs.Add("categories_ids", strconv.Itoa(id))
+	}
+	for _, label := range p.CategoriesLabels {
+		label = strings.TrimSpace(label)
+		if label != "" {
+			qs.Add("categories_labels", label)
+		}
+	}
+	if p.Closed != nil {
+		qs.Set("closed", strconv.FormatBool(*p.Closed))
+	}
+	if p.IncludeChat != nil {
+		qs.Set("include_chat", strconv.FormatBool(*p.IncludeChat))
+	}
+	if p.Recurrence != nil && strings.TrimSpace(*p.Recurrence) != "" {
+		qs.Set("recurrence", strings.TrimSpace(*p.Recurrence))
+	}
+	u.RawQuery = qs.Encode()
+
+	var out []*types.Series
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, u.String(), &http2.RequestOptions{}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return out, nil
+}
+
+// GetAllSeries pages through GetSeries from p.Offset, p.Limit series per request.
+func (c *Client) GetAllSeries(ctx context.Context, p *types.GetSeriesParams) ([]*types.Series, error) {
+	if p == nil {
+		return nil, fmt.Errorf("get series params is nil")
+	}
+	return collectPages(p.Offset, p.Limit, func(offset, limit int) ([]*types.Series, error) {
+		page := *p
+		page.Offset, page.Limit = offset, limit
+		return c.GetSeries(ctx, &page)
+	})
+}
+
+func (c *Client) GetSeriesByID(ctx context.Context, id string, includeChat bool) (*types.Series, error) {
+	u := url.URL{Path: types.GET_SERIES_ID + url.PathEscape(id)}
+	if includeChat {
+		u.RawQuery = url.Values{"include_chat": {"true"}}.Encode()
+	}
+
+	var out types.Series
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, u.String(), &http2.RequestOptions{}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return &out, nil
+}
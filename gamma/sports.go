@@ -0,0 +1,79 @@
+package gamma
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/override-coder/go-polymarket-sdk/gamma/types"
+	http2 "github.com/override-coder/go-polymarket-sdk/http"
+)
+
+// GetSports lists the sports (leagues) with their tag IDs and series.
+func (c *Client) GetSports(ctx context.Context) ([]*types.SportMetadata, error) {
+	var out []*types.SportMetadata
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, types.GET_SPORTS, &http2.RequestOptions{}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return out, nil
+}
+
+// GetSportsMarketTypes lists the values accepted by GetMarketsParams.SportsMarketTypes.
+func (c *Client) GetSportsMarketTypes(ctx context.Context) ([]string, error) {
+	var out types.SportsMarketTypesResponse
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, types.GET_SPORTS_MARKET_TYPES, &http2.RequestOptions{}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return out.MarketTypes, nil
+}
+
+func (c *Client) GetTeams(ctx context.Context, p *types.GetTeamsParams) ([]*types.Team, error) {
+	if p == nil {
+		return nil, fmt.Errorf("get teams params is nil")
+	}
+
+	u := url.URL{Path: types.GET_TEAMS}
+	qs := url.Values{}
+	if err := setPagination(qs, p.Limit, p.Offset); err != nil {
+		return nil, err
+	}
+	if p.Order != nil && strings.TrimSpace(*p.Order) != "" {
+		qs.Set("order", strings.TrimSpace(*p.Order))
+	}
+	if p.Ascending != nil {
+		qs.Set("ascending", strconv.FormatBool(*p.Ascending))
+	}
+	for key, values := range map[string][]string{"league": p.League, "name": p.Name, "abbreviation": p.Abbreviation} {
+		for _, v := range values {
+			v = strings.TrimSpace(v)
+			if v != "" {
+				qs.Add(key, v)
+			}
+		}
+	}
+	u.RawQuery = qs.Encode()
+
+	var out []*types.Team
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, u.String(), &http2.RequestOptions{}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return out, nil
+}
+
+// GetAllTeams pages through GetTeams from p.Offset, p.Limit teams per request.
+func (c *Client) GetAllTeams(ctx context.Context, p *types.GetTeamsParams) ([]*types.Team, error) {
+	if p == nil {
+		return nil, fmt.Errorf("get teams params is nil")
+	}
+	return collectPages(p.Offset, p.Limit, func(offset, limit int) ([]*types.Team, error) {
+		page := *p
+		page.Offset, page.Limit = offset, limit
+		return c.GetTeams(ctx, &page)
+	})
+}
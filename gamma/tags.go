@@ -0,0 +1,128 @@
+package gamma
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/override-coder/go-polymarket-sdk/gamma/types"
+	http2 "github.com/override-coder/go-polymarket-sdk/http"
+)
+
+func (c *Client) GetTags(ctx context.Context, p *types.GetTagsParams) ([]*types.Tag, error) {
+	if p == nil {
+		return nil, fmt.Errorf("get tags params is nil")
+	}
+
+	u := url.URL{Path: types.GET_TAGS}
+	qs := url.Values{}
+	if err := setPagination(qs, p.Limit, p.Offset); err != nil {
+		return nil, err
+	}
+	if p.Order != nil && strings.TrimSpace(*p.Order) != "" {
+		qs.Set("order", strings.TrimSpace(*p.Order))
+	}
+	if p.Ascending != nil {
+		qs.Set("ascending", strconv.FormatBool(*p.Ascending))
+	}
+	if p.IncludeTemplate != nil {
+		qs.Set("include_template", strconv.FormatBool(*p.IncludeTemplate))
+	}
+	if p.IsCarousel != nil {
+		qs.Set("is_carousel", strconv.FormatBool(*p.IsCarousel))
+	}
+	u.RawQuery = qs.Encode()
+
+	var out []*types.Tag
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, u.String(), &http2.RequestOptions{}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return out, nil
+}
+
+// GetAllTags pages through GetTags from p.Offset, p.Limit tags per request.
+func (c *Client) GetAllTags(ctx context.Context, p *types.GetTagsParams) ([]*types.Tag, error) {
+	if p == nil {
+		return nil, fmt.Errorf("get tags params is nil")
+	}
+	return collectPages(p.Offset, p.Limit, func(offset, limit int) ([]*types.Tag, error) {
+		page := *p
+		page.Offset, page.Limit = offset, limit
+		return c.GetTags(ctx, &page)
+	})
+}
+
+func (c *Client) GetTagByID(ctx context.Context, id string) (*types.Tag, error) {
+	return c.getTag(ctx, types.GET_TAGS_ID+url.PathEscape(id))
+}
+
+func (c *Client) GetTagBySlug(ctx context.Context, slug string) (*types.Tag, error) {
+	return c.getTag(ctx, types.GET_TAGS_SLUG+url.PathEscape(slug))
+}
+
+func (c *Client) getTag(ctx context.Context, requestPath string) (*types.Tag, error) {
+	var out types.Tag
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, requestPath, &http2.RequestOptions{}, &out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return nil, e
+	}
+	return &out, nil
+}
+
+// GetRelatedTagsByID returns the relationships of a tag, see GetRelatedTagsTagsByID
+// for the related tags themselves.
+func (c *Client) GetRelatedTagsByID(ctx context.Context, id string, p *types.RelatedTagsParams) ([]*types.RelatedTag, error) {
+	var out []*types.RelatedTag
+	if err := c.getRelatedTags(ctx, types.GET_TAGS_ID+url.PathEscape(id)+"/related-tags", p, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) GetRelatedTagsBySlug(ctx context.Context, slug string, p *types.RelatedTagsParams) ([]*types.RelatedTag, error) {
+	var out []*types.RelatedTag
+	if err := c.getRelatedTags(ctx, types.GET_TAGS_SLUG+url.PathEscape(slug)+"/related-tags", p, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) GetRelatedTagsTagsByID(ctx context.Context, id string, p *types.RelatedTagsParams) ([]*types.Tag, error) {
+	var out []*types.Tag
+	if err := c.getRelatedTags(ctx, types.GET_TAGS_ID+url.PathEscape(id)+"/related-tags/tags", p, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) GetRelatedTagsTagsBySlug(ctx context.Context, slug string, p *types.RelatedTagsParams) ([]*types.Tag, error) {
+	var out []*types.Tag
+	if err := c.getRelatedTags(ctx, types.GET_TAGS_SLUG+url.PathEscape(slug)+"/related-tags/tags", p, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) getRelatedTags(ctx context.Context, path string, p *types.RelatedTagsParams, out interface{}) error {
+	u := url.URL{Path: path}
+	qs := url.Values{}
+	if p != nil {
+		if p.OmitEmpty != nil {
+			qs.Set("omit_empty", strconv.FormatBool(*p.OmitEmpty))
+		}
+		if p.Status != nil && strings.TrimSpace(*p.Status) != "" {
+			qs.Set("status", strings.TrimSpace(*p.Status))
+		}
+	}
+	u.RawQuery = qs.Encode()
+
+	resp, err := c.client.DoRequest(ctx, http.MethodGet, u.String(), &http2.RequestOptions{}, out)
+	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		return e
+	}
+	return nil
+}
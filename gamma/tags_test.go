@@ -0,0 +1,70 @@
+package gamma_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/override-coder/go-polymarket-sdk/gamma"
+	"github.com/override-coder/go-polymarket-sdk/gamma/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllTagsAndTeams(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		queries = append(queries, req.URL.Path+"?"+req.URL.RawQuery)
+		switch req.URL.Path {
+		case types.GET_TAGS:
+			offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+			// The server caps pages at 3 tags.
+			limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
+			limit = min(limit, 3)
+			var page []map[string]string
+			for i := offset; i < offset+limit && i < 5; i++ {
+				page = append(page, map[string]string{"id": strconv.Itoa(i), "slug": fmt.Sprintf("tag-%d", i)})
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		case types.GET_TAGS_SLUG + "politics/related-tags/tags":
+			_, _ = w.Write([]byte(`[{"id":"2","label":"Elections","slug":"elections"}]`))
+		case types.GET_TEAMS:
+			_, _ = w.Write([]byte(`[{"id":1,"name":"Lakers","league":"nba","abbreviation":"LAL"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := gamma.NewClient(server.URL, chaindId)
+	ctx := context.Background()
+
+	tags, err := client.GetAllTags(ctx, &types.GetTagsParams{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, tags, 5)
+	assert.Equal(t, "tag-4", *tags[4].Slug)
+	assert.Equal(t, []string{"/tags?limit=2", "/tags?limit=2&offset=2", "/tags?limit=2&offset=4", "/tags?limit=2&offset=5"}, queries)
+
+	queries = nil
+	tags, err = client.GetAllTags(ctx, &types.GetTagsParams{Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, tags, 5, "short pages do not end the listing")
+	assert.Equal(t, []string{"/tags?limit=10", "/tags?limit=10&offset=3", "/tags?limit=10&offset=5"}, queries)
+
+	status := "active"
+	related, err := client.GetRelatedTagsTagsBySlug(ctx, "politics", &types.RelatedTagsParams{Status: &status})
+	assert.NoError(t, err)
+	assert.Equal(t, "elections", *related[0].Slug)
+	assert.Equal(t, "/tags/slug/politics/related-tags/tags?status=active", queries[len(queries)-1])
+
+	teams, err := client.GetTeams(ctx, &types.GetTeamsParams{Limit: 10, League: []string{"nba"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "LAL", *teams[0].Abbreviation)
+	assert.Equal(t, "/teams?league=nba&limit=10", queries[len(queries)-1])
+
+	_, err = client.GetTags(ctx, &types.GetTagsParams{Limit: -1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Gamma encodes some fields as a string in one response and as a number in
+// another, and sends stringified arrays as plain ones. looseField decodes such
+// a field into the Market or Event field it points to: a string field accepts
+// any JSON value as its text, and a numeric or bool field a quoted value, with
+// a blank string decoding to nil.
+type looseField[T any] struct {
+	p **T
+}
+
+func loose[T any](p **T) looseField[T] {
+	return looseField[T]{p: p}
+}
+
+func (f looseField[T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		*f.p = nil
+		return nil
+	}
+	v := new(T)
+	err := json.Unmarshal(data, v)
+	if err == nil {
+		*f.p = v
+		return nil
+	}
+	if s, ok := any(v).(*string); ok {
+		*s = string(data)
+		*f.p = v
+		return nil
+	}
+	var quoted string
+	if json.Unmarshal(data, &quoted) != nil {
+		return err
+	}
+	quoted = strings.TrimSpace(quoted)
+	if quoted == "" {
+		*f.p = nil
+		return nil
+	}
+	if err := json.Unmarshal([]byte(quoted), v); err != nil {
+		return err
+	}
+	*f.p = v
+	return nil
+}
+
+// DecodeStringArray decodes a stringified JSON array such as "[\"Yes\", \"No\"]".
+// A nil or blank s decodes to nil.
+func DecodeStringArray(s *string) ([]string, error) {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil, nil
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(*s), &raw); err != nil {
+		return nil, err
+	}
+	out := make([]string, len(raw))
+	for i, item := range raw {
+		var str string
+		if err := json.Unmarshal(item, &str); err != nil {
+			// tolerate unquoted numbers, e.g. [0.5, 0.5]
+			str = string(bytes.TrimSpace(item))
+		}
+		out[i] = str
+	}
+	return out, nil
+}
+
+// DecodeDecimalArray decodes a stringified JSON array of numbers, quoted or not.
+func DecodeDecimalArray(s *string) ([]decimal.Decimal, error) {
+	items, err := DecodeStringArray(s)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		return nil, nil
+	}
+	out := make([]decimal.Decimal, len(items))
+	for i, item := range items {
+		if out[i], err = decimal.NewFromString(strings.TrimSpace(item)); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,126 @@
+package types_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/override-coder/go-polymarket-sdk/gamma/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+type marketSummary struct {
+	ID               string                `json:"id"`
+	Liquidity        *string               `json:"liquidity"`
+	Volume           *string               `json:"volume"`
+	VolumeNum        string                `json:"volumeNum"`
+	OrderMinSize     string                `json:"orderMinSize"`
+	TickSize         string                `json:"orderPriceMinTickSize"`
+	CreatedBy        *int                  `json:"createdBy"`
+	Active           *bool                 `json:"active"`
+	Closed           *bool                 `json:"closed"`
+	ResolutionStatus *string               `json:"umaResolutionStatus"`
+	Outcomes         []types.MarketOutcome `json:"outcomes"`
+}
+
+func summarize(t *testing.T, m *types.Market) marketSummary {
+	outcomes, err := m.OutcomeList()
+	assert.NoError(t, err)
+	s := marketSummary{
+		ID:               m.ID,
+		Liquidity:        m.Liquidity,
+		Volume:           m.Volume,
+		CreatedBy:        m.CreatedBy,
+		Active:           m.Active,
+		Closed:           m.Closed,
+		ResolutionStatus: m.UmaResolutionStatus,
+		Outcomes:         outcomes,
+	}
+	if m.VolumeNum != nil {
+		s.VolumeNum = m.VolumeNum.String()
+	}
+	if m.OrderMinSize != nil {
+		s.OrderMinSize = m.OrderMinSize.String()
+	}
+	if m.OrderPriceMinTickSize != nil {
+		s.TickSize = m.OrderPriceMinTickSize.String()
+	}
+	return s
+}
+
+func assertGolden(t *testing.T, name string, got any) {
+	data, err := json.MarshalIndent(got, "", "  ")
+	assert.NoError(t, err)
+	path := filepath.Join("testdata", name+".golden.json")
+	if *update {
+		assert.NoError(t, os.WriteFile(path, append(data, '\n'), 0o644))
+		return
+	}
+	want, err := os.ReadFile(path)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, string(want), string(data))
+	}
+}
+
+func TestMarketDecodingGolden(t *testing.T) {
+	for _, name := range []string{"market_strings", "market_numbers"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+			assert.NoError(t, err)
+			var m types.Market
+			if assert.NoError(t, json.Unmarshal(data, &m)) {
+				assertGolden(t, name, summarize(t, &m))
+			}
+		})
+	}
+
+	data, err := os.ReadFile(filepath.Join("testdata", "event.json"))
+	assert.NoError(t, err)
+	var e types.Event
+	assert.NoError(t, json.Unmarshal(data, &e))
+	assert.Equal(t, "1834220.512773", e.Volume.String())
+	assert.Equal(t, 412, *e.CommentCount)
+	if assert.Len(t, e.Markets, 1) {
+		assertGolden(t, "event_market", summarize(t, &e.Markets[0]))
+	}
+}
+
+func TestDecodeArrays(t *testing.T) {
+	s := `["0.5", 0.25, "1e-2"]`
+	prices, err := types.DecodeDecimalArray(&s)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0.5", "0.25", "0.01"}, []string{prices[0].String(), prices[1].String(), prices[2].String()})
+
+	blank := " "
+	names, err := types.DecodeStringArray(&blank)
+	assert.NoError(t, err)
+	assert.Nil(t, names)
+
+	bad := `["Yes"`
+	_, err = types.DecodeStringArray(&bad)
+	assert.Error(t, err)
+
+	outcomes, tokens := `["Yes","No"]`, `["1"]`
+	_, err = (&types.Market{Outcomes: &outcomes, ClobTokenIds: &tokens}).OutcomeList()
+	assert.Error(t, err)
+}
+
+func TestOutcomeListBadPrice(t *testing.T) {
+	var m types.Market
+	data := `{"id":"1","outcomes":"[\"Yes\", \"No\"]","outcomePrices":"[\"n/a\", 0.4]","clobTokenIds":["1","2"]}`
+	assert.NoError(t, json.Unmarshal([]byte(data), &m))
+	outcomes, err := m.OutcomeList()
+	assert.NoError(t, err)
+	if assert.Len(t, outcomes, 2) {
+		assert.True(t, outcomes[0].Price.IsZero())
+		assert.Equal(t, "0.4", outcomes[1].Price.String())
+		assert.Equal(t, "2", outcomes[1].TokenID)
+	}
+
+	bad := `{"id":"1","createdBy":"seven"}`
+	assert.Error(t, json.Unmarshal([]byte(bad), &m))
+}
@@ -10,4 +10,15 @@ const (
 	GET_MARKETS_SLUG                     = "/markets/slug/"
 	GET_PUBLIC_SEARCH                    = "/public-search"
 	GET_PUBLIC_PROFILE_BY_WALLET_ADDRESS = "/public-profile"
+	GET_TAGS                             = "/tags"
+	GET_TAGS_ID                          = "/tags/"
+	GET_TAGS_SLUG                        = "/tags/slug/"
+	GET_SERIES                           = "/series"
+	GET_SERIES_ID                        = "/series/"
+	GET_SPORTS                           = "/sports"
+	GET_SPORTS_MARKET_TYPES              = "/sports/market-types"
+	GET_TEAMS                            = "/teams"
+	GET_COMMENTS                         = "/comments"
+	GET_COMMENTS_ID                      = "/comments/"
+	GET_COMMENTS_USER_ADDRESS            = "/comments/user_address/"
 )
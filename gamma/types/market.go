@@ -0,0 +1,147 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+func (m *Market) UnmarshalJSON(data []byte) error {
+	type market Market
+	aux := struct {
+		*market
+		Liquidity             looseField[string]          `json:"liquidity"`
+		Volume                looseField[string]          `json:"volume"`
+		Outcomes              looseField[string]          `json:"outcomes"`
+		OutcomePrices         looseField[string]          `json:"outcomePrices"`
+		ClobTokenIds          looseField[string]          `json:"clobTokenIds"`
+		ShortOutcomes         looseField[string]          `json:"shortOutcomes"`
+		Fee                   looseField[string]          `json:"fee"`
+		UmaBond               looseField[string]          `json:"umaBond"`
+		UmaReward             looseField[string]          `json:"umaReward"`
+		Active                looseField[bool]            `json:"active"`
+		Closed                looseField[bool]            `json:"closed"`
+		AcceptingOrders       looseField[bool]            `json:"acceptingOrders"`
+		CreatedBy             looseField[int]             `json:"createdBy"`
+		UpdatedBy             looseField[int]             `json:"updatedBy"`
+		SecondsDelay          looseField[int]             `json:"secondsDelay"`
+		Line                  looseField[float64]         `json:"line"`
+		OrderPriceMinTickSize looseField[decimal.Decimal] `json:"orderPriceMinTickSize"`
+		OrderMinSize          looseField[decimal.Decimal] `json:"orderMinSize"`
+		VolumeNum             looseField[decimal.Decimal] `json:"volumeNum"`
+		LiquidityNum          looseField[decimal.Decimal] `json:"liquidityNum"`
+		BestBid               looseField[decimal.Decimal] `json:"bestBid"`
+		BestAsk               looseField[decimal.Decimal] `json:"bestAsk"`
+	}{
+		market:                (*market)(m),
+		Liquidity:             loose(&m.Liquidity),
+		Volume:                loose(&m.Volume),
+		Outcomes:              loose(&m.Outcomes),
+		OutcomePrices:         loose(&m.OutcomePrices),
+		ClobTokenIds:          loose(&m.ClobTokenIds),
+		ShortOutcomes:         loose(&m.ShortOutcomes),
+		Fee:                   loose(&m.Fee),
+		UmaBond:               loose(&m.UmaBond),
+		UmaReward:             loose(&m.UmaReward),
+		Active:                loose(&m.Active),
+		Closed:                loose(&m.Closed),
+		AcceptingOrders:       loose(&m.AcceptingOrders),
+		CreatedBy:             loose(&m.CreatedBy),
+		UpdatedBy:             loose(&m.UpdatedBy),
+		SecondsDelay:          loose(&m.SecondsDelay),
+		Line:                  loose(&m.Line),
+		OrderPriceMinTickSize: loose(&m.OrderPriceMinTickSize),
+		OrderMinSize:          loose(&m.OrderMinSize),
+		VolumeNum:             loose(&m.VolumeNum),
+		LiquidityNum:          loose(&m.LiquidityNum),
+		BestBid:               loose(&m.BestBid),
+		BestAsk:               loose(&m.BestAsk),
+	}
+	return json.Unmarshal(data, &aux)
+}
+
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type event Event
+	aux := struct {
+		*event
+		Active         looseField[bool]            `json:"active"`
+		Closed         looseField[bool]            `json:"closed"`
+		NegRisk        looseField[bool]            `json:"negRisk"`
+		Liquidity      looseField[decimal.Decimal] `json:"liquidity"`
+		Volume         looseField[decimal.Decimal] `json:"volume"`
+		OpenInterest   looseField[decimal.Decimal] `json:"openInterest"`
+		Competitive    looseField[decimal.Decimal] `json:"competitive"`
+		CreatedBy      looseField[string]          `json:"createdBy"`
+		UpdatedBy      looseField[string]          `json:"updatedBy"`
+		NegRiskFeeBips looseField[int]             `json:"negRiskFeeBips"`
+		CommentCount   looseField[int]             `json:"commentCount"`
+	}{
+		event:          (*event)(e),
+		Active:         loose(&e.Active),
+		Closed:         loose(&e.Closed),
+		NegRisk:        loose(&e.NegRisk),
+		Liquidity:      loose(&e.Liquidity),
+		Volume:         loose(&e.Volume),
+		OpenInterest:   loose(&e.OpenInterest),
+		Competitive:    loose(&e.Competitive),
+		CreatedBy:      loose(&e.CreatedBy),
+		UpdatedBy:      loose(&e.UpdatedBy),
+		NegRiskFeeBips: loose(&e.NegRiskFeeBips),
+		CommentCount:   loose(&e.CommentCount),
+	}
+	return json.Unmarshal(data, &aux)
+}
+
+// MarketOutcome pairs an outcome with its CLOB token and Gamma price.
+type MarketOutcome struct {
+	Name    string          `json:"name"`
+	TokenID string          `json:"tokenId"`
+	Price   decimal.Decimal `json:"price"` // zero when Gamma has no price
+}
+
+// OutcomeNames decodes the stringified outcomes field.
+func (m *Market) OutcomeNames() ([]string, error) {
+	return DecodeStringArray(m.Outcomes)
+}
+
+// OutcomePriceValues decodes the stringified outcomePrices field.
+func (m *Market) OutcomePriceValues() ([]decimal.Decimal, error) {
+	return DecodeDecimalArray(m.OutcomePrices)
+}
+
+// TokenIDs decodes the stringified clobTokenIds field.
+func (m *Market) TokenIDs() ([]string, error) {
+	return DecodeStringArray(m.ClobTokenIds)
+}
+
+// OutcomeList pairs outcomes with token IDs and prices by position. It is not
+// named Outcomes as that is the raw field. Prices Gamma sends malformed are
+// left zero.
+func (m *Market) OutcomeList() ([]MarketOutcome, error) {
+	names, err := m.OutcomeNames()
+	if err != nil {
+		return nil, fmt.Errorf("decode outcomes: %w", err)
+	}
+	tokenIDs, err := m.TokenIDs()
+	if err != nil {
+		return nil, fmt.Errorf("decode clobTokenIds: %w", err)
+	}
+	if len(tokenIDs) > 0 && len(names) != len(tokenIDs) {
+		return nil, fmt.Errorf("market %s has %d outcomes but %d clob token ids", m.ID, len(names), len(tokenIDs))
+	}
+	prices, _ := DecodeStringArray(m.OutcomePrices)
+
+	out := make([]MarketOutcome, len(names))
+	for i, name := range names {
+		out[i].Name = name
+		if i < len(tokenIDs) {
+			out[i].TokenID = tokenIDs[i]
+		}
+		if i < len(prices) {
+			out[i].Price, _ = decimal.NewFromString(strings.TrimSpace(prices[i]))
+		}
+	}
+	return out, nil
+}
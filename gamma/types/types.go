@@ -560,3 +560,170 @@ type GetEventsKeysetResponse struct {
 	Events     []Event `json:"events"`
 	NextCursor *string `json:"next_cursor"`
 }
+
+type GetTagsParams struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+
+	Order           *string `json:"order,omitempty"`
+	Ascending       *bool   `json:"ascending,omitempty"`
+	IncludeTemplate *bool   `json:"include_template,omitempty"`
+	IsCarousel      *bool   `json:"is_carousel,omitempty"`
+}
+
+// RelatedTagsParams filters /tags/{id}/related-tags. Status is one of
+// "active", "closed" or "all".
+type RelatedTagsParams struct {
+	OmitEmpty *bool   `json:"omit_empty,omitempty"`
+	Status    *string `json:"status,omitempty"`
+}
+
+// RelatedTag links TagID to RelatedTagID; lower Rank is closer.
+type RelatedTag struct {
+	ID           *string `json:"id"`
+	TagID        *int    `json:"tagID"`
+	RelatedTagID *int    `json:"relatedTagID"`
+	Rank         *int    `json:"rank"`
+}
+
+type GetSeriesParams struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+
+	Order            *string  `json:"order,omitempty"`
+	Ascending        *bool    `json:"ascending,omitempty"`
+	Slug             []string `json:"slug,omitempty"`
+	CategoriesIDs    []int    `json:"categories_ids,omitempty"`
+	CategoriesLabels []string `json:"categories_labels,omitempty"`
+	Closed           *bool    `json:"closed,omitempty"`
+	IncludeChat      *bool    `json:"include_chat,omitempty"`
+	Recurrence       *string  `json:"recurrence,omitempty"`
+}
+
+// SportMetadata is an element of /sports. Tags is a comma-separated list of
+// tag IDs and Series the ID of the league's series.
+type SportMetadata struct {
+	Sport      *string `json:"sport"`
+	Image      *string `json:"image"`
+	Resolution *string `json:"resolution"`
+	Ordering   *string `json:"ordering"`
+	Tags       *string `json:"tags"`
+	Series     *string `json:"series"`
+}
+
+type SportsMarketTypesResponse struct {
+	MarketTypes []string `json:"marketTypes"`
+}
+
+type GetTeamsParams struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+
+	Order        *string  `json:"order,omitempty"`
+	Ascending    *bool    `json:"ascending,omitempty"`
+	League       []string `json:"league,omitempty"`
+	Name         []string `json:"name,omitempty"`
+	Abbreviation []string `json:"abbreviation,omitempty"`
+}
+
+type Team struct {
+	ID           *int    `json:"id"`
+	Name         *string `json:"name"`
+	League       *string `json:"league"`
+	Record       *string `json:"record"`
+	Logo         *string `json:"logo"`
+	Abbreviation *string `json:"abbreviation"`
+	Alias        *string `json:"alias"`
+	CreatedAt    *string `json:"createdAt"`
+	UpdatedAt    *string `json:"updatedAt"`
+}
+
+// Comment parent entity types accepted by GetCommentsParams.ParentEntityType.
+const (
+	CommentEntityEvent  = "Event"
+	CommentEntitySeries = "Series"
+	CommentEntityMarket = "market"
+)
+
+type GetCommentsParams struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+
+	Order            *string `json:"order,omitempty"`
+	Ascending        *bool   `json:"ascending,omitempty"`
+	ParentEntityType string  `json:"parent_entity_type"`
+	ParentEntityID   int     `json:"parent_entity_id"`
+	GetPositions     *bool   `json:"get_positions,omitempty"`
+	HoldersOnly      *bool   `json:"holders_only,omitempty"`
+}
+
+type GetCommentsByUserParams struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+
+	Order     *string `json:"order,omitempty"`
+	Ascending *bool   `json:"ascending,omitempty"`
+}
+
+// Comment is a Gamma comment. ParentCommentID is set on replies.
+type Comment struct {
+	ID               *string           `json:"id"`
+	Body             *string           `json:"body"`
+	ParentEntityType *string           `json:"parentEntityType"`
+	ParentEntityID   *int              `json:"parentEntityID"`
+	ParentCommentID  *string           `json:"parentCommentID"`
+	UserAddress      *string           `json:"userAddress"`
+	ReplyAddress     *string           `json:"replyAddress"`
+	CreatedAt        *time.Time        `json:"createdAt"`
+	UpdatedAt        *time.Time        `json:"updatedAt"`
+	Profile          *CommentProfile   `json:"profile"`
+	Reactions        []CommentReaction `json:"reactions"`
+	ReportCount      *int              `json:"reportCount"`
+	ReactionCount    *int              `json:"reactionCount"`
+}
+
+// ReactionCounts counts the loaded reactions by ReactionType.
+func (c *Comment) ReactionCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, r := range c.Reactions {
+		if r.ReactionType != nil {
+			counts[*r.ReactionType]++
+		}
+	}
+	return counts
+}
+
+type CommentProfile struct {
+	Name                  *string           `json:"name"`
+	Pseudonym             *string           `json:"pseudonym"`
+	DisplayUsernamePublic *bool             `json:"displayUsernamePublic"`
+	Bio                   *string           `json:"bio"`
+	IsMod                 *bool             `json:"isMod"`
+	IsCreator             *bool             `json:"isCreator"`
+	ProxyWallet           *string           `json:"proxyWallet"`
+	BaseAddress           *string           `json:"baseAddress"`
+	ProfileImage          *string           `json:"profileImage"`
+	Positions             []CommentPosition `json:"positions"`
+}
+
+// CommentPosition is a holding of the commenter, returned with get_positions.
+type CommentPosition struct {
+	TokenID      *string          `json:"tokenId"`
+	PositionSize *decimal.Decimal `json:"positionSize"`
+}
+
+type CommentReaction struct {
+	ID           *string         `json:"id"`
+	CommentID    *int            `json:"commentID"`
+	ReactionType *string         `json:"reactionType"`
+	Icon         *string         `json:"icon"`
+	UserAddress  *string         `json:"userAddress"`
+	CreatedAt    *time.Time      `json:"createdAt"`
+	Profile      *CommentProfile `json:"profile"`
+}
+
+// CommentThread is a comment with its replies, see gamma.BuildCommentThreads.
+type CommentThread struct {
+	Comment *Comment
+	Replies []*CommentThread
+}
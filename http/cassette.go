@@ -0,0 +1,278 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redacted replaces secrets in recorded cassettes.
+const Redacted = "REDACTED"
+
+var (
+	// RedactedHeaders are the request and response headers whose values are
+	// never written to a cassette.
+	RedactedHeaders = []string{
+		"POLY_SIGNATURE", "POLY_API_KEY", "POLY_PASSPHRASE",
+		"POLY_BUILDER_SIGNATURE", "POLY_BUILDER_API_KEY", "POLY_BUILDER_PASSPHRASE",
+		"Authorization", "Cookie", "Set-Cookie",
+	}
+	// RedactedFields are the JSON body fields, at any depth, whose values are
+	// never written to a cassette. owner holds the API key in order payloads
+	// and trades.
+	RedactedFields = []string{"signature", "secret", "passphrase", "apiKey", "owner"}
+	// VolatileFields are the JSON body fields, at any depth, that change on
+	// every run and are ignored when matching a request.
+	VolatileFields = []string{"salt"}
+)
+
+// Interaction is one recorded request and its response, one line of a
+// cassette.
+type Interaction struct {
+	Method          string            `json:"method"`
+	Path            string            `json:"path"`
+	Query           string            `json:"query,omitempty"`
+	RequestHeaders  map[string]string `json:"request_headers,omitempty"`
+	RequestBody     string            `json:"request_body,omitempty"`
+	Status          int               `json:"status"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	ResponseBody    string            `json:"response_body,omitempty"`
+	RecordedAt      time.Time         `json:"recorded_at"`
+}
+
+// key identifies the request an interaction answers.
+func (i *Interaction) key() string {
+	return i.Method + " " + i.Path + "?" + i.Query + " " + normalizeBody(i.RequestBody, VolatileFields)
+}
+
+func readBody(body io.ReadCloser) ([]byte, error) {
+	if body == nil || body == http.NoBody {
+		return nil, nil
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k := range h {
+		out[k] = h.Get(k)
+	}
+	for _, name := range RedactedHeaders {
+		if h.Get(name) != "" {
+			out[http.CanonicalHeaderKey(name)] = Redacted
+		}
+	}
+	return out
+}
+
+// rewrite replaces the values of fields at any depth of v with replacement,
+// or drops them when replacement is nil.
+func rewrite(v any, fields []string, replacement any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			matched := false
+			for _, f := range fields {
+				if strings.EqualFold(k, f) {
+					matched = true
+				}
+			}
+			switch {
+			case matched && replacement == nil:
+				delete(v, k)
+			case matched:
+				v[k] = replacement
+			default:
+				v[k] = rewrite(val, fields, replacement)
+			}
+		}
+	case []any:
+		for i, val := range v {
+			v[i] = rewrite(val, fields, replacement)
+		}
+	}
+	return v
+}
+
+// redactBody redacts RedactedFields of a JSON body; other bodies are kept.
+func redactBody(body []byte) string {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if len(body) == 0 || dec.Decode(&v) != nil {
+		return string(body)
+	}
+	out, _ := json.Marshal(rewrite(v, RedactedFields, Redacted))
+	return string(out)
+}
+
+// normalizeBody returns a JSON body with sorted keys, redacted secrets and
+// without ignored fields, so it compares equal across runs.
+func normalizeBody(body string, ignored []string) string {
+	var v any
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	if body == "" || dec.Decode(&v) != nil {
+		return body
+	}
+	v = rewrite(rewrite(v, RedactedFields, Redacted), ignored, nil)
+	out, _ := json.Marshal(v)
+	return string(out)
+}
+
+// Recorder is an http.RoundTripper that writes every request it forwards
+// and its response to a JSONL cassette, with secrets redacted.
+type Recorder struct {
+	next http.RoundTripper
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewRecorder records to w the round trips of next, http.DefaultTransport
+// if nil.
+func NewRecorder(w io.Writer, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{next: next, w: w}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if reqBody != nil {
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Method:          req.Method,
+		Path:            req.URL.Path,
+		Query:           req.URL.Query().Encode(),
+		RequestHeaders:  redactHeaders(req.Header),
+		RequestBody:     redactBody(reqBody),
+		Status:          resp.StatusCode,
+		ResponseHeaders: redactHeaders(resp.Header),
+		ResponseBody:    redactBody(respBody),
+		RecordedAt:      time.Now().UTC(),
+	}
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.w.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("record %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	return resp, nil
+}
+
+// Replayer is an http.RoundTripper that answers requests from a cassette
+// without touching the network. A request matches an interaction with the
+// same method, path, sorted query and JSON body, ignoring redacted and
+// VolatileFields; identical requests are answered in recorded order.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer reads a cassette written by Recorder.
+func NewReplayer(r io.Reader) (*Replayer, error) {
+	p := &Replayer{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var i Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &i); err != nil {
+			return nil, fmt.Errorf("cassette line %d: %w", line, err)
+		}
+		p.interactions = append(p.interactions, i)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	p.used = make([]bool, len(p.interactions))
+	return p, nil
+}
+
+// LoadCassette opens a cassette file for replay.
+func LoadCassette(path string) (*Replayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return NewReplayer(f)
+}
+
+func (p *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	want := Interaction{Method: req.Method, Path: req.URL.Path, Query: req.URL.Query().Encode(), RequestBody: string(body)}
+	key := want.key()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for n := range p.interactions {
+		i := &p.interactions[n]
+		if p.used[n] || i.key() != key {
+			continue
+		}
+		p.used[n] = true
+		header := make(http.Header, len(i.ResponseHeaders))
+		for k, v := range i.ResponseHeaders {
+			header.Set(k, v)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Status, http.StatusText(i.Status)),
+			StatusCode:    i.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(i.ResponseBody)),
+			ContentLength: int64(len(i.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("replay: no recorded interaction for %s %s", req.Method, req.URL.RequestURI())
+}
+
+// Pending returns the interactions not replayed yet.
+func (p *Replayer) Pending() []Interaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var out []Interaction
+	for n, i := range p.interactions {
+		if !p.used[n] {
+			out = append(out, i)
+		}
+	}
+	return out
+}
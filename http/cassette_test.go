@@ -0,0 +1,96 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/override-coder/go-polymarket-sdk/clob"
+	"github.com/override-coder/go-polymarket-sdk/clob/clobtest"
+	clobtypes "github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/gamma"
+	"github.com/override-coder/go-polymarket-sdk/gamma/gammatest"
+	gammatypes "github.com/override-coder/go-polymarket-sdk/gamma/types"
+	http2 "github.com/override-coder/go-polymarket-sdk/http"
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var chainId = big.NewInt(137)
+
+func TestRecordReplayOrder(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	signer := signing.NewPrivateKeySigner(key)
+	creds := sdktypes.ApiKeyCreds{
+		ApiKey:     "00000000-0000-0000-0000-000000000001",
+		Secret:     base64.URLEncoding.EncodeToString([]byte("cassette-secret")),
+		Passphrase: "cassette-passphrase",
+	}
+	auth := &sdktypes.AuthOption{SignatureType: model.EOA, SingerAddress: signer.Address().Hex(), ApiKeyCreds: &creds}
+	order := clobtypes.UserOrder{TokenID: "1234", Price: 0.5, Size: 10, Side: clobtypes.BUY}
+
+	s := clobtest.NewServer(chainId, clobtest.Options{})
+	s.AddMarket(clobtest.Market{TokenID: "1234", ConditionID: "0xc0", Outcome: "Yes", MinOrderSize: decimal.NewFromInt(5)})
+	s.AddAPIKey(auth.SingerAddress, creds)
+
+	var cassette bytes.Buffer
+	client := clob.NewClient(s.URL, chainId, signing.ToSignatureFunc(signer), nil)
+	assert.NoError(t, client.WithTransport(http2.NewRecorder(&cassette, nil)))
+	recorded, err := client.CreateOrder(context.Background(), order, clobtypes.OrderTypeGTC, false, auth)
+	assert.NoError(t, err)
+	s.Close()
+
+	for _, secret := range []string{creds.ApiKey, creds.Secret, creds.Passphrase} {
+		assert.NotContains(t, cassette.String(), secret)
+	}
+	assert.Contains(t, cassette.String(), `"Poly_signature":"REDACTED"`)
+
+	replayer, err := http2.NewReplayer(strings.NewReader(cassette.String()))
+	assert.NoError(t, err)
+	client = clob.NewClient(s.URL, chainId, signing.ToSignatureFunc(signer), nil)
+	assert.NoError(t, client.WithTransport(replayer))
+	replayed, err := client.CreateOrder(context.Background(), order, clobtypes.OrderTypeGTC, false, auth)
+	assert.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+	assert.Empty(t, replayer.Pending())
+
+	// A different order was never recorded.
+	order.Price = 0.6
+	_, err = client.CreateOrder(context.Background(), order, clobtypes.OrderTypeGTC, false, auth)
+	assert.ErrorContains(t, err, "no recorded interaction")
+}
+
+func TestRecordReplayMarkets(t *testing.T) {
+	s, err := gammatest.NewServer(os.DirFS("../gamma/testdata"))
+	assert.NoError(t, err)
+	path := t.TempDir() + "/markets.jsonl"
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+
+	closed := false
+	params := &gammatypes.GetMarketsParams{Closed: &closed, CLOBTokenIDs: []string{"2222"}}
+	client := gamma.NewClient(s.URL, chainId)
+	assert.NoError(t, client.WithTransport(http2.NewRecorder(f, nil)))
+	recorded, err := client.GetMarkets(context.Background(), params)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	s.Close()
+
+	replayer, err := http2.LoadCassette(path)
+	assert.NoError(t, err)
+	client = gamma.NewClient(s.URL, chainId)
+	assert.NoError(t, client.WithTransport(replayer))
+	replayed, err := client.GetMarkets(context.Background(), params)
+	assert.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+	assert.Len(t, replayed, 1)
+}
@@ -27,6 +27,11 @@ func NewClient(host string) *Client {
 	}
 }
 
+// SetTransport routes requests through rt, e.g. a Recorder or Replayer.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.client.SetTransport(rt)
+}
+
 type RequestOptions struct {
 	Headers map[string]string
 	Data    any
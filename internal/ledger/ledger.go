@@ -0,0 +1,317 @@
+// Package ledger is the simulated account shared by paper trading and
+// backtests: orders, fills, USDC and token balances, and the read and cancel
+// side of clob.OrderClient over them. Matching is left to its users.
+package ledger
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	StatusLive     = "LIVE"
+	StatusMatched  = "MATCHED"
+	StatusCanceled = "CANCELED"
+)
+
+// Order is a simulated order. Sizes are in shares.
+type Order struct {
+	ID         string
+	Status     string
+	Owner      string
+	Maker      string
+	TokenID    string
+	Market     string
+	TickSize   types.TickSize
+	Side       types.Side
+	Price      decimal.Decimal
+	Size       decimal.Decimal
+	Matched    decimal.Decimal
+	OrderType  types.OrderType
+	Expiration int64
+	FeeRateBps decimal.Decimal
+	CreatedAt  time.Time
+	Trades     []string
+}
+
+// SetAuth takes the maker and owner of o from option, like clob.Client signs
+// and posts an order.
+func (o *Order) SetAuth(option *sdktypes.AuthOption) {
+	if option == nil {
+		return
+	}
+	o.Maker = option.SingerAddress
+	if option.FunderAddress != "" {
+		o.Maker = option.FunderAddress
+	}
+	if option.ApiKeyCreds != nil {
+		o.Owner = option.ApiKeyCreds.ApiKey
+	}
+}
+
+func (o *Order) Remaining() decimal.Decimal {
+	return o.Size.Sub(o.Matched)
+}
+
+func (o *Order) OpenOrder() types.OpenOrder {
+	return types.OpenOrder{
+		AssociateTrades: append([]string{}, o.Trades...),
+		ID:              o.ID,
+		Status:          o.Status,
+		Market:          o.Market,
+		OriginalSize:    o.Size.String(),
+		Owner:           o.Owner,
+		Price:           o.Price.String(),
+		Side:            string(o.Side),
+		SizeMatched:     o.Matched.String(),
+		AssetID:         o.TokenID,
+		Expiration:      strconv.FormatInt(o.Expiration, 10),
+		Type:            string(o.OrderType),
+		CreatedAt:       uint64(o.CreatedAt.Unix()),
+	}
+}
+
+// Expiration returns the expiration of userOrder, checked like the exchange
+// checks it for orderType.
+func Expiration(userOrder types.UserOrder, orderType types.OrderType, now time.Time) (int64, error) {
+	var expiration int64
+	if userOrder.Expiration != nil {
+		expiration = *userOrder.Expiration
+	}
+	switch orderType {
+	case types.OrderTypeGTD:
+		if expiration <= now.Unix() {
+			return 0, fmt.Errorf("invalid expiration value (%d), expiration must be in the future", expiration)
+		}
+	case types.OrderTypeGTC, types.OrderTypeFOK, types.OrderTypeFAK:
+		if expiration != 0 {
+			return 0, fmt.Errorf("invalid expiration value (%d), only GTD orders expire", expiration)
+		}
+	default:
+		return 0, fmt.Errorf("invalid order type %q", orderType)
+	}
+	return expiration, nil
+}
+
+// Crosses reports whether price is at or through limit for side.
+func Crosses(side types.Side, limit, price decimal.Decimal) bool {
+	if side == types.BUY {
+		return price.LessThanOrEqual(limit)
+	}
+	return price.GreaterThanOrEqual(limit)
+}
+
+// Fee is the taker fee in USDC of size shares at price: the fee rate applied
+// to the cheaper of the two outcomes, as the exchange charges it.
+func Fee(feeRateBps, price, size decimal.Decimal) decimal.Decimal {
+	one := decimal.NewFromInt(1)
+	return feeRateBps.Div(decimal.NewFromInt(10000)).Mul(decimal.Min(price, one.Sub(price))).Mul(size)
+}
+
+// Fill is a simulated trade and the fee it paid in USDC. Only taker fills
+// pay fees.
+type Fill struct {
+	types.Trade
+	Fee decimal.Decimal
+}
+
+// Ledger holds the orders, fills and balances of a simulated account. It is
+// not synchronized.
+type Ledger struct {
+	// prefix starts the order and trade IDs, e.g. "paper" for paper-order-1.
+	prefix string
+
+	Cash      decimal.Decimal
+	Positions map[string]decimal.Decimal
+	Orders    []*Order
+	Fills     []Fill
+}
+
+func New(prefix string, cash decimal.Decimal) *Ledger {
+	return &Ledger{prefix: prefix, Cash: cash, Positions: make(map[string]decimal.Decimal)}
+}
+
+// Add assigns o the next order ID and records it.
+func (l *Ledger) Add(o *Order) {
+	o.ID = fmt.Sprintf("%s-order-%d", l.prefix, len(l.Orders)+1)
+	l.Orders = append(l.Orders, o)
+}
+
+func (l *Ledger) Find(id string) *Order {
+	for _, o := range l.Orders {
+		if o.ID == id {
+			return o
+		}
+	}
+	return nil
+}
+
+// Live returns the live orders of tokenID, oldest first, canceling the GTD
+// orders expired at now.
+func (l *Ledger) Live(tokenID string, now time.Time) []*Order {
+	var out []*Order
+	for _, o := range l.Orders {
+		if o.Status != StatusLive || o.TokenID != tokenID {
+			continue
+		}
+		if o.Expiration != 0 && o.Expiration <= now.Unix() {
+			o.Status = StatusCanceled
+			continue
+		}
+		out = append(out, o)
+	}
+	return out
+}
+
+// Settle books a fill of shares of o for cash USDC at now, marks o matched
+// once filled and records the trade. It returns the fee paid.
+func (l *Ledger) Settle(o *Order, price, shares, cash decimal.Decimal, taker bool, now time.Time) decimal.Decimal {
+	o.Matched = o.Matched.Add(shares)
+	if !o.Remaining().IsPositive() {
+		o.Status = StatusMatched
+	}
+	paid := decimal.Zero
+	if taker {
+		paid = Fee(o.FeeRateBps, price, shares)
+	}
+	if o.Side == types.BUY {
+		l.Cash = l.Cash.Sub(cash).Sub(paid)
+		l.Positions[o.TokenID] = l.Positions[o.TokenID].Add(shares)
+	} else {
+		l.Cash = l.Cash.Add(cash).Sub(paid)
+		l.Positions[o.TokenID] = l.Positions[o.TokenID].Sub(shares)
+	}
+
+	matchTime := strconv.FormatInt(now.Unix(), 10)
+	t := types.Trade{
+		ID:           fmt.Sprintf("%s-trade-%d", l.prefix, len(l.Fills)+1),
+		Market:       o.Market,
+		AssetID:      o.TokenID,
+		Side:         string(o.Side),
+		Size:         shares.String(),
+		FeeRateBps:   o.FeeRateBps.String(),
+		Price:        price.String(),
+		Status:       StatusMatched,
+		MatchTime:    matchTime,
+		LastUpdate:   matchTime,
+		Owner:        o.Owner,
+		MakerAddress: o.Maker,
+		MakerOrders:  []map[string]any{},
+	}
+	if taker {
+		t.TakerOrderID = o.ID
+		t.TraderSide = "TAKER"
+	} else {
+		t.TraderSide = "MAKER"
+		t.MakerOrders = append(t.MakerOrders, map[string]any{
+			"order_id":       o.ID,
+			"owner":          o.Owner,
+			"maker_address":  o.Maker,
+			"matched_amount": shares.String(),
+			"price":          price.String(),
+			"asset_id":       o.TokenID,
+			"side":           string(o.Side),
+		})
+	}
+	o.Trades = append(o.Trades, t.ID)
+	l.Fills = append(l.Fills, Fill{Trade: t, Fee: paid})
+	return paid
+}
+
+func (l *Ledger) GetOrders(req types.GetActiveOrdersRequest) *types.OpenOrders {
+	out := &types.OpenOrders{Data: []types.OpenOrder{}, NextCursor: types.END_CURSOR}
+	for _, o := range l.Orders {
+		if o.Status != StatusLive ||
+			(req.ID != "" && req.ID != o.ID) ||
+			(req.Market != "" && req.Market != o.Market) ||
+			(req.AssetID != "" && req.AssetID != o.TokenID) {
+			continue
+		}
+		out.Data = append(out.Data, o.OpenOrder())
+	}
+	out.Count, out.Limit = len(out.Data), len(out.Data)
+	return out
+}
+
+func (l *Ledger) GetTrades(req types.GetTradesRequest) *types.Trades {
+	out := &types.Trades{Data: []types.Trade{}, NextCursor: types.END_CURSOR}
+	for _, f := range l.Fills {
+		t := f.Trade
+		matchTime, _ := strconv.ParseInt(t.MatchTime, 10, 64)
+		if req.ID != nil && *req.ID != "" && *req.ID != t.ID {
+			continue
+		}
+		if req.MakerAddress != "" && req.MakerAddress != t.MakerAddress {
+			continue
+		}
+		if req.Market != nil && *req.Market != "" && *req.Market != t.Market {
+			continue
+		}
+		if req.AssetID != nil && *req.AssetID != "" && *req.AssetID != t.AssetID {
+			continue
+		}
+		if v, err := strconv.ParseInt(deref(req.Before), 10, 64); err == nil && matchTime >= v {
+			continue
+		}
+		if v, err := strconv.ParseInt(deref(req.After), 10, 64); err == nil && matchTime <= v {
+			continue
+		}
+		out.Data = append(out.Data, t)
+	}
+	out.Count, out.Limit = len(out.Data), len(out.Data)
+	return out
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// LiveIDs returns the IDs of the live orders, oldest first.
+func (l *Ledger) LiveIDs() []string {
+	var ids []string
+	for _, o := range l.Orders {
+		if o.Status == StatusLive {
+			ids = append(ids, o.ID)
+		}
+	}
+	return ids
+}
+
+func (l *Ledger) Cancel(ids []string) *types.CancelOrder {
+	out := &types.CancelOrder{Canceled: []string{}, NotCanceled: map[string]string{}}
+	for _, id := range ids {
+		o := l.Find(id)
+		switch {
+		case o == nil:
+			out.NotCanceled[id] = "order not found"
+		case o.Status != StatusLive:
+			out.NotCanceled[id] = "order can't be found - already canceled or matched"
+		default:
+			o.Status = StatusCanceled
+			out.Canceled = append(out.Canceled, id)
+		}
+	}
+	return out
+}
+
+// BalanceAllowance returns the USDC balance in base units. Simulated
+// accounts have no allowances, so they are all unlimited.
+func (l *Ledger) BalanceAllowance() *types.BalanceAllowanceResponse {
+	resp := &types.BalanceAllowanceResponse{
+		Balance: l.Cash.Shift(int32(types.CollateralTokenDecimals)).Truncate(0).String(),
+	}
+	unlimited := math.MaxBig256.String()
+	resp.Allowances.CTFExchange = unlimited
+	resp.Allowances.NegRiskCtfExchange = unlimited
+	resp.Allowances.NegRiskAdapter = unlimited
+	return resp
+}
@@ -0,0 +1,43 @@
+package ledger_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/internal/ledger"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedger(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := ledger.New("sim", decimal.NewFromInt(100))
+	buy := &ledger.Order{Status: ledger.StatusLive, TokenID: "1", Side: types.BUY, Price: decimal.RequireFromString("0.4"), Size: decimal.NewFromInt(10), FeeRateBps: decimal.NewFromInt(100)}
+	sell := &ledger.Order{Status: ledger.StatusLive, TokenID: "1", Side: types.SELL, Price: decimal.RequireFromString("0.6"), Size: decimal.NewFromInt(5), Expiration: now.Unix()}
+	l.Add(buy)
+	l.Add(sell)
+	assert.Equal(t, "sim-order-1", buy.ID)
+	assert.Equal(t, []string{"sim-order-1", "sim-order-2"}, l.LiveIDs())
+
+	// Taker fills pay the fee on the cheaper outcome: 1% of 0.4 per share.
+	paid := l.Settle(buy, buy.Price, decimal.NewFromInt(10), decimal.NewFromInt(4), true, now)
+	assert.Equal(t, "0.04", paid.String())
+	assert.Equal(t, "95.96", l.Cash.String())
+	assert.Equal(t, "10", l.Positions["1"].String())
+	assert.Equal(t, ledger.StatusMatched, buy.Status)
+	trades := l.GetTrades(types.GetTradesRequest{})
+	if assert.Len(t, trades.Data, 1) {
+		assert.Equal(t, "sim-trade-1", trades.Data[0].ID)
+		assert.Equal(t, "TAKER", trades.Data[0].TraderSide)
+		assert.Equal(t, buy.ID, trades.Data[0].TakerOrderID)
+	}
+
+	// The sell expired at now.
+	assert.Empty(t, l.Live("1", now))
+	assert.Empty(t, l.GetOrders(types.GetActiveOrdersRequest{}).Data)
+	resp := l.Cancel([]string{sell.ID, "missing"})
+	assert.Empty(t, resp.Canceled)
+	assert.Len(t, resp.NotCanceled, 2)
+	assert.Equal(t, "95960000", l.BalanceAllowance().Balance)
+}
@@ -0,0 +1,112 @@
+package paper
+
+import (
+	"sort"
+
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/internal/ledger"
+	"github.com/shopspring/decimal"
+)
+
+// queue is the place of a resting order in the queue at its price: ahead is
+// the size that must trade at price before the order fills, level the size
+// displayed at price in the last book.
+type queue struct {
+	ahead decimal.Decimal
+	level decimal.Decimal
+}
+
+// level is one price level of a live book.
+type level struct {
+	price decimal.Decimal
+	size  decimal.Decimal
+}
+
+// book is the last live book of a token. Simulated fills do not change the
+// live book, so the size they take is tracked in taken until the next one.
+type book struct {
+	summary *types.OrderBookSummary
+	bids    []level // best first
+	asks    []level // best first
+	taken   map[string]decimal.Decimal
+}
+
+func newBook(summary *types.OrderBookSummary) *book {
+	b := &book{summary: summary, bids: levels(summary.Bids), asks: levels(summary.Asks), taken: map[string]decimal.Decimal{}}
+	sort.Slice(b.bids, func(i, j int) bool { return b.bids[i].price.GreaterThan(b.bids[j].price) })
+	sort.Slice(b.asks, func(i, j int) bool { return b.asks[i].price.LessThan(b.asks[j].price) })
+	return b
+}
+
+func levels(summaries []types.OrderSummary) []level {
+	out := make([]level, 0, len(summaries))
+	for _, s := range summaries {
+		price, err := decimal.NewFromString(s.Price)
+		if err != nil {
+			continue
+		}
+		size, err := decimal.NewFromString(s.Size)
+		if err != nil || !size.IsPositive() {
+			continue
+		}
+		out = append(out, level{price: price, size: size})
+	}
+	return out
+}
+
+// opposite returns the levels side can trade against, best first.
+func (b *book) opposite(side types.Side) []level {
+	if side == types.BUY {
+		return b.asks
+	}
+	return b.bids
+}
+
+// displayed returns the size shown at price on side.
+func (b *book) displayed(side types.Side, price decimal.Decimal) decimal.Decimal {
+	own := b.bids
+	if side != types.BUY {
+		own = b.asks
+	}
+	for _, l := range own {
+		if l.price.Equal(price) {
+			return l.size
+		}
+	}
+	return decimal.Zero
+}
+
+// available returns the size of l not taken by earlier simulated fills.
+func (b *book) available(l level) decimal.Decimal {
+	return decimal.Max(decimal.Zero, l.size.Sub(b.taken[l.price.String()]))
+}
+
+func (b *book) take(l level, size decimal.Decimal) {
+	b.taken[l.price.String()] = b.taken[l.price.String()].Add(size)
+}
+
+// fill is a simulated match of an order against one level of the book.
+type fill struct {
+	level level
+	price decimal.Decimal
+	size  decimal.Decimal
+}
+
+// match returns the fills of size at limit against the book without applying
+// them. Fills are at the level price.
+func (b *book) match(side types.Side, limit, size decimal.Decimal) []fill {
+	var fills []fill
+	left := size
+	for _, l := range b.opposite(side) {
+		if left.IsZero() || !ledger.Crosses(side, limit, l.price) {
+			break
+		}
+		n := decimal.Min(left, b.available(l))
+		if n.IsZero() {
+			continue
+		}
+		fills = append(fills, fill{level: l, price: l.price, size: n})
+		left = left.Sub(n)
+	}
+	return fills
+}
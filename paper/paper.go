@@ -0,0 +1,426 @@
+// Package paper simulates trading on the CLOB. Orders are matched against
+// live order books instead of being posted, and settle against a virtual
+// USDC and token ledger, so strategies written against clob.OrderClient can
+// run without risking funds.
+package paper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/clob"
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/internal/ledger"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/override-coder/go-polymarket-sdk/types/utils"
+	"github.com/shopspring/decimal"
+)
+
+// MarketData is the part of clob.Client paper trading reads live data from.
+type MarketData interface {
+	GetOrderBook(tokenID string) (*types.OrderBookSummary, error)
+	GetTickSize(ctx context.Context, tokenID string) (string, error)
+	GetFeeRateBps(ctx context.Context, tokenID string) (float64, error)
+}
+
+type Options struct {
+	// Balance is the initial USDC balance.
+	Balance decimal.Decimal
+	// Latency delays every order before it is matched against a fresh book,
+	// like the round trip to the exchange.
+	Latency time.Duration
+	// QueueAhead is the fraction of the size displayed at its price that must
+	// trade before a resting order fills. nil means 1, the back of the queue.
+	QueueAhead *float64
+	// FeeRateBps overrides the fee rate of every market.
+	FeeRateBps *float64
+	// Now returns the current time, time.Now if nil.
+	Now func() time.Time
+}
+
+// Fill is a simulated trade and the fee it paid in USDC. Only taker fills
+// pay fees.
+type Fill = ledger.Fill
+
+// Client is a paper trading clob.OrderClient.
+//
+// Taker orders fill at the level prices of the book fetched after Latency.
+// Resting orders fill at their own price when a later book crosses them, or
+// once the size displayed at their price has dropped by more than the size
+// queued ahead of them. Books come from MarketData on CreateOrder and Sync,
+// or from a market data feed through UpdateBook.
+type Client struct {
+	market MarketData
+	opts   Options
+
+	mu     sync.Mutex
+	ledger *ledger.Ledger
+	books  map[string]*book
+	queues map[string]*queue // by order ID
+}
+
+var _ clob.OrderClient = (*Client)(nil)
+
+func NewClient(market MarketData, opts Options) *Client {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Client{
+		market: market,
+		opts:   opts,
+		ledger: ledger.New("paper", opts.Balance),
+		books:  make(map[string]*book),
+		queues: make(map[string]*queue),
+	}
+}
+
+// Balance returns the USDC balance, including the part reserved by open
+// buy orders.
+func (c *Client) Balance() decimal.Decimal {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ledger.Cash
+}
+
+// Deposit adds amount to the USDC balance.
+func (c *Client) Deposit(amount decimal.Decimal) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ledger.Cash = c.ledger.Cash.Add(amount)
+}
+
+// Position returns the shares held of tokenID.
+func (c *Client) Position(tokenID string) decimal.Decimal {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ledger.Positions[tokenID]
+}
+
+// SetPosition sets the shares held of tokenID.
+func (c *Client) SetPosition(tokenID string, size decimal.Decimal) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ledger.Positions[tokenID] = size
+}
+
+// Fills returns every simulated trade, oldest first.
+func (c *Client) Fills() []Fill {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Fill{}, c.ledger.Fills...)
+}
+
+// UpdateBook matches the resting orders of the book's token against it. Use
+// it to feed books from a WebSocket subscription.
+func (c *Client) UpdateBook(summary *types.OrderBookSummary) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.update(summary.AssetID, summary)
+}
+
+// Sync fetches the books of all tokens with resting orders and matches the
+// orders against them.
+func (c *Client) Sync(ctx context.Context) error {
+	c.mu.Lock()
+	var tokenIDs []string
+	seen := map[string]bool{}
+	for _, o := range c.ledger.Orders {
+		if o.Status == ledger.StatusLive && !seen[o.TokenID] {
+			seen[o.TokenID] = true
+			tokenIDs = append(tokenIDs, o.TokenID)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, tokenID := range tokenIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		summary, err := c.market.GetOrderBook(tokenID)
+		if err != nil {
+			return fmt.Errorf("paper sync %s: %w", tokenID, err)
+		}
+		c.mu.Lock()
+		c.update(tokenID, summary)
+		c.mu.Unlock()
+	}
+	return nil
+}
+
+// CreateOrder simulates userOrder like clob.Client.CreateOrder: FOK and FAK
+// buys are sized in USDC, other orders in shares.
+func (c *Client) CreateOrder(ctx context.Context, userOrder types.UserOrder, orderType types.OrderType, deferExec bool, option *sdktypes.AuthOption) (*types.OrderResponse, error) {
+	o, err := c.newOrder(ctx, userOrder, orderType, option)
+	if err != nil {
+		return nil, err
+	}
+	if c.opts.Latency > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.opts.Latency):
+		}
+	}
+	summary, err := c.market.GetOrderBook(o.TokenID)
+	if err != nil {
+		return nil, fmt.Errorf("paper create order get order book: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.update(o.TokenID, summary)
+	return c.place(o)
+}
+
+// newOrder validates userOrder like clob.Client does before signing it.
+func (c *Client) newOrder(ctx context.Context, userOrder types.UserOrder, orderType types.OrderType, option *sdktypes.AuthOption) (*ledger.Order, error) {
+	if userOrder.TokenID == "" {
+		return nil, fmt.Errorf("token id is empty")
+	}
+	if userOrder.Side != types.BUY && userOrder.Side != types.SELL {
+		return nil, fmt.Errorf("invalid side %q", userOrder.Side)
+	}
+	if userOrder.Size <= 0 {
+		return nil, fmt.Errorf("invalid size %v", userOrder.Size)
+	}
+
+	tickSize := ""
+	if userOrder.TickSize != nil {
+		tickSize = *userOrder.TickSize
+	} else {
+		tick, err := c.market.GetTickSize(ctx, userOrder.TokenID)
+		if err != nil {
+			return nil, fmt.Errorf("paper create order get tickSize: %w", err)
+		}
+		tickSize = tick
+	}
+	tick, err := decimal.NewFromString(tickSize)
+	if err != nil || !tick.IsPositive() {
+		return nil, fmt.Errorf("invalid tick size %q", tickSize)
+	}
+
+	feeRateBps := float64(0)
+	switch {
+	case c.opts.FeeRateBps != nil:
+		feeRateBps = *c.opts.FeeRateBps
+	case userOrder.FeeRateBps != nil:
+		feeRateBps = *userOrder.FeeRateBps
+	default:
+		bps, err := c.market.GetFeeRateBps(ctx, userOrder.TokenID)
+		if err != nil {
+			return nil, fmt.Errorf("paper create order get feeRateBps: %w", err)
+		}
+		feeRateBps = bps
+	}
+
+	userOrder.Price = utils.NormalizePrice(userOrder.Price, tick.InexactFloat64())
+	price := decimal.NewFromFloat(userOrder.Price)
+	if !price.Mod(tick).IsZero() {
+		return nil, fmt.Errorf("invalid price (%s), breaks minimum tick size rule: %s", price, tick)
+	}
+	// Size the order in shares from the amounts clob.Client would sign, so
+	// market buys are sized in USDC as they are live.
+	makerAmt, takerAmt, err := clob.RawAmounts(userOrder, orderType, types.TickSize(tick.String()))
+	if err != nil {
+		return nil, err
+	}
+	size := decimal.NewFromFloat(makerAmt)
+	if userOrder.Side == types.BUY {
+		size = decimal.NewFromFloat(takerAmt)
+	}
+
+	now := c.opts.Now()
+	expiration, err := ledger.Expiration(userOrder, orderType, now)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &ledger.Order{
+		Status:     ledger.StatusLive,
+		TokenID:    userOrder.TokenID,
+		TickSize:   types.TickSize(tick.String()),
+		Side:       userOrder.Side,
+		Price:      price,
+		Size:       size,
+		OrderType:  orderType,
+		Expiration: expiration,
+		FeeRateBps: decimal.NewFromFloat(feeRateBps),
+		CreatedAt:  now,
+	}
+	o.SetAuth(option)
+	return o, nil
+}
+
+// reserved returns the USDC and shares of tokenID locked by resting orders.
+func (c *Client) reserved(tokenID string) (decimal.Decimal, decimal.Decimal) {
+	usdc, shares := decimal.Zero, decimal.Zero
+	for _, o := range c.ledger.Orders {
+		if o.Status != ledger.StatusLive {
+			continue
+		}
+		if o.Side == types.BUY {
+			usdc = usdc.Add(o.Remaining().Mul(o.Price))
+		} else if o.TokenID == tokenID {
+			shares = shares.Add(o.Remaining())
+		}
+	}
+	return usdc, shares
+}
+
+// place matches o against the current book of its token, settles the fills
+// and rests what is left of GTC and GTD orders.
+func (c *Client) place(o *ledger.Order) (*types.OrderResponse, error) {
+	b := c.books[o.TokenID]
+	o.Market = b.summary.Market
+	if minSize, err := decimal.NewFromString(b.summary.MinOrderSize); err == nil && o.Size.LessThan(minSize) {
+		return nil, fmt.Errorf("Size (%s) lower than the minimum: %s", o.Size, minSize)
+	}
+
+	fills := b.match(o.Side, o.Price, o.Size)
+	filled, notional, fees := decimal.Zero, decimal.Zero, decimal.Zero
+	for _, f := range fills {
+		filled = filled.Add(f.size)
+		notional = notional.Add(f.size.Mul(f.price))
+		fees = fees.Add(ledger.Fee(o.FeeRateBps, f.price, f.size))
+	}
+	rest := o.Size.Sub(filled)
+	switch o.OrderType {
+	case types.OrderTypeFOK:
+		if !rest.IsZero() {
+			return nil, fmt.Errorf("order couldn't be fully filled. FOK orders are fully filled or killed.")
+		}
+	case types.OrderTypeFAK:
+		if filled.IsZero() {
+			return nil, fmt.Errorf("no orders found to match with FAK order. FAK orders are partially filled or killed if no match is found.")
+		}
+		rest = decimal.Zero
+	}
+
+	usdc, tokens := c.ledger.Cash, c.ledger.Positions[o.TokenID]
+	reservedUSDC, reservedShares := c.reserved(o.TokenID)
+	if o.Side == types.BUY {
+		if cost := notional.Add(fees).Add(rest.Mul(o.Price)); cost.GreaterThan(usdc.Sub(reservedUSDC)) {
+			return nil, fmt.Errorf("not enough balance / allowance: need %s USDC, available %s", cost, usdc.Sub(reservedUSDC))
+		}
+	} else if need := filled.Add(rest); need.GreaterThan(tokens.Sub(reservedShares)) {
+		return nil, fmt.Errorf("not enough balance / allowance: need %s shares, available %s", need, tokens.Sub(reservedShares))
+	}
+
+	c.ledger.Add(o)
+	now := c.opts.Now()
+	for _, f := range fills {
+		b.take(f.level, f.size)
+		c.ledger.Settle(o, f.price, f.size, f.price.Mul(f.size), true, now)
+	}
+
+	status := "live"
+	switch {
+	case o.Remaining().IsZero():
+		status = "matched"
+	case o.OrderType == types.OrderTypeFAK:
+		o.Status = ledger.StatusCanceled
+		status = "matched"
+	default:
+		shown := b.displayed(o.Side, o.Price)
+		c.queues[o.ID] = &queue{level: shown, ahead: shown.Mul(decimal.NewFromFloat(c.queueAhead()))}
+		if !filled.IsZero() {
+			status = "matched"
+		}
+	}
+
+	resp := &types.OrderResponse{Success: true, OrderID: o.ID, Status: status, TransactionsHashes: []string{}}
+	if o.Side == types.BUY {
+		resp.MakingAmount, resp.TakingAmount = notional.String(), filled.String()
+	} else {
+		resp.MakingAmount, resp.TakingAmount = filled.String(), notional.String()
+	}
+	return resp, nil
+}
+
+func (c *Client) queueAhead() float64 {
+	if c.opts.QueueAhead == nil {
+		return 1
+	}
+	return *c.opts.QueueAhead
+}
+
+// update stores summary as the book of tokenID and fills the resting orders
+// it reaches, oldest first. A book with the hash of the previous one keeps
+// its taken sizes, since the live book has not moved since.
+func (c *Client) update(tokenID string, summary *types.OrderBookSummary) {
+	b := newBook(summary)
+	if prev := c.books[tokenID]; prev != nil && summary.Hash != "" && summary.Hash == prev.summary.Hash {
+		b.taken = prev.taken
+	}
+	c.books[tokenID] = b
+	now := c.opts.Now()
+	for _, o := range c.ledger.Live(tokenID, now) {
+		q := c.queues[o.ID]
+		// The book crossed the order: it fills as maker at its own price.
+		for _, f := range b.match(o.Side, o.Price, o.Remaining()) {
+			b.take(f.level, f.size)
+			c.ledger.Settle(o, o.Price, f.size, o.Price.Mul(f.size), false, now)
+		}
+		// Size gone from the order's level traded through the queue.
+		shown := b.displayed(o.Side, o.Price)
+		if traded := q.level.Sub(shown); traded.IsPositive() && o.Remaining().IsPositive() {
+			if traded.GreaterThan(q.ahead) {
+				size := decimal.Min(traded.Sub(q.ahead), o.Remaining())
+				c.ledger.Settle(o, o.Price, size, o.Price.Mul(size), false, now)
+				q.ahead = decimal.Zero
+			} else {
+				q.ahead = q.ahead.Sub(traded)
+			}
+		}
+		q.level = shown
+	}
+}
+
+func (c *Client) GetOrders(ctx context.Context, req types.GetActiveOrdersRequest, option *sdktypes.AuthOption) (*types.OpenOrders, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ledger.GetOrders(req), nil
+}
+
+func (c *Client) GetTrades(ctx context.Context, req types.GetTradesRequest, option *sdktypes.AuthOption) (*types.Trades, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ledger.GetTrades(req), nil
+}
+
+// GetBalanceAllowance returns the USDC balance in base units. Paper trading
+// has no allowances, so they are all unlimited.
+func (c *Client) GetBalanceAllowance(option *sdktypes.AuthOption) (*types.BalanceAllowanceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ledger.BalanceAllowance(), nil
+}
+
+func (c *Client) CancelOrder(ctx context.Context, orderId string, option *sdktypes.AuthOption) (*types.CancelOrder, error) {
+	if orderId == "" {
+		return nil, fmt.Errorf("order id is empty")
+	}
+	return c.cancel([]string{orderId}), nil
+}
+
+func (c *Client) CancelOrders(ctx context.Context, orderId []string, option *sdktypes.AuthOption) (*types.CancelOrder, error) {
+	if len(orderId) == 0 {
+		return nil, fmt.Errorf("orderId is empty")
+	}
+	return c.cancel(orderId), nil
+}
+
+func (c *Client) CancelOrderAll(ctx context.Context, option *sdktypes.AuthOption) (*types.CancelOrder, error) {
+	c.mu.Lock()
+	ids := c.ledger.LiveIDs()
+	c.mu.Unlock()
+	return c.cancel(ids), nil
+}
+
+func (c *Client) cancel(ids []string) *types.CancelOrder {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ledger.Cancel(ids)
+}
@@ -0,0 +1,163 @@
+package paper_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/paper"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenID = "1234"
+
+type fakeMarket struct {
+	book *types.OrderBookSummary
+}
+
+func (f *fakeMarket) GetOrderBook(string) (*types.OrderBookSummary, error) {
+	return f.book, nil
+}
+
+func (f *fakeMarket) GetTickSize(context.Context, string) (string, error) {
+	return "0.01", nil
+}
+
+func (f *fakeMarket) GetFeeRateBps(context.Context, string) (float64, error) {
+	return 100, nil
+}
+
+func book(hash string, bids, asks []types.OrderSummary) *types.OrderBookSummary {
+	return &types.OrderBookSummary{Market: "0xc0", AssetID: tokenID, Bids: bids, Asks: asks, MinOrderSize: "5", Hash: hash}
+}
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func order(side types.Side, price, size float64) types.UserOrder {
+	return types.UserOrder{TokenID: tokenID, Side: side, Price: price, Size: size}
+}
+
+func TestTakerOrders(t *testing.T) {
+	ctx := context.Background()
+	market := &fakeMarket{book: book("h1",
+		[]types.OrderSummary{{Price: "0.48", Size: "20"}},
+		[]types.OrderSummary{{Price: "0.55", Size: "10"}, {Price: "0.52", Size: "10"}},
+	)}
+	c := paper.NewClient(market, paper.Options{Balance: dec("100")})
+
+	resp, err := c.CreateOrder(ctx, order(types.BUY, 0.53, 15), types.OrderTypeFAK, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "matched", resp.Status)
+	assert.Equal(t, "5.2", resp.MakingAmount)
+	assert.Equal(t, "10", resp.TakingAmount)
+	assert.Equal(t, "94.752", c.Balance().String())
+	assert.Equal(t, "10", c.Position(tokenID).String())
+
+	// The live book has not moved, so the 0.52 asks are already taken.
+	_, err = c.CreateOrder(ctx, order(types.BUY, 0.52, 5), types.OrderTypeFOK, false, nil)
+	assert.ErrorContains(t, err, "fully filled or killed")
+	market.book = book("h2", market.book.Bids, market.book.Asks)
+	resp, err = c.CreateOrder(ctx, order(types.BUY, 0.52, 2.6), types.OrderTypeFOK, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "5", resp.TakingAmount, "market buys are sized in USDC")
+
+	resp, err = c.CreateOrder(ctx, order(types.SELL, 0.48, 5), types.OrderTypeGTC, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.4", resp.TakingAmount)
+	assert.Equal(t, "10", c.Position(tokenID).String())
+
+	_, err = c.CreateOrder(ctx, order(types.SELL, 0.48, 50), types.OrderTypeGTC, false, nil)
+	assert.ErrorContains(t, err, "not enough balance")
+	_, err = c.CreateOrder(ctx, order(types.BUY, 0.3, 1000), types.OrderTypeGTC, false, nil)
+	assert.ErrorContains(t, err, "not enough balance")
+	_, err = c.CreateOrder(ctx, order(types.BUY, 0.3, 1), types.OrderTypeGTC, false, nil)
+	assert.ErrorContains(t, err, "lower than the minimum")
+
+	fills := c.Fills()
+	if assert.Len(t, fills, 3) {
+		assert.Equal(t, "TAKER", fills[0].TraderSide)
+		assert.Equal(t, "0.048", fills[0].Fee.String())
+		assert.Equal(t, "0.024", fills[2].Fee.String())
+	}
+	trades, err := c.GetTrades(ctx, types.GetTradesRequest{}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, trades.Data, 3)
+}
+
+func TestRestingOrders(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	market := &fakeMarket{book: book("h1",
+		[]types.OrderSummary{{Price: "0.4", Size: "100"}},
+		[]types.OrderSummary{{Price: "0.45", Size: "50"}},
+	)}
+	c := paper.NewClient(market, paper.Options{Balance: dec("100"), Now: func() time.Time { return now }})
+
+	resp, err := c.CreateOrder(ctx, order(types.BUY, 0.4, 20), types.OrderTypeGTC, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "live", resp.Status)
+	_, err = c.CreateOrder(ctx, order(types.BUY, 0.4, 250), types.OrderTypeGTC, false, nil)
+	assert.ErrorContains(t, err, "not enough balance", "8 USDC are reserved")
+
+	// The 100 shares ahead trade first.
+	asks := []types.OrderSummary{{Price: "0.45", Size: "50"}}
+	c.UpdateBook(book("h2", []types.OrderSummary{{Price: "0.4", Size: "40"}}, asks))
+	c.UpdateBook(book("h3", []types.OrderSummary{{Price: "0.39", Size: "10"}}, asks))
+	assert.Empty(t, c.Fills())
+
+	// An ask through the bid fills it as maker, without fees.
+	c.UpdateBook(book("h4", []types.OrderSummary{{Price: "0.39", Size: "10"}}, []types.OrderSummary{{Price: "0.4", Size: "5"}}))
+	orders, err := c.GetOrders(ctx, types.GetActiveOrdersRequest{}, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, orders.Data, 1) {
+		assert.Equal(t, "5", orders.Data[0].SizeMatched)
+	}
+	fills := c.Fills()
+	if assert.Len(t, fills, 1) {
+		assert.Equal(t, "MAKER", fills[0].TraderSide)
+		assert.True(t, fills[0].Fee.IsZero())
+	}
+
+	// Now at the front of the queue, it fills as soon as the level trades.
+	c.UpdateBook(book("h5", []types.OrderSummary{{Price: "0.4", Size: "30"}}, asks))
+	c.UpdateBook(book("h6", []types.OrderSummary{{Price: "0.4", Size: "10"}}, asks))
+	orders, err = c.GetOrders(ctx, types.GetActiveOrdersRequest{}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, orders.Data)
+	assert.Equal(t, "20", c.Position(tokenID).String())
+	balance, err := c.GetBalanceAllowance(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "92000000", balance.Balance)
+
+	expiration := now.Unix() + 60
+	gtd := order(types.BUY, 0.3, 10)
+	gtd.Expiration = &expiration
+	resp, err = c.CreateOrder(ctx, gtd, types.OrderTypeGTD, false, nil)
+	assert.NoError(t, err)
+	now = now.Add(time.Minute)
+	assert.NoError(t, c.Sync(ctx))
+	canceled, err := c.CancelOrder(ctx, resp.OrderID, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, canceled.NotCanceled, resp.OrderID)
+
+	resp, err = c.CreateOrder(ctx, order(types.BUY, 0.3, 10), types.OrderTypeGTC, false, nil)
+	assert.NoError(t, err)
+	canceled, err = c.CancelOrders(ctx, []string{resp.OrderID, "missing"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{resp.OrderID}, canceled.Canceled)
+	assert.Equal(t, "order not found", canceled.NotCanceled["missing"])
+}
+
+func TestLatency(t *testing.T) {
+	market := &fakeMarket{book: book("h1", nil, []types.OrderSummary{{Price: "0.5", Size: "10"}})}
+	c := paper.NewClient(market, paper.Options{Balance: dec("100"), Latency: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.CreateOrder(ctx, order(types.BUY, 0.5, 10), types.OrderTypeFOK, false, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, c.Fills())
+}
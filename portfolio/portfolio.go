@@ -0,0 +1,441 @@
+// Package portfolio aggregates a user's positions, open orders, collateral
+// balance and PnL from the data API and the CLOB into one Snapshot.
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	clobtypes "github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/dataapi/types"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/shopspring/decimal"
+)
+
+// DataAPI is the part of dataapi.Client used by Snapshot.
+type DataAPI interface {
+	GetPositions(ctx context.Context, q types.PositionsQuery) ([]types.Position, error)
+	GetClosedPositions(ctx context.Context, q types.ClosedPositionsQuery) ([]types.ClosedPosition, error)
+	GetPositionValue(ctx context.Context, q types.PositionValueQuery) ([]types.PositionValue, error)
+}
+
+// ClobAPI is the part of clob.Client used by Snapshot.
+type ClobAPI interface {
+	GetOrders(ctx context.Context, req clobtypes.GetActiveOrdersRequest, option *sdktypes.AuthOption) (*clobtypes.OpenOrders, error)
+	GetBalanceAllowance(option *sdktypes.AuthOption) (*clobtypes.BalanceAllowanceResponse, error)
+	GetMidpoints(ctx context.Context, tokenIDs []string) (map[string]string, error)
+}
+
+// Mark sources of Position.MarkSource.
+const (
+	MarkMidpoint = "midpoint"
+	MarkCurPrice = "curPrice"
+)
+
+type Position struct {
+	TokenID      string
+	ConditionID  string
+	EventSlug    string
+	Title        string
+	Outcome      string
+	OutcomeIndex int64
+	NegativeRisk bool
+
+	Size      decimal.Decimal
+	AvgPrice  decimal.Decimal
+	CostBasis decimal.Decimal // Size * AvgPrice
+	// MarkPrice is the CLOB midpoint, or the data API curPrice when the token
+	// has no order book (e.g. a resolved market).
+	MarkPrice     decimal.Decimal
+	MarkSource    string
+	MarketValue   decimal.Decimal // Size * MarkPrice
+	UnrealizedPnl decimal.Decimal // MarketValue - CostBasis
+	RealizedPnl   decimal.Decimal
+	// LockedShares are held by resting SELL orders.
+	LockedShares decimal.Decimal
+}
+
+type Order struct {
+	ID          string
+	TokenID     string
+	ConditionID string
+	Side        string
+	Price       decimal.Decimal
+	Remaining   decimal.Decimal // original size - size matched
+	// Locked is the collateral of a BUY (Price * Remaining) or the shares of a SELL.
+	Locked decimal.Decimal
+	Raw    clobtypes.OpenOrder
+}
+
+// Exposure is the position and resting orders held in one outcome token.
+type Exposure struct {
+	TokenID     string
+	ConditionID string
+	Outcome     string
+	Shares      decimal.Decimal
+	MarketValue decimal.Decimal
+	// PendingBuyShares and PendingBuyCollateral would be added by resting BUYs.
+	PendingBuyShares     decimal.Decimal
+	PendingBuyCollateral decimal.Decimal
+	// PendingSellShares would leave by resting SELLs.
+	PendingSellShares decimal.Decimal
+}
+
+// EventExposure groups exposures by event. Orders in markets without a
+// position have no known event and are grouped by condition ID instead.
+type EventExposure struct {
+	EventSlug            string
+	MarketValue          decimal.Decimal
+	PendingBuyCollateral decimal.Decimal
+	Outcomes             []Exposure
+}
+
+type Snapshot struct {
+	User string
+	At   time.Time
+
+	// Collateral is the CLOB USDC balance; CollateralLocked is reserved by
+	// resting BUY orders and CollateralAvailable is the rest.
+	Collateral          decimal.Decimal
+	CollateralLocked    decimal.Decimal
+	CollateralAvailable decimal.Decimal
+
+	Positions []Position
+	Orders    []Order
+	Events    []EventExposure
+
+	// MarketValue sums the positions marked to market, PositionValue is the
+	// data API's own valuation.
+	MarketValue   decimal.Decimal
+	PositionValue decimal.Decimal
+	// RealizedPnl includes closed positions, UnrealizedPnl open ones only.
+	RealizedPnl   decimal.Decimal
+	UnrealizedPnl decimal.Decimal
+	// Equity is Collateral + MarketValue.
+	Equity decimal.Decimal
+
+	// Truncated is set when positions, closed positions or orders had more
+	// pages than Snapshot fetches; the totals then miss the rest. Warnings
+	// names the lists.
+	Truncated bool
+	// Warnings lists non-fatal problems, e.g. marks that fell back to curPrice.
+	Warnings []string
+}
+
+type Client struct {
+	data DataAPI
+	clob ClobAPI
+	now  func() time.Time
+}
+
+func NewClient(data DataAPI, clob ClobAPI) *Client {
+	return &Client{data: data, clob: clob, now: time.Now}
+}
+
+const (
+	positionsPageSize       = 500
+	closedPositionsPageSize = 50
+	maxPages                = 20
+)
+
+// Snapshot fetches positions, closed positions, open orders, the collateral
+// balance and the position value concurrently, then marks positions to market
+// with CLOB midpoints. user is the address holding the positions (the proxy
+// wallet for Safe and proxy accounts); option authenticates the CLOB calls.
+func (c *Client) Snapshot(ctx context.Context, user string, option *sdktypes.AuthOption) (*Snapshot, error) {
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		errs      []error
+		truncated []string
+		positions []types.Position
+		closed    []types.ClosedPosition
+		orders    []clobtypes.OpenOrder
+		balance   *clobtypes.BalanceAllowanceResponse
+		values    []types.PositionValue
+	)
+	fetch := func(name string, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	truncate := func(name string, more bool) {
+		if more {
+			mu.Lock()
+			truncated = append(truncated, name)
+			mu.Unlock()
+		}
+	}
+
+	fetch("get positions", func() (err error) {
+		var more bool
+		positions, more, err = c.fetchPositions(ctx, user)
+		truncate("positions", more)
+		return err
+	})
+	fetch("get closed positions", func() (err error) {
+		var more bool
+		closed, more, err = c.fetchClosedPositions(ctx, user)
+		truncate("closed positions", more)
+		return err
+	})
+	fetch("get orders", func() (err error) {
+		var more bool
+		orders, more, err = c.fetchOrders(ctx, option)
+		truncate("orders", more)
+		return err
+	})
+	fetch("get balance allowance", func() (err error) {
+		balance, err = c.clob.GetBalanceAllowance(option)
+		return err
+	})
+	fetch("get position value", func() (err error) {
+		values, err = c.data.GetPositionValue(ctx, types.PositionValueQuery{User: user})
+		return err
+	})
+	wg.Wait()
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("portfolio snapshot: %w", errs[0])
+	}
+
+	s := &Snapshot{User: user, At: c.now(), Truncated: len(truncated) > 0}
+	sort.Strings(truncated)
+	for _, name := range truncated {
+		s.Warnings = append(s.Warnings, fmt.Sprintf("%s truncated after %d pages", name, maxPages))
+	}
+	if balance != nil && balance.Balance != "" {
+		raw, err := decimal.NewFromString(balance.Balance)
+		if err != nil {
+			return nil, fmt.Errorf("portfolio snapshot: parse balance %q: %w", balance.Balance, err)
+		}
+		s.Collateral = raw.Shift(-int32(clobtypes.CollateralTokenDecimals))
+	}
+	for _, v := range values {
+		s.PositionValue = s.PositionValue.Add(decimal.NewFromFloat(v.Value))
+	}
+
+	marks := c.midpoints(ctx, positions, s)
+	for _, p := range positions {
+		s.Positions = append(s.Positions, position(p, marks))
+	}
+	for _, o := range orders {
+		order, err := openOrder(o)
+		if err != nil {
+			return nil, fmt.Errorf("portfolio snapshot: %w", err)
+		}
+		s.Orders = append(s.Orders, order)
+	}
+
+	s.aggregate(closed)
+	return s, nil
+}
+
+// The fetchers stop after maxPages and then report whether more pages remain.
+
+func (c *Client) fetchPositions(ctx context.Context, user string) ([]types.Position, bool, error) {
+	var out []types.Position
+	limit, zero := positionsPageSize, 0.0
+	for page := 0; page < maxPages; page++ {
+		offset := page * limit
+		batch, err := c.data.GetPositions(ctx, types.PositionsQuery{User: user, Limit: &limit, Offset: &offset, SizeThreshold: &zero})
+		if err != nil {
+			return nil, false, err
+		}
+		out = append(out, batch...)
+		if len(batch) < limit {
+			return out, false, nil
+		}
+	}
+	return out, true, nil
+}
+
+func (c *Client) fetchClosedPositions(ctx context.Context, user string) ([]types.ClosedPosition, bool, error) {
+	var out []types.ClosedPosition
+	limit := closedPositionsPageSize
+	for page := 0; page < maxPages; page++ {
+		offset := page * limit
+		batch, err := c.data.GetClosedPositions(ctx, types.ClosedPositionsQuery{User: user, Limit: &limit, Offset: &offset})
+		if err != nil {
+			return nil, false, err
+		}
+		out = append(out, batch...)
+		if len(batch) < limit {
+			return out, false, nil
+		}
+	}
+	return out, true, nil
+}
+
+func (c *Client) fetchOrders(ctx context.Context, option *sdktypes.AuthOption) ([]clobtypes.OpenOrder, bool, error) {
+	var out []clobtypes.OpenOrder
+	req := clobtypes.GetActiveOrdersRequest{}
+	for page := 0; page < maxPages; page++ {
+		resp, err := c.clob.GetOrders(ctx, req, option)
+		if err != nil {
+			return nil, false, err
+		}
+		out = append(out, resp.Data...)
+		if resp.NextCursor == "" || resp.NextCursor == clobtypes.END_CURSOR || resp.NextCursor == req.NextCursor {
+			return out, false, nil
+		}
+		req.NextCursor = resp.NextCursor
+	}
+	return out, true, nil
+}
+
+// midpoints returns the CLOB midpoint of every position token it could get.
+func (c *Client) midpoints(ctx context.Context, positions []types.Position, s *Snapshot) map[string]decimal.Decimal {
+	marks := make(map[string]decimal.Decimal, len(positions))
+	if len(positions) == 0 {
+		return marks
+	}
+	tokenIDs := make([]string, 0, len(positions))
+	for _, p := range positions {
+		tokenIDs = append(tokenIDs, p.Asset)
+	}
+	mids, err := c.clob.GetMidpoints(ctx, tokenIDs)
+	if err != nil {
+		s.Warnings = append(s.Warnings, fmt.Sprintf("get midpoints: %v; marking at curPrice", err))
+		return marks
+	}
+	for _, id := range tokenIDs {
+		mid, ok := mids[id]
+		if !ok {
+			s.Warnings = append(s.Warnings, fmt.Sprintf("no midpoint for token %s; marking at curPrice", id))
+			continue
+		}
+		price, err := decimal.NewFromString(mid)
+		if err != nil {
+			s.Warnings = append(s.Warnings, fmt.Sprintf("invalid midpoint %q for token %s; marking at curPrice", mid, id))
+			continue
+		}
+		marks[id] = price
+	}
+	return marks
+}
+
+func position(p types.Position, marks map[string]decimal.Decimal) Position {
+	out := Position{
+		TokenID:      p.Asset,
+		ConditionID:  p.ConditionID,
+		EventSlug:    p.EventSlug,
+		Title:        p.Title,
+		Outcome:      p.Outcome,
+		OutcomeIndex: p.OutcomeIndex,
+		NegativeRisk: p.NegativeRisk,
+		Size:         decimal.NewFromFloat(p.Size),
+		AvgPrice:     decimal.NewFromFloat(p.AvgPrice),
+		RealizedPnl:  decimal.NewFromFloat(p.RealizedPnl),
+		MarkPrice:    decimal.NewFromFloat(p.CurPrice),
+		MarkSource:   MarkCurPrice,
+	}
+	if mark, ok := marks[p.Asset]; ok {
+		out.MarkPrice, out.MarkSource = mark, MarkMidpoint
+	}
+	out.CostBasis = out.Size.Mul(out.AvgPrice)
+	out.MarketValue = out.Size.Mul(out.MarkPrice)
+	out.UnrealizedPnl = out.MarketValue.Sub(out.CostBasis)
+	return out
+}
+
+func openOrder(o clobtypes.OpenOrder) (Order, error) {
+	price, err := decimal.NewFromString(o.Price)
+	if err != nil {
+		return Order{}, fmt.Errorf("order %s: invalid price %q", o.ID, o.Price)
+	}
+	size, err := decimal.NewFromString(o.OriginalSize)
+	if err != nil {
+		return Order{}, fmt.Errorf("order %s: invalid original_size %q", o.ID, o.OriginalSize)
+	}
+	matched := decimal.Zero
+	if o.SizeMatched != "" {
+		if matched, err = decimal.NewFromString(o.SizeMatched); err != nil {
+			return Order{}, fmt.Errorf("order %s: invalid size_matched %q", o.ID, o.SizeMatched)
+		}
+	}
+	remaining := decimal.Max(size.Sub(matched), decimal.Zero)
+	out := Order{ID: o.ID, TokenID: o.AssetID, ConditionID: o.Market, Side: o.Side, Price: price, Remaining: remaining, Raw: o}
+	if o.Side == string(clobtypes.BUY) {
+		out.Locked = price.Mul(remaining)
+	} else {
+		out.Locked = remaining
+	}
+	return out, nil
+}
+
+func (s *Snapshot) aggregate(closed []types.ClosedPosition) {
+	exposures := map[string]*Exposure{}
+	eventOf := map[string]string{} // condition ID -> event slug
+	exposure := func(tokenID, conditionID string) *Exposure {
+		e, ok := exposures[tokenID]
+		if !ok {
+			e = &Exposure{TokenID: tokenID, ConditionID: conditionID}
+			exposures[tokenID] = e
+		}
+		return e
+	}
+
+	for i := range s.Positions {
+		p := &s.Positions[i]
+		e := exposure(p.TokenID, p.ConditionID)
+		e.Outcome = p.Outcome
+		e.Shares = e.Shares.Add(p.Size)
+		e.MarketValue = e.MarketValue.Add(p.MarketValue)
+		eventOf[p.ConditionID] = p.EventSlug
+
+		s.MarketValue = s.MarketValue.Add(p.MarketValue)
+		s.UnrealizedPnl = s.UnrealizedPnl.Add(p.UnrealizedPnl)
+		s.RealizedPnl = s.RealizedPnl.Add(p.RealizedPnl)
+	}
+	for _, cp := range closed {
+		s.RealizedPnl = s.RealizedPnl.Add(decimal.NewFromFloat(cp.RealizedPnl))
+	}
+
+	locked := map[string]decimal.Decimal{}
+	for _, o := range s.Orders {
+		e := exposure(o.TokenID, o.ConditionID)
+		if o.Side == string(clobtypes.BUY) {
+			e.PendingBuyShares = e.PendingBuyShares.Add(o.Remaining)
+			e.PendingBuyCollateral = e.PendingBuyCollateral.Add(o.Locked)
+			s.CollateralLocked = s.CollateralLocked.Add(o.Locked)
+		} else {
+			e.PendingSellShares = e.PendingSellShares.Add(o.Remaining)
+			locked[o.TokenID] = locked[o.TokenID].Add(o.Remaining)
+		}
+	}
+	for i := range s.Positions {
+		s.Positions[i].LockedShares = locked[s.Positions[i].TokenID]
+	}
+	s.CollateralAvailable = s.Collateral.Sub(s.CollateralLocked)
+	s.Equity = s.Collateral.Add(s.MarketValue)
+
+	events := map[string]*EventExposure{}
+	for _, e := range exposures {
+		slug, ok := eventOf[e.ConditionID]
+		if !ok {
+			slug = e.ConditionID
+		}
+		ev, ok := events[slug]
+		if !ok {
+			ev = &EventExposure{EventSlug: slug}
+			events[slug] = ev
+		}
+		ev.MarketValue = ev.MarketValue.Add(e.MarketValue)
+		ev.PendingBuyCollateral = ev.PendingBuyCollateral.Add(e.PendingBuyCollateral)
+		ev.Outcomes = append(ev.Outcomes, *e)
+	}
+	for _, ev := range events {
+		sort.Slice(ev.Outcomes, func(i, j int) bool { return ev.Outcomes[i].TokenID < ev.Outcomes[j].TokenID })
+		s.Events = append(s.Events, *ev)
+	}
+	sort.Slice(s.Events, func(i, j int) bool { return s.Events[i].EventSlug < s.Events[j].EventSlug })
+}
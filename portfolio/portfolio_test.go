@@ -0,0 +1,147 @@
+package portfolio_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	clobtypes "github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/dataapi/types"
+	"github.com/override-coder/go-polymarket-sdk/portfolio"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeData struct {
+	positions []types.Position
+	closed    []types.ClosedPosition
+}
+
+func (f *fakeData) GetPositions(_ context.Context, q types.PositionsQuery) ([]types.Position, error) {
+	if *q.Offset > 0 {
+		return nil, nil
+	}
+	return f.positions, nil
+}
+
+func (f *fakeData) GetClosedPositions(_ context.Context, q types.ClosedPositionsQuery) ([]types.ClosedPosition, error) {
+	if *q.Offset > 0 {
+		return nil, nil
+	}
+	return f.closed, nil
+}
+
+func (f *fakeData) GetPositionValue(_ context.Context, q types.PositionValueQuery) ([]types.PositionValue, error) {
+	return []types.PositionValue{{User: q.User, Value: 61}}, nil
+}
+
+type fakeClob struct {
+	pages     []clobtypes.OpenOrders
+	mids      map[string]string
+	midsErr   error
+	requested []string
+}
+
+func (f *fakeClob) GetOrders(_ context.Context, req clobtypes.GetActiveOrdersRequest, _ *sdktypes.AuthOption) (*clobtypes.OpenOrders, error) {
+	f.requested = append(f.requested, req.NextCursor)
+	page := f.pages[len(f.requested)-1]
+	return &page, nil
+}
+
+func (f *fakeClob) GetBalanceAllowance(_ *sdktypes.AuthOption) (*clobtypes.BalanceAllowanceResponse, error) {
+	return &clobtypes.BalanceAllowanceResponse{Balance: "250500000"}, nil
+}
+
+func (f *fakeClob) GetMidpoints(_ context.Context, _ []string) (map[string]string, error) {
+	return f.mids, f.midsErr
+}
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestSnapshot(t *testing.T) {
+	data := &fakeData{
+		positions: []types.Position{
+			{Asset: "yes-a", ConditionID: "0xa", EventSlug: "election", Outcome: "Yes", Size: 100, AvgPrice: 0.4, CurPrice: 0.5, RealizedPnl: 2},
+			{Asset: "no-b", ConditionID: "0xb", EventSlug: "election", Outcome: "No", Size: 20, AvgPrice: 0.3, CurPrice: 0.25},
+		},
+		closed: []types.ClosedPosition{{RealizedPnl: 10.5}},
+	}
+	clob := &fakeClob{
+		pages: []clobtypes.OpenOrders{
+			{Data: []clobtypes.OpenOrder{{ID: "1", AssetID: "yes-a", Market: "0xa", Side: "BUY", Price: "0.45", OriginalSize: "50", SizeMatched: "10"}}, NextCursor: "MTAw"},
+			{Data: []clobtypes.OpenOrder{
+				{ID: "2", AssetID: "yes-a", Market: "0xa", Side: "SELL", Price: "0.7", OriginalSize: "30", SizeMatched: "0"},
+				{ID: "3", AssetID: "yes-c", Market: "0xc", Side: "BUY", Price: "0.1", OriginalSize: "100", SizeMatched: ""},
+			}, NextCursor: clobtypes.END_CURSOR},
+		},
+		mids: map[string]string{"yes-a": "0.55"},
+	}
+
+	s, err := portfolio.NewClient(data, clob).Snapshot(context.Background(), "0xuser", &sdktypes.AuthOption{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "MTAw"}, clob.requested)
+
+	assert.True(t, dec("250.5").Equal(s.Collateral))
+	// 0.45 * 40 + 0.1 * 100
+	assert.True(t, dec("28").Equal(s.CollateralLocked), s.CollateralLocked.String())
+	assert.True(t, dec("222.5").Equal(s.CollateralAvailable))
+
+	if assert.Len(t, s.Positions, 2) {
+		a, b := s.Positions[0], s.Positions[1]
+		assert.Equal(t, portfolio.MarkMidpoint, a.MarkSource)
+		assert.True(t, dec("55").Equal(a.MarketValue))
+		assert.True(t, dec("15").Equal(a.UnrealizedPnl))
+		assert.True(t, dec("30").Equal(a.LockedShares))
+		assert.Equal(t, portfolio.MarkCurPrice, b.MarkSource)
+		assert.True(t, dec("-1").Equal(b.UnrealizedPnl))
+	}
+	assert.Len(t, s.Warnings, 1)
+
+	assert.True(t, dec("60").Equal(s.MarketValue))
+	assert.True(t, dec("61").Equal(s.PositionValue))
+	assert.True(t, dec("14").Equal(s.UnrealizedPnl))
+	assert.True(t, dec("12.5").Equal(s.RealizedPnl))
+	assert.True(t, dec("310.5").Equal(s.Equity))
+
+	if assert.Len(t, s.Events, 2) {
+		assert.Equal(t, "0xc", s.Events[0].EventSlug)
+		election := s.Events[1]
+		assert.Equal(t, "election", election.EventSlug)
+		assert.True(t, dec("18").Equal(election.PendingBuyCollateral))
+		if assert.Len(t, election.Outcomes, 2) {
+			assert.True(t, dec("40").Equal(election.Outcomes[1].PendingBuyShares))
+			assert.True(t, dec("30").Equal(election.Outcomes[1].PendingSellShares))
+		}
+	}
+}
+
+func TestSnapshotMidpointFallback(t *testing.T) {
+	data := &fakeData{positions: []types.Position{{Asset: "yes-a", Size: 10, AvgPrice: 0.5, CurPrice: 1}}}
+	clob := &fakeClob{pages: []clobtypes.OpenOrders{{}}, midsErr: errors.New("no orderbook exists")}
+
+	s, err := portfolio.NewClient(data, clob).Snapshot(context.Background(), "0xuser", &sdktypes.AuthOption{})
+	assert.NoError(t, err)
+	assert.Equal(t, portfolio.MarkCurPrice, s.Positions[0].MarkSource)
+	assert.True(t, dec("5").Equal(s.UnrealizedPnl))
+	assert.Len(t, s.Warnings, 1)
+}
+
+func TestSnapshotTruncated(t *testing.T) {
+	clob := &fakeClob{}
+	for i := 0; i < 20; i++ {
+		clob.pages = append(clob.pages, clobtypes.OpenOrders{
+			Data:       []clobtypes.OpenOrder{{ID: fmt.Sprint(i), AssetID: "yes-a", Side: "BUY", Price: "0.5", OriginalSize: "1"}},
+			NextCursor: fmt.Sprintf("page-%d", i+1),
+		})
+	}
+
+	s, err := portfolio.NewClient(&fakeData{}, clob).Snapshot(context.Background(), "0xuser", &sdktypes.AuthOption{})
+	assert.NoError(t, err)
+	assert.True(t, s.Truncated)
+	assert.Len(t, s.Orders, 20)
+	assert.Equal(t, []string{"orders truncated after 20 pages"}, s.Warnings)
+}
@@ -14,6 +14,7 @@ import (
 	"math/big"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -25,6 +26,21 @@ type Client struct {
 	builderApiKeyCreds *sdktypes.BuilderApiKeyCreds
 
 	contractConfig *types.ContractConfig
+
+	nonceManager *NonceManager
+
+	pendingMu   sync.Mutex
+	pending     map[*types.TransactionRequest]pendingTx // built by BuildTx, holding the signer lock
+	buildTxHold time.Duration
+}
+
+// defaultBuildTxHold is how long BuildTx holds the signer lock by default.
+const defaultBuildTxHold = time.Minute
+
+type pendingTx struct {
+	from   string
+	unlock func()
+	expiry *time.Timer // discards the request once the hold is over
 }
 
 func NewClient(host string, chainId *big.Int, signFn signing.SignatureFunc, builderApiKeyCreds *sdktypes.BuilderApiKeyCreds) *Client {
@@ -37,6 +53,7 @@ func NewClient(host string, chainId *big.Int, signFn signing.SignatureFunc, buil
 		builderApiKeyCreds: builderApiKeyCreds,
 		signFn:             signFn,
 		contractConfig:     types.GetContractConfig(chainId),
+		buildTxHold:        defaultBuildTxHold,
 	}
 }
 
@@ -53,6 +70,68 @@ func (c *Client) WithBuilderApiKeyCreds(newBuilder *sdktypes.BuilderApiKeyCreds)
 	return nil
 }
 
+// WithTransport routes the client's requests through rt, e.g. an
+// http.Recorder or http.Replayer.
+func (c *Client) WithTransport(rt http.RoundTripper) error {
+	c.client.SetTransport(rt)
+	return nil
+}
+
+// WithNonceManager makes Execute and BuildTx reserve nonces locally instead of
+// fetching /nonce on every call.
+func (c *Client) WithNonceManager(nm *NonceManager) error {
+	if c.nonceManager != nil {
+		return errors.New("nonceManager already set")
+	}
+	c.nonceManager = nm
+	return nil
+}
+
+// WithBuildTxHold sets how long a request built by BuildTx holds the signer
+// lock, default 1 minute. A request not submitted or discarded by then is
+// discarded, so a caller that never submits does not block the signer.
+func (c *Client) WithBuildTxHold(d time.Duration) error {
+	if d <= 0 {
+		return errors.New("buildTxHold must be positive")
+	}
+	c.buildTxHold = d
+	return nil
+}
+
+// NonceManager returns the configured nonce manager, or nil.
+func (c *Client) NonceManager() *NonceManager {
+	return c.nonceManager
+}
+
+func (c *Client) nextNonce(from string) (string, error) {
+	if c.nonceManager != nil {
+		nonce, err := c.nonceManager.Reserve(from)
+		if err != nil {
+			return "", err
+		}
+		return nonce.String(), nil
+	}
+	noncePayload, err := c.GetNonce(from, types.TransactionTypeSAFE)
+	if err != nil {
+		return "", err
+	}
+	return noncePayload.Nonce, nil
+}
+
+// observeSubmit resyncs the nonce manager when a submission did not consume the nonce.
+func (c *Client) observeSubmit(from string, out *types.RelayerTransactionResponse, err error) {
+	if c.nonceManager == nil {
+		return
+	}
+	if err != nil {
+		c.nonceManager.Resync(from)
+		return
+	}
+	if out != nil {
+		c.nonceManager.Observe(from, types.RelayerTransactionState(out.State))
+	}
+}
+
 func (c *Client) GetNonce(signerAddress string, signerType types.TransactionType) (types.NoncePayload, error) {
 	var resp types.NoncePayload
 	res, err := c.client.DoRequest(context.Background(), http.MethodGet, types.GET_NONCE, &http2.RequestOptions{
@@ -182,7 +261,6 @@ func (c *Client) deploy(option *sdktypes.AuthOption) (*types.RelayerTransactionR
 }
 
 func (c *Client) Execute(txns []types.SafeTransaction, metadata string, option *sdktypes.AuthOption) (*types.RelayerTransactionResponse, error) {
-	start := time.Now()
 	from := option.SingerAddress
 	safeAddr, err := c.GetExpectedSafe(from)
 	if err != nil {
@@ -197,27 +275,45 @@ func (c *Client) Execute(txns []types.SafeTransaction, metadata string, option *
 		return nil, fmt.Errorf("execute: safe not deployed")
 	}
 
-	noncePayload, err := c.GetNonce(from, types.TransactionTypeSAFE)
-	if err != nil {
-		return nil, fmt.Errorf("execute: GetNonce failed: %w", err)
+	if c.nonceManager != nil {
+		unlock := c.nonceManager.Lock(from)
+		defer unlock()
 	}
 
-	args := types.SafeTransactionArgs{
-		From:         from,
-		Nonce:        noncePayload.Nonce,
-		ChainID:      c.chainId.Int64(),
-		Transactions: txns,
-	}
+	for attempt := 0; ; attempt++ {
+		nonce, err := c.nextNonce(from)
+		if err != nil {
+			return nil, fmt.Errorf("execute: GetNonce failed: %w", err)
+		}
 
-	reqBody, err := buildSafeTransactionRequest(c.signFn, args, c.contractConfig, metadata)
-	if err != nil {
-		return nil, fmt.Errorf("execute: build safe transaction r	equest failed: %w", err)
-	}
+		args := types.SafeTransactionArgs{
+			From:         from,
+			Nonce:        nonce,
+			ChainID:      c.chainId.Int64(),
+			Transactions: txns,
+		}
 
-	fmt.Printf("Client side safe request creation took: %.3f seconds\n", time.Since(start).Seconds())
+		reqBody, err := buildSafeTransactionRequest(c.signFn, args, c.contractConfig, metadata)
+		if err != nil {
+			c.observeSubmit(from, nil, err)
+			return nil, fmt.Errorf("execute: build safe transaction r	equest failed: %w", err)
+		}
+
+		out, err := c.submit(reqBody, from, option)
+		if err != nil && attempt == 0 && isNonceMismatch(err) {
+			// The local nonce is stale, e.g. another submitter used it: the
+			// failed submit resynced it, retry once with the relayer's.
+			continue
+		}
+		return out, err
+	}
+}
 
+// submit posts a signed SAFE request and updates the nonce manager.
+func (c *Client) submit(reqBody *types.TransactionRequest, from string, option *sdktypes.AuthOption) (*types.RelayerTransactionResponse, error) {
 	payloadBytes, err := json.Marshal(reqBody)
 	if err != nil {
+		c.observeSubmit(from, nil, err)
 		return nil, fmt.Errorf("execute: marshal request failed: %w", err)
 	}
 
@@ -229,6 +325,7 @@ func (c *Client) Execute(txns []types.SafeTransaction, metadata string, option *
 	}
 	headers, err := sdkheaders.CreateL2BuilderHeaders(builderApiKeyCreds, clobtypes.L2HeaderArgs{Method: http.MethodPost, RequestPath: types.SUBMIT_TRANSACTION, Body: body}, nil)
 	if err != nil {
+		c.observeSubmit(from, nil, err)
 		return nil, fmt.Errorf("deploy internal: create header failed: %w", err)
 	}
 
@@ -238,11 +335,22 @@ func (c *Client) Execute(txns []types.SafeTransaction, metadata string, option *
 		Data:    body,
 	}, &out)
 	if _, e := http2.ParseHTTPError(resp, err); e != nil {
+		c.observeSubmit(from, nil, e)
 		return nil, e
 	}
+	c.observeSubmit(from, &out, nil)
 	return &out, nil
 }
 
+// isNonceMismatch reports whether the relayer rejected a submission because
+// it was signed for another nonce than the Safe's: a 400 reading "invalid
+// nonce".
+func isNonceMismatch(err error) bool {
+	var upstream *http2.UpstreamServiceError
+	return errors.As(err, &upstream) && upstream.StatusCode == http.StatusBadRequest &&
+		strings.HasPrefix(strings.ToLower(upstream.Message), "invalid nonce")
+}
+
 func (c *Client) GetDeployed(safeAddr string) (types.GetDeployedResponse, error) {
 	var resp types.GetDeployedResponse
 	res, err := c.client.DoRequest(context.Background(), http.MethodGet, types.GET_DEPLOYED, &http2.RequestOptions{
@@ -295,7 +403,7 @@ func (c *Client) PollUntilState(
 				return &txn, nil
 			}
 			if failState != nil && txn.State == *failState {
-				return nil, fmt.Errorf("transaction %s failed on-chain (hash=%s)", transactionID, txn.TransactionHash)
+				return nil, types.NewTxFailedError(&txn)
 			}
 		}
 
@@ -345,6 +453,12 @@ func (c *Client) BuildDeployTx(option *sdktypes.AuthOption) (*types.TransactionR
 	return reqBody, nil
 }
 
+// BuildTx signs txns as a SAFE request without submitting it. When the next
+// nonce is reserved from the nonce manager, the signer stays locked, as in
+// Execute, until the request is submitted with ExecuteByTx or dropped with
+// Discard, so concurrent callers submit in nonce order. The lock is held for
+// at most the build tx hold, see WithBuildTxHold; the request is discarded
+// after it.
 func (c *Client) BuildTx(txns []types.SafeTransaction, nonceAt *big.Int, metadata string, option *sdktypes.AuthOption) (*types.TransactionRequest, error) {
 	from := option.SingerAddress
 	safeAddr, err := c.GetExpectedSafe(from)
@@ -360,13 +474,23 @@ func (c *Client) BuildTx(txns []types.SafeTransaction, nonceAt *big.Int, metadat
 		return nil, fmt.Errorf("execute: safe not deployed")
 	}
 
-	nonce := nonceAt.String()
-	if nonceAt.Cmp(big.NewInt(0)) == 0 {
-		noncePayload, err := c.GetNonce(from, types.TransactionTypeSAFE)
+	// nil or zero nonceAt means "next nonce": reserved from the nonce manager
+	// when one is configured, otherwise fetched from the relayer.
+	var nonce string
+	var unlock func()
+	if nonceAt == nil || nonceAt.Sign() == 0 {
+		if c.nonceManager != nil {
+			unlock = c.nonceManager.Lock(from)
+		}
+		nonce, err = c.nextNonce(from)
 		if err != nil {
+			if unlock != nil {
+				unlock()
+			}
 			return nil, fmt.Errorf("execute: GetNonce failed: %w", err)
 		}
-		nonce = noncePayload.Nonce
+	} else {
+		nonce = nonceAt.String()
 	}
 
 	args := types.SafeTransactionArgs{
@@ -378,53 +502,71 @@ func (c *Client) BuildTx(txns []types.SafeTransaction, nonceAt *big.Int, metadat
 
 	reqBody, err := buildSafeTransactionRequest(c.signFn, args, c.contractConfig, metadata)
 	if err != nil {
+		c.observeSubmit(from, nil, err)
+		if unlock != nil {
+			unlock()
+		}
 		return nil, fmt.Errorf("execute: build safe transaction r	equest failed: %w", err)
 	}
 
+	if unlock != nil {
+		c.pendingMu.Lock()
+		if c.pending == nil {
+			c.pending = make(map[*types.TransactionRequest]pendingTx)
+		}
+		c.pending[reqBody] = pendingTx{from: from, unlock: unlock, expiry: time.AfterFunc(c.buildTxHold, func() { c.Discard(reqBody) })}
+		c.pendingMu.Unlock()
+	}
 	return reqBody, nil
 }
 
+// claim takes reqBody out of the requests built by BuildTx and stops its
+// hold. It returns nil if reqBody holds no signer lock.
+func (c *Client) claim(reqBody *types.TransactionRequest) *pendingTx {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	p, ok := c.pending[reqBody]
+	if !ok {
+		return nil
+	}
+	delete(c.pending, reqBody)
+	p.expiry.Stop()
+	return &p
+}
+
+// Discard gives up a request built by BuildTx without submitting it. It
+// releases the signer lock and the reserved nonce.
+func (c *Client) Discard(reqBody *types.TransactionRequest) {
+	if p := c.claim(reqBody); p != nil {
+		c.nonceManager.Resync(p.from)
+		p.unlock()
+	}
+}
+
 func (c *Client) ExecuteByTx(reqBody *types.TransactionRequest, option *sdktypes.AuthOption) (*types.RelayerTransactionResponse, error) {
 	start := time.Now()
 	from := option.SingerAddress
+	if p := c.claim(reqBody); p != nil {
+		defer p.unlock()
+	}
 	safeAddr, err := c.GetExpectedSafe(from)
 	if err != nil {
+		c.observeSubmit(from, nil, err)
 		return nil, fmt.Errorf("execute: GetExpectedSafe failed: %w", err)
 	}
 
 	deployed, err := c.GetDeployed(safeAddr)
 	if err != nil {
+		c.observeSubmit(from, nil, err)
 		return nil, fmt.Errorf("execute: GetDeployed failed: %w", err)
 	}
 	if !deployed.Deployed {
-		return nil, fmt.Errorf("execute: safe not deployed")
+		err := fmt.Errorf("execute: safe not deployed")
+		c.observeSubmit(from, nil, err)
+		return nil, err
 	}
 
 	fmt.Printf("Client side safe request creation took: %.3f seconds\n", time.Since(start).Seconds())
 
-	payloadBytes, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("execute: marshal request failed: %w", err)
-	}
-
-	body := string(payloadBytes)
-
-	builderApiKeyCreds := option.BuilderApiKeyCreds
-	if builderApiKeyCreds == nil {
-		builderApiKeyCreds = c.builderApiKeyCreds
-	}
-	headers, err := sdkheaders.CreateL2BuilderHeaders(builderApiKeyCreds, clobtypes.L2HeaderArgs{Method: http.MethodPost, RequestPath: types.SUBMIT_TRANSACTION, Body: body}, nil)
-	if err != nil {
-		return nil, fmt.Errorf("deploy internal: create header failed: %w", err)
-	}
-
-	var out types.RelayerTransactionResponse
-	resp, err := c.client.DoRequest(context.Background(), http.MethodPost, types.SUBMIT_TRANSACTION, &http2.RequestOptions{
-		Headers: headers,
-		Data:    body,
-	}, &out)
-	if _, e := http2.ParseHTTPError(resp, err); e != nil {
-		return nil, e
-	}
-	return &out, nil
+	return c.submit(reqBody, from, option)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/override-coder/go-polymarket-sdk/relayer"
+	"github.com/override-coder/go-polymarket-sdk/relayer/relayertest"
 	"github.com/override-coder/go-polymarket-sdk/relayer/types"
 	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
 	"github.com/polymarket/go-order-utils/pkg/model"
@@ -19,9 +20,8 @@ import (
 )
 
 var (
-	PolymarketRelayURL = "https://relayer-v2.polymarket.com"
-	chaindId           = big.NewInt(137)
-	privateKey, _      = crypto.ToECDSA(common.Hex2Bytes("3f26dbcf904a3542e5f54eed3381c740d6246d8bc81cefbfd0679ae4ce8d82c9"))
+	chaindId      = big.NewInt(137)
+	privateKey, _ = crypto.ToECDSA(common.Hex2Bytes("3f26dbcf904a3542e5f54eed3381c740d6246d8bc81cefbfd0679ae4ce8d82c9"))
 )
 
 func signature(signer string, digest []byte) ([]byte, error) {
@@ -33,58 +33,88 @@ func signature(signer string, digest []byte) ([]byte, error) {
 	return sig, nil
 }
 
+func newRelayer(t *testing.T, opts relayertest.Options) (*relayertest.Server, *relayer.Client) {
+	server := relayertest.NewServer(chaindId, opts)
+	t.Cleanup(server.Close)
+	server.AddBuilderKey(*testBuilderCreds)
+	return server, relayer.NewClient(server.URL, chaindId, signature, testBuilderCreds)
+}
+
 func TestClient_GetNonce(t *testing.T) {
-	client := relayer.NewClient(PolymarketRelayURL, chaindId, signature, &sdktypes.BuilderApiKeyCreds{
-		Key:        "019a4dec-fc6a-79ba-8937-d9bf3c2792ca",
-		Secret:     "Q23ZHyR21V5_F8qVLvOvnXGhxtW6CmNCWDjHzFJQW7k=",
-		Passphrase: "2a171196ddfe34aab62eea32ed63fe424fde8144413982dd90527c844cf2e8d3",
-	})
+	server, client := newRelayer(t, relayertest.Options{})
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	assert.NoError(t, server.SetNonce(owner, 4))
+
+	nonce, err := client.GetNonce(owner, types.TransactionTypeSAFE)
+	assert.NoError(t, err)
+	assert.Equal(t, "4", nonce.Nonce)
 
 	transactions, err := client.GetTransactions()
-	assert.Equal(t, nil, err)
-	t.Logf("transactions: %v", transactions)
+	assert.NoError(t, err)
+	assert.Empty(t, transactions)
 }
 
 func TestProxy(t *testing.T) {
-	client := relayer.NewClient(PolymarketRelayURL, chaindId, signature, &sdktypes.BuilderApiKeyCreds{
-		Key:        "019a4dec-fc6a-79ba-8937-d9bf3c2792ca",
-		Secret:     "Q23ZHyR21V5_F8qVLvOvnXGhxtW6CmNCWDjHzFJQW7k=",
-		Passphrase: "2a171196ddfe34aab62eea32ed63fe424fde8144413982dd90527c844cf2e8d3",
-	})
-	response, err := client.Deploy(&sdktypes.AuthOption{
+	server, client := newRelayer(t, relayertest.Options{})
+	option := &sdktypes.AuthOption{
 		SignatureType: model.POLY_GNOSIS_SAFE,
-		SingerAddress: "0x2061452270d3E93A37B72062d10e68Ec46d4A304",
-	})
+		SingerAddress: crypto.PubkeyToAddress(privateKey.PublicKey).Hex(),
+	}
+	response, err := client.Deploy(option)
+	assert.NoError(t, err)
+	assert.Equal(t, string(types.RelayerStateNew), response.State)
+
+	_, err = client.Deploy(option)
+	assert.ErrorContains(t, err, "already being deployed")
+
+	server.Advance(10 * time.Second)
+	state, err := client.PollUntilState(context.Background(), response.TransactionID, []types.RelayerTransactionState{types.RelayerStateConfirmed}, nil, 1, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, types.TransactionTypeSAFECreate, types.TransactionType(state.Type))
+
+	safe, err := client.GetExpectedSafe(option.SingerAddress)
+	assert.NoError(t, err)
+	deployed, err := client.GetDeployed(safe)
 	assert.NoError(t, err)
-	state, err := client.PollUntilState(context.Background(), response.TransactionID, []types.RelayerTransactionState{types.RelayerStateConfirmed}, nil, 10, 5*time.Second)
-	assert.Equal(t, nil, err)
-	t.Logf("state: %v", state)
+	assert.True(t, deployed.Deployed)
 }
 
 func TestGetExpectedSafe(t *testing.T) {
-	client := relayer.NewClient(PolymarketRelayURL, chaindId, signature, &sdktypes.BuilderApiKeyCreds{
-		Key:        "019a4dec-fc6a-79ba-8937-d9bf3c2792ca",
-		Secret:     "Q23ZHyR21V5_F8qVLvOvnXGhxtW6CmNCWDjHzFJQW7k=",
-		Passphrase: "2a171196ddfe34aab62eea32ed63fe424fde8144413982dd90527c844cf2e8d3",
+	failed := types.RelayerStateFailed
+	server, client := newRelayer(t, relayertest.Options{
+		Fail: func(req *types.TransactionRequest) bool { return *req.Metadata == "fail" },
 	})
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	option := &sdktypes.AuthOption{SingerAddress: owner}
 
 	txn, err := createUsdcApproveTxn("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174", "0x4d97dcd97ec945f40cf65f87097ace5ea0476045")
+	assert.NoError(t, err)
 	txn2, err := createUsdcApproveTxn("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174", "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E")
-	txn3, err := createUsdcApproveTxn("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174", "0xC5d563A36AE78145C45a50134d48A1215220f80a")
-	txn4, err := createUsdcApproveTxn("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174", "0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296")
-	//txn5, err := createSetApproveAll("0x4d97dcd97ec945f40cf65f87097ace5ea0476045", "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E")
-	//txn6, err := createSetApproveAll("0x4d97dcd97ec945f40cf65f87097ace5ea0476045", "0xC5d563A36AE78145C45a50134d48A1215220f80a")
-	//txn7, err := createSetApproveAll("0x4d97dcd97ec945f40cf65f87097ace5ea0476045", "0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296")
-	//var parent [32]byte
-	//indexSets := []*big.Int{big.NewInt(1), big.NewInt(2)}
-	//txn, err := createRedeemPositions("0x4d97dcd97ec945f40cf65f87097ace5ea0476045", common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"), parent, common.HexToHash("0xee1091d8be46ae92f59d743694ff29e4fbd9d9b15151064ab4a4af7062aed6de"), indexSets)
-
-	assert.Equal(t, nil, err)
-	execute, err := client.Execute([]types.SafeTransaction{txn, txn2, txn3, txn4}, "approve USDC on CTF", &sdktypes.AuthOption{
-		SingerAddress: "0x8c5f23249462e20C4a202Ad35275562075F37e09",
-	})
-	assert.Equal(t, nil, err)
-	t.Logf("execute: %s", execute)
+	assert.NoError(t, err)
+
+	_, err = client.Execute([]types.SafeTransaction{txn, txn2}, "approve USDC on CTF", option)
+	assert.ErrorContains(t, err, "safe not deployed")
+
+	assert.NoError(t, server.DeploySafe(owner))
+	execute, err := client.Execute([]types.SafeTransaction{txn, txn2}, "approve USDC on CTF", option)
+	assert.NoError(t, err)
+	failing, err := client.Execute([]types.SafeTransaction{txn}, "fail", option)
+	assert.NoError(t, err)
+
+	// a request signed for a used nonce is rejected
+	stale, err := client.BuildTx([]types.SafeTransaction{txn}, big.NewInt(1), "stale", option)
+	assert.NoError(t, err)
+	_, err = client.ExecuteByTx(stale, option)
+	assert.ErrorContains(t, err, "invalid nonce")
+
+	server.Advance(time.Minute)
+	state, err := client.PollUntilState(context.Background(), execute.TransactionID, []types.RelayerTransactionState{types.RelayerStateConfirmed}, &failed, 1, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", state.Nonce)
+	_, err = client.PollUntilState(context.Background(), failing.TransactionID, []types.RelayerTransactionState{types.RelayerStateConfirmed}, &failed, 1, time.Second)
+	var txErr *types.TxFailedError
+	assert.ErrorAs(t, err, &txErr)
+	assert.Len(t, server.Transactions(), 2)
 }
 
 func createUsdcApproveTxn(tokenAddress string, spenderAddress string) (types.SafeTransaction, error) {
@@ -0,0 +1,467 @@
+package relayer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	"github.com/polymarket/go-order-utils/pkg/config"
+	"github.com/polymarket/go-order-utils/pkg/contracts/exchange"
+)
+
+const (
+	ContractConditionalTokens = "ConditionalTokens"
+	ContractNegRiskAdapter    = "NegRiskAdapter"
+	ContractCollateral        = "Collateral"
+	ContractExchange          = "Exchange"
+	ContractNegRiskExchange   = "NegRiskExchange"
+	ContractMultiSend         = "MultiSend"
+)
+
+// ErrUnknownLog is returned by DecodeLog for events the decoder does not handle.
+var ErrUnknownLog = errors.New("unknown log")
+
+const erc20Abi = `[
+  {"inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+  {"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+  {"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"transferFrom","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"}
+]`
+
+// Action is the typed form of a decoded inner call.
+type Action interface {
+	Kind() string
+}
+
+type ApproveAction struct {
+	Token   common.Address
+	Spender common.Address
+	Amount  *big.Int
+}
+
+type TransferAction struct {
+	Token  common.Address
+	From   common.Address // zero for transfer, set for transferFrom
+	To     common.Address
+	Amount *big.Int
+}
+
+type SetApprovalForAllAction struct {
+	Token    common.Address
+	Operator common.Address
+	Approved bool
+}
+
+type ERC1155TransferAction struct {
+	Token  common.Address
+	From   common.Address
+	To     common.Address
+	IDs    []*big.Int
+	Values []*big.Int
+}
+
+// SplitAction is a splitPosition on ConditionalTokens or the NegRiskAdapter.
+// Partition is nil for the adapter's binary splitPosition(conditionId, amount).
+type SplitAction struct {
+	Contract           common.Address
+	NegRisk            bool
+	CollateralToken    common.Address
+	ParentCollectionID common.Hash
+	ConditionID        common.Hash
+	Partition          []*big.Int
+	Amount             *big.Int
+}
+
+type MergeAction struct {
+	Contract           common.Address
+	NegRisk            bool
+	CollateralToken    common.Address
+	ParentCollectionID common.Hash
+	ConditionID        common.Hash
+	Partition          []*big.Int
+	Amount             *big.Int
+}
+
+type RedeemAction struct {
+	CollateralToken    common.Address
+	ParentCollectionID common.Hash
+	ConditionID        common.Hash
+	IndexSets          []*big.Int
+}
+
+type NegRiskRedeemAction struct {
+	ConditionID common.Hash
+	Amounts     []*big.Int
+}
+
+type ConvertAction struct {
+	MarketID common.Hash
+	IndexSet *big.Int
+	Amount   *big.Int
+}
+
+type CancelOrdersAction struct {
+	Exchange common.Address
+	Orders   []exchange.Order
+}
+
+type IncrementNonceAction struct {
+	Exchange common.Address
+}
+
+// ContractCallAction is a call to a known ABI method without a dedicated action type.
+type ContractCallAction struct {
+	Method string
+	Args   map[string]interface{}
+}
+
+// UnknownAction is a call whose selector matched none of the known ABIs.
+type UnknownAction struct {
+	Selector string
+}
+
+func (ApproveAction) Kind() string           { return "approve" }
+func (TransferAction) Kind() string          { return "transfer" }
+func (SetApprovalForAllAction) Kind() string { return "setApprovalForAll" }
+func (ERC1155TransferAction) Kind() string   { return "erc1155Transfer" }
+func (SplitAction) Kind() string             { return "split" }
+func (MergeAction) Kind() string             { return "merge" }
+func (RedeemAction) Kind() string            { return "redeem" }
+func (NegRiskRedeemAction) Kind() string     { return "negRiskRedeem" }
+func (ConvertAction) Kind() string           { return "convert" }
+func (CancelOrdersAction) Kind() string      { return "cancelOrders" }
+func (IncrementNonceAction) Kind() string    { return "incrementNonce" }
+func (a ContractCallAction) Kind() string    { return a.Method }
+func (UnknownAction) Kind() string           { return "unknown" }
+
+// DecodedCall is one call executed by the Safe, either directly or as a MultiSend entry.
+type DecodedCall struct {
+	To        common.Address
+	Value     *big.Int
+	Operation types.OperationType
+	Data      []byte
+	Contract  string // one of the Contract* names, empty if the address is not known
+	Method    string
+	Action    Action
+}
+
+// ExecTransaction holds the arguments of a Safe execTransaction call.
+type ExecTransaction struct {
+	To             common.Address
+	Value          *big.Int
+	Data           []byte
+	Operation      types.OperationType
+	SafeTxGas      *big.Int
+	BaseGas        *big.Int
+	GasPrice       *big.Int
+	GasToken       common.Address
+	RefundReceiver common.Address
+	Signatures     []byte
+}
+
+// DecodedTransaction is a relayer transaction broken down into the calls it performs.
+// Exec is nil when the data is not an execTransaction call.
+type DecodedTransaction struct {
+	Exec      *ExecTransaction
+	MultiSend bool
+	Calls     []DecodedCall
+}
+
+// Decoder turns relayer calldata and receipt logs into typed values using the
+// Polymarket contract ABIs for a chain.
+type Decoder struct {
+	contracts map[common.Address]string
+	multiSend common.Address
+
+	safeABI    *abi.ABI
+	ctfABI     *abi.ABI
+	adapterABI *abi.ABI
+	erc20ABI   abi.ABI
+	exchABI    *abi.ABI
+
+	ctf     *ConditionalTokensFilterer
+	adapter *ContractFilterer
+}
+
+func NewDecoder(chainId *big.Int) (*Decoder, error) {
+	contracts, err := config.GetContracts(chainId.Int64())
+	if err != nil {
+		return nil, fmt.Errorf("new decoder: %w", err)
+	}
+	safeCfg := types.GetContractConfig(chainId)
+
+	d := &Decoder{
+		contracts: map[common.Address]string{
+			contracts.Conditional:     ContractConditionalTokens,
+			contracts.NegRiskAdapter:  ContractNegRiskAdapter,
+			contracts.Collateral:      ContractCollateral,
+			contracts.Exchange:        ContractExchange,
+			contracts.NegRiskExchange: ContractNegRiskExchange,
+		},
+		multiSend: common.HexToAddress(safeCfg.SafeMultisend),
+	}
+	d.contracts[d.multiSend] = ContractMultiSend
+
+	if d.safeABI, err = types.ContractMetaData.GetAbi(); err != nil {
+		return nil, fmt.Errorf("new decoder: safe abi: %w", err)
+	}
+	if d.ctfABI, err = ConditionalTokensMetaData.GetAbi(); err != nil {
+		return nil, fmt.Errorf("new decoder: conditional tokens abi: %w", err)
+	}
+	if d.adapterABI, err = ContractMetaData.GetAbi(); err != nil {
+		return nil, fmt.Errorf("new decoder: neg risk adapter abi: %w", err)
+	}
+	if d.erc20ABI, err = abi.JSON(strings.NewReader(erc20Abi)); err != nil {
+		return nil, fmt.Errorf("new decoder: erc20 abi: %w", err)
+	}
+	if d.exchABI, err = exchange.ExchangeMetaData.GetAbi(); err != nil {
+		return nil, fmt.Errorf("new decoder: exchange abi: %w", err)
+	}
+	if d.ctf, err = NewConditionalTokensFilterer(contracts.Conditional, nil); err != nil {
+		return nil, fmt.Errorf("new decoder: %w", err)
+	}
+	if d.adapter, err = NewContractFilterer(contracts.NegRiskAdapter, nil); err != nil {
+		return nil, fmt.Errorf("new decoder: %w", err)
+	}
+	return d, nil
+}
+
+// DecodeRelayerTransaction decodes the Data of a transaction returned by the relayer.
+func (d *Decoder) DecodeRelayerTransaction(txn *types.RelayerTransaction) (*DecodedTransaction, error) {
+	return d.DecodeTransaction(txn.To, txn.Data)
+}
+
+// DecodeTransaction decodes calldata sent to `to`. The data may be a Safe
+// execTransaction call or the Safe transaction itself (as submitted to the
+// relayer); MultiSend batches are expanded into one call per entry.
+func (d *Decoder) DecodeTransaction(to string, data string) (*DecodedTransaction, error) {
+	raw, err := hexutil.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode transaction: invalid data: %w", err)
+	}
+
+	out := &DecodedTransaction{}
+	inner := types.SafeTransaction{To: to, Data: data, Value: "0", Operation: types.OperationCall}
+
+	if exec := d.safeABI.Methods["execTransaction"]; len(raw) >= 4 && bytes.Equal(raw[:4], exec.ID) {
+		if out.Exec, err = d.unpackExec(&exec, raw[4:]); err != nil {
+			return nil, err
+		}
+		inner = types.SafeTransaction{
+			To:        out.Exec.To.Hex(),
+			Data:      hexutil.Encode(out.Exec.Data),
+			Value:     out.Exec.Value.String(),
+			Operation: out.Exec.Operation,
+		}
+	}
+
+	if common.HexToAddress(inner.To) == d.multiSend {
+		txns, err := types.DecodeMultiSendData(hexutil.MustDecode(inner.Data))
+		if err != nil {
+			return nil, fmt.Errorf("decode transaction: %w", err)
+		}
+		out.MultiSend = true
+		for _, txn := range txns {
+			call, err := d.DecodeCall(txn)
+			if err != nil {
+				return nil, err
+			}
+			out.Calls = append(out.Calls, call)
+		}
+		return out, nil
+	}
+
+	call, err := d.DecodeCall(inner)
+	if err != nil {
+		return nil, err
+	}
+	out.Calls = []DecodedCall{call}
+	return out, nil
+}
+
+func (d *Decoder) unpackExec(method *abi.Method, payload []byte) (*ExecTransaction, error) {
+	args, err := method.Inputs.Unpack(payload)
+	if err != nil {
+		return nil, fmt.Errorf("decode transaction: unpack execTransaction failed: %w", err)
+	}
+	return &ExecTransaction{
+		To:             args[0].(common.Address),
+		Value:          args[1].(*big.Int),
+		Data:           args[2].([]byte),
+		Operation:      types.OperationType(args[3].(uint8)),
+		SafeTxGas:      args[4].(*big.Int),
+		BaseGas:        args[5].(*big.Int),
+		GasPrice:       args[6].(*big.Int),
+		GasToken:       args[7].(common.Address),
+		RefundReceiver: args[8].(common.Address),
+		Signatures:     args[9].([]byte),
+	}, nil
+}
+
+// DecodeCall decodes a single Safe transaction into a typed Action. Calls to
+// unknown contracts are matched by selector against every known ABI.
+func (d *Decoder) DecodeCall(txn types.SafeTransaction) (DecodedCall, error) {
+	data, err := hexutil.Decode(txn.Data)
+	if err != nil {
+		return DecodedCall{}, fmt.Errorf("decode call: invalid data: %w", err)
+	}
+	to := common.HexToAddress(txn.To)
+	call := DecodedCall{
+		To:        to,
+		Value:     parseValue(txn.Value),
+		Operation: txn.Operation,
+		Data:      data,
+		Contract:  d.contracts[to],
+	}
+	if len(data) < 4 {
+		call.Action = UnknownAction{Selector: hexutil.Encode(data)}
+		return call, nil
+	}
+
+	for _, contractABI := range d.abisFor(call.Contract) {
+		method, err := contractABI.MethodById(data[:4])
+		if err != nil {
+			continue
+		}
+		args, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			return DecodedCall{}, fmt.Errorf("decode call: unpack %s failed: %w", method.RawName, err)
+		}
+		call.Method = method.RawName
+		call.Action = d.toAction(to, contractABI, method, args)
+		return call, nil
+	}
+	call.Action = UnknownAction{Selector: hexutil.Encode(data[:4])}
+	return call, nil
+}
+
+func (d *Decoder) abisFor(contract string) []*abi.ABI {
+	switch contract {
+	case ContractConditionalTokens:
+		return []*abi.ABI{d.ctfABI}
+	case ContractNegRiskAdapter:
+		return []*abi.ABI{d.adapterABI}
+	case ContractCollateral:
+		return []*abi.ABI{&d.erc20ABI}
+	case ContractExchange, ContractNegRiskExchange:
+		return []*abi.ABI{d.exchABI}
+	default:
+		return []*abi.ABI{d.ctfABI, d.adapterABI, &d.erc20ABI, d.exchABI}
+	}
+}
+
+func (d *Decoder) toAction(to common.Address, contractABI *abi.ABI, method *abi.Method, args []interface{}) Action {
+	negRisk := contractABI == d.adapterABI
+	switch {
+	case method.RawName == "approve" && len(args) == 2:
+		return ApproveAction{Token: to, Spender: args[0].(common.Address), Amount: args[1].(*big.Int)}
+	case method.RawName == "transfer" && len(args) == 2:
+		return TransferAction{Token: to, To: args[0].(common.Address), Amount: args[1].(*big.Int)}
+	case method.RawName == "transferFrom" && len(args) == 3:
+		return TransferAction{Token: to, From: args[0].(common.Address), To: args[1].(common.Address), Amount: args[2].(*big.Int)}
+	case method.RawName == "setApprovalForAll":
+		return SetApprovalForAllAction{Token: to, Operator: args[0].(common.Address), Approved: args[1].(bool)}
+	case method.RawName == "safeTransferFrom":
+		return ERC1155TransferAction{Token: to, From: args[0].(common.Address), To: args[1].(common.Address),
+			IDs: []*big.Int{args[2].(*big.Int)}, Values: []*big.Int{args[3].(*big.Int)}}
+	case method.RawName == "safeBatchTransferFrom":
+		return ERC1155TransferAction{Token: to, From: args[0].(common.Address), To: args[1].(common.Address),
+			IDs: args[2].([]*big.Int), Values: args[3].([]*big.Int)}
+	case method.RawName == "splitPosition" && len(args) == 2:
+		return SplitAction{Contract: to, NegRisk: negRisk, ConditionID: args[0].([32]byte), Amount: args[1].(*big.Int)}
+	case method.RawName == "splitPosition":
+		return SplitAction{Contract: to, NegRisk: negRisk, CollateralToken: args[0].(common.Address),
+			ParentCollectionID: args[1].([32]byte), ConditionID: args[2].([32]byte), Partition: args[3].([]*big.Int), Amount: args[4].(*big.Int)}
+	case method.RawName == "mergePositions" && len(args) == 2:
+		return MergeAction{Contract: to, NegRisk: negRisk, ConditionID: args[0].([32]byte), Amount: args[1].(*big.Int)}
+	case method.RawName == "mergePositions":
+		return MergeAction{Contract: to, NegRisk: negRisk, CollateralToken: args[0].(common.Address),
+			ParentCollectionID: args[1].([32]byte), ConditionID: args[2].([32]byte), Partition: args[3].([]*big.Int), Amount: args[4].(*big.Int)}
+	case method.RawName == "redeemPositions" && negRisk:
+		return NegRiskRedeemAction{ConditionID: args[0].([32]byte), Amounts: args[1].([]*big.Int)}
+	case method.RawName == "redeemPositions":
+		return RedeemAction{CollateralToken: args[0].(common.Address), ParentCollectionID: args[1].([32]byte),
+			ConditionID: args[2].([32]byte), IndexSets: args[3].([]*big.Int)}
+	case method.RawName == "convertPositions":
+		return ConvertAction{MarketID: args[0].([32]byte), IndexSet: args[1].(*big.Int), Amount: args[2].(*big.Int)}
+	case method.RawName == "cancelOrder":
+		order := *abi.ConvertType(args[0], new(exchange.Order)).(*exchange.Order)
+		return CancelOrdersAction{Exchange: to, Orders: []exchange.Order{order}}
+	case method.RawName == "cancelOrders":
+		orders := *abi.ConvertType(args[0], new([]exchange.Order)).(*[]exchange.Order)
+		return CancelOrdersAction{Exchange: to, Orders: orders}
+	case method.RawName == "incrementNonce":
+		return IncrementNonceAction{Exchange: to}
+	}
+
+	named := make(map[string]interface{}, len(args))
+	for i, input := range method.Inputs {
+		name := input.Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		named[name] = args[i]
+	}
+	return ContractCallAction{Method: method.RawName, Args: named}
+}
+
+// DecodeLog decodes PayoutRedemption, PositionSplit, PositionsMerge and
+// PositionsConverted logs emitted by ConditionalTokens or the NegRiskAdapter.
+// It returns the generated binding event, e.g. *ConditionalTokensPayoutRedemption
+// or *ContractPositionsConverted, and ErrUnknownLog for any other log.
+func (d *Decoder) DecodeLog(log ethtypes.Log) (interface{}, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrUnknownLog
+	}
+	switch log.Topics[0] {
+	case d.ctfABI.Events["PayoutRedemption"].ID:
+		return d.ctf.ParsePayoutRedemption(log)
+	case d.ctfABI.Events["PositionSplit"].ID:
+		return d.ctf.ParsePositionSplit(log)
+	case d.ctfABI.Events["PositionsMerge"].ID:
+		return d.ctf.ParsePositionsMerge(log)
+	case d.adapterABI.Events["PayoutRedemption"].ID:
+		return d.adapter.ParsePayoutRedemption(log)
+	case d.adapterABI.Events["PositionSplit"].ID:
+		return d.adapter.ParsePositionSplit(log)
+	case d.adapterABI.Events["PositionsMerge"].ID:
+		return d.adapter.ParsePositionsMerge(log)
+	case d.adapterABI.Events["PositionsConverted"].ID:
+		return d.adapter.ParsePositionsConverted(log)
+	}
+	return nil, ErrUnknownLog
+}
+
+// DecodeLogs decodes every known log of a receipt, skipping the rest.
+func (d *Decoder) DecodeLogs(logs []*ethtypes.Log) ([]interface{}, error) {
+	var events []interface{}
+	for _, log := range logs {
+		ev, err := d.DecodeLog(*log)
+		if errors.Is(err, ErrUnknownLog) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+func parseValue(value string) *big.Int {
+	if strings.HasPrefix(value, "0x") {
+		if v, ok := new(big.Int).SetString(value[2:], 16); ok {
+			return v
+		}
+	}
+	if v, ok := new(big.Int).SetString(value, 10); ok {
+		return v
+	}
+	return big.NewInt(0)
+}
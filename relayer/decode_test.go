@@ -0,0 +1,125 @@
+package relayer_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/override-coder/go-polymarket-sdk/relayer"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	"github.com/polymarket/go-order-utils/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeExecTransactionMultiSend(t *testing.T) {
+	contracts, err := config.GetContracts(chaindId.Int64())
+	assert.NoError(t, err)
+	decoder, err := relayer.NewDecoder(chaindId)
+	assert.NoError(t, err)
+
+	approve, err := createUsdcApproveTxn(contracts.Collateral.Hex(), contracts.Exchange.Hex())
+	assert.NoError(t, err)
+
+	ctfABI, _ := relayer.ConditionalTokensMetaData.GetAbi()
+	conditionID := common.HexToHash("0xee1091d8be46ae92f59d743694ff29e4fbd9d9b15151064ab4a4af7062aed6de")
+	redeemData, err := ctfABI.Pack("redeemPositions", contracts.Collateral, [32]byte{}, conditionID, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	assert.NoError(t, err)
+	redeem := types.SafeTransaction{To: contracts.Conditional.Hex(), Data: hexutil.Encode(redeemData), Value: "0"}
+
+	adapterABI, _ := relayer.ContractMetaData.GetAbi()
+	marketID := common.HexToHash("0x01")
+	convertData, err := adapterABI.Pack("convertPositions", marketID, big.NewInt(3), big.NewInt(1_000_000))
+	assert.NoError(t, err)
+	convert := types.SafeTransaction{To: contracts.NegRiskAdapter.Hex(), Data: hexutil.Encode(convertData), Value: "0"}
+
+	unknown := types.SafeTransaction{To: "0x0000000000000000000000000000000000000001", Data: "0xdeadbeef", Value: "5"}
+
+	batch := []types.SafeTransaction{approve, redeem, convert, unknown}
+	multi, err := types.CreateSafeMultiSendTransaction(batch, types.GetContractConfig(chaindId).SafeMultisend)
+	assert.NoError(t, err)
+
+	unpacked, err := types.DecodeMultiSendData(hexutil.MustDecode(multi.Data))
+	assert.NoError(t, err)
+	for i := range batch {
+		assert.True(t, common.HexToAddress(batch[i].To) == common.HexToAddress(unpacked[i].To))
+		assert.Equal(t, batch[i].Data, unpacked[i].Data)
+		assert.Equal(t, batch[i].Value, unpacked[i].Value)
+	}
+
+	safeABI, _ := types.ContractMetaData.GetAbi()
+	execData, err := safeABI.Pack("execTransaction",
+		common.HexToAddress(multi.To), big.NewInt(0), hexutil.MustDecode(multi.Data), uint8(multi.Operation),
+		big.NewInt(0), big.NewInt(0), big.NewInt(0), common.Address{}, common.Address{}, []byte{0x01})
+	assert.NoError(t, err)
+
+	decoded, err := decoder.DecodeRelayerTransaction(&types.RelayerTransaction{
+		To:   "0x6e0c80c90ea6c15917308f820eac91ce2724b5b5",
+		Data: hexutil.Encode(execData),
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, decoded.Exec)
+	assert.Equal(t, types.OperationDelegateCall, decoded.Exec.Operation)
+	assert.True(t, decoded.MultiSend)
+	assert.Len(t, decoded.Calls, 4)
+
+	assert.Equal(t, relayer.ContractCollateral, decoded.Calls[0].Contract)
+	approveAction, ok := decoded.Calls[0].Action.(relayer.ApproveAction)
+	assert.True(t, ok)
+	assert.Equal(t, contracts.Collateral, approveAction.Token)
+	assert.Equal(t, contracts.Exchange, approveAction.Spender)
+
+	assert.Equal(t, "redeemPositions", decoded.Calls[1].Method)
+	assert.Equal(t, relayer.RedeemAction{
+		CollateralToken: contracts.Collateral,
+		ConditionID:     conditionID,
+		IndexSets:       []*big.Int{big.NewInt(1), big.NewInt(2)},
+	}, decoded.Calls[1].Action)
+
+	assert.Equal(t, relayer.ConvertAction{MarketID: marketID, IndexSet: big.NewInt(3), Amount: big.NewInt(1_000_000)}, decoded.Calls[2].Action)
+
+	assert.Equal(t, relayer.UnknownAction{Selector: "0xdeadbeef"}, decoded.Calls[3].Action)
+	assert.Equal(t, int64(5), decoded.Calls[3].Value.Int64())
+}
+
+func TestDecodeLog(t *testing.T) {
+	decoder, err := relayer.NewDecoder(chaindId)
+	assert.NoError(t, err)
+
+	ctfABI, _ := relayer.ConditionalTokensMetaData.GetAbi()
+	event := ctfABI.Events["PayoutRedemption"]
+	redeemer := common.HexToAddress("0x8c5f23249462e20C4a202Ad35275562075F37e09")
+	collateral := common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174")
+	conditionID := common.HexToHash("0xee1091d8be46ae92f59d743694ff29e4fbd9d9b15151064ab4a4af7062aed6de")
+	data, err := event.Inputs.NonIndexed().Pack(conditionID, []*big.Int{big.NewInt(2)}, big.NewInt(42))
+	assert.NoError(t, err)
+
+	ev, err := decoder.DecodeLog(ethtypes.Log{
+		Topics: []common.Hash{event.ID, common.BytesToHash(redeemer.Bytes()), common.BytesToHash(collateral.Bytes()), {}},
+		Data:   data,
+	})
+	assert.NoError(t, err)
+	redemption, ok := ev.(*relayer.ConditionalTokensPayoutRedemption)
+	assert.True(t, ok)
+	assert.Equal(t, redeemer, redemption.Redeemer)
+	assert.Equal(t, [32]byte(conditionID), redemption.ConditionId)
+	assert.Equal(t, int64(42), redemption.Payout.Int64())
+
+	adapterABI, _ := relayer.ContractMetaData.GetAbi()
+	converted := adapterABI.Events["PositionsConverted"]
+	data, err = converted.Inputs.NonIndexed().Pack(big.NewInt(7))
+	assert.NoError(t, err)
+	ev, err = decoder.DecodeLog(ethtypes.Log{
+		Topics: []common.Hash{converted.ID, common.BytesToHash(redeemer.Bytes()), common.HexToHash("0x01"), common.BigToHash(big.NewInt(3))},
+		Data:   data,
+	})
+	assert.NoError(t, err)
+	conv, ok := ev.(*relayer.ContractPositionsConverted)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), conv.IndexSet.Int64())
+	assert.Equal(t, int64(7), conv.Amount.Int64())
+
+	_, err = decoder.DecodeLog(ethtypes.Log{Topics: []common.Hash{common.HexToHash("0x1234")}})
+	assert.ErrorIs(t, err, relayer.ErrUnknownLog)
+}
@@ -0,0 +1,186 @@
+package relayer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	http2 "github.com/override-coder/go-polymarket-sdk/http"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+)
+
+// Executor runs a batch of Safe transactions for option.SingerAddress's Safe.
+type Executor interface {
+	Execute(txns []types.SafeTransaction, metadata string, option *sdktypes.AuthOption) (*types.RelayerTransactionResponse, error)
+}
+
+var (
+	_ Executor = (*Client)(nil)
+	_ Executor = (*DirectExecutor)(nil)
+	_ Executor = (*FallbackExecutor)(nil)
+)
+
+// DirectExecutor submits execTransaction on the Safe itself, paying gas from
+// its own key instead of going through the relayer. The Safe must already be
+// deployed. The response carries the on-chain hash as TransactionID and
+// STATE_EXECUTED; use bind.WaitMined to wait for inclusion.
+type DirectExecutor struct {
+	backend bind.ContractBackend
+	gasKey  *ecdsa.PrivateKey
+
+	chainId        *big.Int
+	signFn         signing.SignatureFunc
+	contractConfig *types.ContractConfig
+}
+
+func NewDirectExecutor(chainId *big.Int, backend bind.ContractBackend, gasKey *ecdsa.PrivateKey, signFn signing.SignatureFunc) *DirectExecutor {
+	return &DirectExecutor{
+		backend:        backend,
+		gasKey:         gasKey,
+		chainId:        chainId,
+		signFn:         signFn,
+		contractConfig: types.GetContractConfig(chainId),
+	}
+}
+
+func (e *DirectExecutor) Execute(txns []types.SafeTransaction, metadata string, option *sdktypes.AuthOption) (*types.RelayerTransactionResponse, error) {
+	ctx := context.Background()
+	from := option.SingerAddress
+	safeAddr, err := deriveSafe(from, e.contractConfig.SafeFactory)
+	if err != nil {
+		return nil, fmt.Errorf("direct execute: deriveSafe failed: %w", err)
+	}
+	safe := common.HexToAddress(safeAddr)
+
+	code, err := e.backend.CodeAt(ctx, safe, nil)
+	if err != nil {
+		return nil, fmt.Errorf("direct execute: get code failed: %w", err)
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("direct execute: safe not deployed")
+	}
+
+	safeContract, err := types.NewContract(safe, e.backend)
+	if err != nil {
+		return nil, fmt.Errorf("direct execute: bind safe failed: %w", err)
+	}
+	nonce, err := safeContract.Nonce(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("direct execute: get safe nonce failed: %w", err)
+	}
+
+	args := types.SafeTransactionArgs{
+		From:         from,
+		Nonce:        nonce.String(),
+		ChainID:      e.chainId.Int64(),
+		Transactions: txns,
+	}
+	req, err := buildSafeTransactionRequest(e.signFn, args, e.contractConfig, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("direct execute: build safe transaction request failed: %w", err)
+	}
+	data, err := hexutil.Decode(req.Data)
+	if err != nil {
+		return nil, fmt.Errorf("direct execute: invalid data: %w", err)
+	}
+	signature, err := hexutil.Decode(req.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("direct execute: invalid signature: %w", err)
+	}
+	operation := uint8(types.OperationCall)
+	if req.SignatureParams.Operation != nil && *req.SignatureParams.Operation == "1" {
+		operation = uint8(types.OperationDelegateCall)
+	}
+
+	opts, err := e.transactOpts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	zero := big.NewInt(0)
+	tx, err := safeContract.ExecTransaction(opts, common.HexToAddress(req.To), zero, data, operation,
+		zero, zero, zero, common.HexToAddress(sdktypes.ZeroAddress), common.HexToAddress(sdktypes.ZeroAddress), signature)
+	if err != nil {
+		return nil, fmt.Errorf("direct execute: execTransaction failed: %w", err)
+	}
+
+	hash := tx.Hash().Hex()
+	return &types.RelayerTransactionResponse{
+		TransactionID:   hash,
+		State:           string(types.RelayerStateExecuted),
+		Hash:            hash,
+		TransactionHash: hash,
+	}, nil
+}
+
+// transactOpts signs with the gas key for the backend's chain, which may differ
+// from the Polymarket chain used in the Safe signature (e.g. a local fork).
+func (e *DirectExecutor) transactOpts(ctx context.Context) (*bind.TransactOpts, error) {
+	txChainId := e.chainId
+	if reader, ok := e.backend.(interface {
+		ChainID(ctx context.Context) (*big.Int, error)
+	}); ok {
+		id, err := reader.ChainID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("direct execute: get chain id failed: %w", err)
+		}
+		txChainId = id
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(e.gasKey, txChainId)
+	if err != nil {
+		return nil, fmt.Errorf("direct execute: transactor failed: %w", err)
+	}
+	opts.Context = ctx
+	return opts, nil
+}
+
+// FallbackExecutor tries Primary (usually the relayer Client) and routes the
+// batch to Fallback (usually a DirectExecutor) when ShouldFallback accepts the
+// error. The default, IsRelayerUnavailable, only falls back on transport errors,
+// 429 and 5xx; note that a timed-out /submit may still have been accepted.
+type FallbackExecutor struct {
+	Primary        Executor
+	Fallback       Executor
+	ShouldFallback func(err error) bool
+}
+
+func NewFallbackExecutor(primary, fallback Executor) *FallbackExecutor {
+	return &FallbackExecutor{Primary: primary, Fallback: fallback, ShouldFallback: IsRelayerUnavailable}
+}
+
+func (f *FallbackExecutor) Execute(txns []types.SafeTransaction, metadata string, option *sdktypes.AuthOption) (*types.RelayerTransactionResponse, error) {
+	out, err := f.Primary.Execute(txns, metadata, option)
+	if err == nil {
+		return out, nil
+	}
+	shouldFallback := f.ShouldFallback
+	if shouldFallback == nil {
+		shouldFallback = IsRelayerUnavailable
+	}
+	if !shouldFallback(err) {
+		return nil, err
+	}
+	out, fallbackErr := f.Fallback.Execute(txns, metadata, option)
+	if fallbackErr != nil {
+		return nil, fmt.Errorf("fallback execute failed: %w (primary: %v)", fallbackErr, err)
+	}
+	return out, nil
+}
+
+// IsRelayerUnavailable reports whether err means the relayer could not be
+// reached, is rate limiting us (429, e.g. exhausted builder quota) or failed (5xx).
+func IsRelayerUnavailable(err error) bool {
+	var upstream *http2.UpstreamServiceError
+	if errors.As(err, &upstream) {
+		return upstream.StatusCode == 429 || upstream.StatusCode >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
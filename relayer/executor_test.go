@@ -0,0 +1,86 @@
+package relayer_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	http2 "github.com/override-coder/go-polymarket-sdk/http"
+	"github.com/override-coder/go-polymarket-sdk/relayer"
+	"github.com/override-coder/go-polymarket-sdk/relayer/simulate"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/polymarket/go-order-utils/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createUsdcTransferTxn(token string, to common.Address, amount int64) types.SafeTransaction {
+	erc20, _ := abi.JSON(strings.NewReader(`[{"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"}]`))
+	data, _ := erc20.Pack("transfer", to, big.NewInt(amount))
+	return types.SafeTransaction{To: token, Data: hexutil.Encode(data), Value: "0"}
+}
+
+func TestDirectExecutorAndFallback(t *testing.T) {
+	ctx := context.Background()
+	contracts, _ := config.GetContracts(chaindId.Int64())
+	usdc := contracts.Collateral.Hex()
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	client := relayer.NewClient(server.URL, chaindId, signature, testBuilderCreds)
+	safe, err := client.GetExpectedSafe(owner)
+	assert.NoError(t, err)
+
+	sim, err := simulate.New(chaindId, owner)
+	assert.NoError(t, err)
+	defer sim.Close()
+	assert.NoError(t, sim.MintCollateral(ctx, safe, big.NewInt(5_000_000)))
+
+	gasKey, _ := crypto.GenerateKey()
+	assert.NoError(t, sim.FundGas(ctx, crypto.PubkeyToAddress(gasKey.PublicKey).Hex(), big.NewInt(1e18)))
+	direct := relayer.NewDirectExecutor(chaindId, sim.Backend().Client(), gasKey, signature)
+	recipient := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	option := &sdktypes.AuthOption{SingerAddress: owner}
+
+	out, err := direct.Execute([]types.SafeTransaction{createUsdcTransferTxn(usdc, recipient, 2_000_000)}, "transfer", option)
+	assert.NoError(t, err)
+	assert.Equal(t, string(types.RelayerStateExecuted), out.State)
+	sim.Backend().Commit()
+
+	// the relayer answers 503, so the batch is routed to the Safe directly
+	executor := relayer.NewFallbackExecutor(client, direct)
+	batch := []types.SafeTransaction{
+		createUsdcTransferTxn(usdc, recipient, 1_000_000),
+		createUsdcTransferTxn(usdc, recipient, 1_000_000),
+	}
+	_, err = executor.Execute(batch, "transfer", option)
+	assert.NoError(t, err)
+	sim.Backend().Commit()
+
+	balance, err := sim.CollateralBalance(ctx, recipient.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4_000_000), balance.Int64())
+	nonce, err := sim.SafeNonce(ctx, safe)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), nonce.Int64())
+
+	// client errors are returned as-is
+	status = http.StatusBadRequest
+	_, err = executor.Execute(batch, "transfer", option)
+	var upstream *http2.UpstreamServiceError
+	assert.True(t, errors.As(err, &upstream))
+	assert.Equal(t, http.StatusBadRequest, upstream.StatusCode)
+	assert.False(t, relayer.IsRelayerUnavailable(err))
+}
@@ -3,8 +3,6 @@ package relayer
 import (
 	"encoding/hex"
 	"fmt"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/override-coder/go-polymarket-sdk/relayer/types"
 	"github.com/override-coder/go-polymarket-sdk/signing"
 	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
@@ -46,29 +44,7 @@ func buildSafeCreateTransactionRequest(
 }
 
 func deriveSafe(ownerAddress string, safeFactory string) (string, error) {
-	if !common.IsHexAddress(ownerAddress) {
-		return "", fmt.Errorf("deriveSafe: invalid owner address: %s", ownerAddress)
-	}
-	if !common.IsHexAddress(safeFactory) {
-		return "", fmt.Errorf("deriveSafe: invalid factory address: %s", safeFactory)
-	}
-
-	initCodeHashBytes, err := hex.DecodeString(types.SafeInitCodeHashHex)
-	if err != nil {
-		return "", fmt.Errorf("deriveSafe: invalid SAFE_INIT_CODE_HASH_HEX: %w", err)
-	}
-	if len(initCodeHashBytes) != 32 {
-		return "", fmt.Errorf("deriveSafe: unexpected init code hash length %d", len(initCodeHashBytes))
-	}
-
-	paddedOwner := common.LeftPadBytes(common.HexToAddress(ownerAddress).Bytes(), 32)
-	saltBytes := crypto.Keccak256(paddedOwner)
-	factoryAddr := common.HexToAddress(safeFactory)
-	salt32 := [32]byte{}
-	copy(salt32[:], saltBytes[:32])
-
-	computedAddress := crypto.CreateAddress2(factoryAddr, salt32, initCodeHashBytes)
-	return computedAddress.Hex(), nil
+	return types.DeriveSafeAddress(ownerAddress, safeFactory)
 }
 
 func buildSafeTransactionRequest(signatureFunc signing.SignatureFunc, args types.SafeTransactionArgs, safeCfg *types.ContractConfig, metadata string) (*types.TransactionRequest, error) {
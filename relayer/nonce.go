@@ -0,0 +1,121 @@
+package relayer
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+)
+
+// NonceFetcher returns the next Safe nonce known to the relayer for a signer.
+type NonceFetcher func(signer string) (*big.Int, error)
+
+// NonceManager reserves Safe nonces locally so that concurrent Execute calls for
+// the same signer never sign with the same nonce. Submissions are serialized per
+// signer, and the local counter is resynced from /nonce after a failure or when
+// the relayer reports a nonce ahead of ours (a gap left by another submitter).
+type NonceManager struct {
+	fetch NonceFetcher
+
+	mu    sync.Mutex
+	safes map[string]*safeNonce
+}
+
+type safeNonce struct {
+	submit sync.Mutex
+
+	mu     sync.Mutex
+	next   *big.Int
+	synced bool
+}
+
+func NewNonceManager(fetch NonceFetcher) *NonceManager {
+	return &NonceManager{
+		fetch: fetch,
+		safes: make(map[string]*safeNonce),
+	}
+}
+
+// NewClientNonceManager builds a NonceManager backed by the relayer /nonce endpoint.
+func NewClientNonceManager(c *Client) *NonceManager {
+	return NewNonceManager(func(signer string) (*big.Int, error) {
+		payload, err := c.GetNonce(signer, types.TransactionTypeSAFE)
+		if err != nil {
+			return nil, err
+		}
+		nonce, ok := new(big.Int).SetString(payload.Nonce, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid relayer nonce %q", payload.Nonce)
+		}
+		return nonce, nil
+	})
+}
+
+func (m *NonceManager) safe(signer string) *safeNonce {
+	key := strings.ToLower(signer)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.safes[key]
+	if !ok {
+		s = &safeNonce{}
+		m.safes[key] = s
+	}
+	return s
+}
+
+// Lock serializes submissions for a signer. The returned func releases the lock.
+func (m *NonceManager) Lock(signer string) func() {
+	s := m.safe(signer)
+	s.submit.Lock()
+	return s.submit.Unlock
+}
+
+// Reserve returns the next nonce for the signer and advances the local counter.
+func (m *NonceManager) Reserve(signer string) (*big.Int, error) {
+	s := m.safe(signer)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.synced {
+		remote, err := m.fetch(signer)
+		if err != nil {
+			return nil, fmt.Errorf("reserve nonce: fetch failed: %w", err)
+		}
+		s.next = remote
+		s.synced = true
+	}
+	nonce := new(big.Int).Set(s.next)
+	s.next.Add(s.next, big.NewInt(1))
+	return nonce, nil
+}
+
+// Resync drops the local counter so the next Reserve refetches from the relayer.
+// Call it when a submission fails or a transaction ends in a failure state.
+func (m *NonceManager) Resync(signer string) {
+	s := m.safe(signer)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.synced = false
+	s.next = nil
+}
+
+// Observe resyncs the signer when the relayer reports a state that does not
+// consume the nonce.
+func (m *NonceManager) Observe(signer string, state types.RelayerTransactionState) {
+	if state == types.RelayerStateFailed || state == types.RelayerStateInvalid {
+		m.Resync(signer)
+	}
+}
+
+// Peek returns the next nonce that Reserve would hand out, or nil if unsynced.
+func (m *NonceManager) Peek(signer string) *big.Int {
+	s := m.safe(signer)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.synced {
+		return nil
+	}
+	return new(big.Int).Set(s.next)
+}
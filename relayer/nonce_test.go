@@ -0,0 +1,251 @@
+package relayer_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/override-coder/go-polymarket-sdk/relayer"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testBuilderCreds = &sdktypes.BuilderApiKeyCreds{
+	Key:        "test-key",
+	Secret:     "c2VjcmV0LXNlY3JldC1zZWNyZXQtc2VjcmV0LXNlY3I=",
+	Passphrase: "test-passphrase",
+}
+
+// nonceRelayer is a minimal relayer that counts nonce fetches and records the
+// nonce of every submitted SAFE request. It rejects requests signed for
+// another nonce than its own.
+type nonceRelayer struct {
+	mu        sync.Mutex
+	nonce     int64
+	fetches   int
+	submitted []string
+	failNext  bool
+	failBody  string // the body of the next failure, default "transaction rejected"
+}
+
+func (r *nonceRelayer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch req.URL.Path {
+	case types.GET_NONCE:
+		r.fetches++
+		_ = json.NewEncoder(w).Encode(types.NoncePayload{Nonce: fmt.Sprintf("%d", r.nonce)})
+	case types.GET_DEPLOYED:
+		_ = json.NewEncoder(w).Encode(types.GetDeployedResponse{Deployed: true})
+	case types.SUBMIT_TRANSACTION:
+		if r.failNext {
+			r.failNext = false
+			body := r.failBody
+			if body == "" {
+				body = `{"error":"transaction rejected"}`
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		var tx types.TransactionRequest
+		_ = json.NewDecoder(req.Body).Decode(&tx)
+		if *tx.Nonce != fmt.Sprintf("%d", r.nonce) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid nonce"}`))
+			return
+		}
+		r.submitted = append(r.submitted, *tx.Nonce)
+		r.nonce++
+		_ = json.NewEncoder(w).Encode(types.RelayerTransactionResponse{
+			TransactionID: fmt.Sprintf("tx-%s", *tx.Nonce),
+			State:         string(types.RelayerStateNew),
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestNonceManagerConcurrentExecute(t *testing.T) {
+	fake := &nonceRelayer{nonce: 7}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := relayer.NewClient(server.URL, chaindId, signature, testBuilderCreds)
+	assert.NoError(t, client.WithNonceManager(relayer.NewClientNonceManager(client)))
+
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	txn, err := createUsdcApproveTxn("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174", "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E")
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Execute([]types.SafeTransaction{txn}, "approve", &sdktypes.AuthOption{SingerAddress: owner})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, fake.fetches)
+	assert.Equal(t, []string{"7", "8", "9", "10", "11", "12", "13", "14"}, fake.submitted)
+	assert.Equal(t, big.NewInt(15), client.NonceManager().Peek(owner))
+}
+
+func TestNonceManagerResyncAfterFailure(t *testing.T) {
+	fake := &nonceRelayer{nonce: 3, failNext: true}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := relayer.NewClient(server.URL, chaindId, signature, testBuilderCreds)
+	assert.NoError(t, client.WithNonceManager(relayer.NewClientNonceManager(client)))
+
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	txn, err := createUsdcApproveTxn("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174", "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E")
+	assert.NoError(t, err)
+
+	_, err = client.Execute([]types.SafeTransaction{txn}, "approve", &sdktypes.AuthOption{SingerAddress: owner})
+	assert.Error(t, err)
+	assert.Nil(t, client.NonceManager().Peek(owner))
+
+	_, err = client.Execute([]types.SafeTransaction{txn}, "approve", &sdktypes.AuthOption{SingerAddress: owner})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fake.fetches)
+	assert.Equal(t, []string{"3"}, fake.submitted)
+}
+
+func TestNonceManagerObserve(t *testing.T) {
+	remote := big.NewInt(5)
+	nm := relayer.NewNonceManager(func(signer string) (*big.Int, error) {
+		return new(big.Int).Set(remote), nil
+	})
+	signer := "0x8c5f23249462e20C4a202Ad35275562075F37e09"
+
+	n, err := nm.Reserve(signer)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), n.Int64())
+
+	nm.Observe(signer, types.RelayerStateMined)
+	assert.Equal(t, int64(6), nm.Peek(signer).Int64())
+
+	remote.SetInt64(9)
+	nm.Observe(signer, types.RelayerStateFailed)
+	assert.Nil(t, nm.Peek(signer))
+	n, err = nm.Reserve(signer)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), n.Int64())
+}
+
+func TestNonceManagerRetriesStaleNonce(t *testing.T) {
+	fake := &nonceRelayer{nonce: 3}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := relayer.NewClient(server.URL, chaindId, signature, testBuilderCreds)
+	assert.NoError(t, client.WithNonceManager(relayer.NewClientNonceManager(client)))
+
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	option := &sdktypes.AuthOption{SingerAddress: owner}
+	txn, err := createUsdcApproveTxn("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174", "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E")
+	assert.NoError(t, err)
+
+	_, err = client.Execute([]types.SafeTransaction{txn}, "approve", option)
+	assert.NoError(t, err)
+
+	// Another submitter used nonces 4 and 5.
+	fake.mu.Lock()
+	fake.nonce = 6
+	fake.mu.Unlock()
+	_, err = client.Execute([]types.SafeTransaction{txn}, "approve", option)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3", "6"}, fake.submitted)
+	assert.Equal(t, big.NewInt(7), client.NonceManager().Peek(owner))
+
+	// Other rejections are not retried, even when they mention the nonce.
+	fake.mu.Lock()
+	fake.failNext, fake.failBody = true, `{"error":"safe nonce too low for this signer type"}`
+	fake.mu.Unlock()
+	_, err = client.Execute([]types.SafeTransaction{txn}, "approve", option)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"3", "6"}, fake.submitted)
+	assert.Equal(t, 2, fake.fetches)
+}
+
+func TestNonceManagerBuildTx(t *testing.T) {
+	fake := &nonceRelayer{nonce: 3}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := relayer.NewClient(server.URL, chaindId, signature, testBuilderCreds)
+	assert.NoError(t, client.WithNonceManager(relayer.NewClientNonceManager(client)))
+
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	option := &sdktypes.AuthOption{SingerAddress: owner}
+	txn, err := createUsdcApproveTxn("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174", "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E")
+	assert.NoError(t, err)
+
+	// A dropped request releases its nonce.
+	req, err := client.BuildTx([]types.SafeTransaction{txn}, nil, "dropped", option)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", *req.Nonce)
+	client.Discard(req)
+
+	// Execute waits for the request built before it to be submitted.
+	req, err = client.BuildTx([]types.SafeTransaction{txn}, nil, "built", option)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", *req.Nonce)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := client.Execute([]types.SafeTransaction{txn}, "executed", option)
+		assert.NoError(t, err)
+	}()
+	_, err = client.ExecuteByTx(req, option)
+	assert.NoError(t, err)
+	<-done
+	assert.Equal(t, []string{"3", "4"}, fake.submitted)
+}
+
+func TestNonceManagerBuildTxHold(t *testing.T) {
+	fake := &nonceRelayer{nonce: 3}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := relayer.NewClient(server.URL, chaindId, signature, testBuilderCreds)
+	assert.NoError(t, client.WithNonceManager(relayer.NewClientNonceManager(client)))
+	assert.Error(t, client.WithBuildTxHold(0))
+	assert.NoError(t, client.WithBuildTxHold(50*time.Millisecond))
+
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	option := &sdktypes.AuthOption{SingerAddress: owner}
+	txn, err := createUsdcApproveTxn("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174", "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E")
+	assert.NoError(t, err)
+
+	// An abandoned request is discarded after the hold, so Execute goes on
+	// with its nonce.
+	req, err := client.BuildTx([]types.SafeTransaction{txn}, nil, "abandoned", option)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", *req.Nonce)
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Execute([]types.SafeTransaction{txn}, "executed", option)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Execute blocked by an abandoned BuildTx")
+	}
+	assert.Equal(t, []string{"3"}, fake.submitted)
+}
@@ -0,0 +1,48 @@
+package relayer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	"github.com/polymarket/go-order-utils/pkg/config"
+)
+
+// RedeemPositionsTx builds the Safe call redeeming the outcome tokens of a
+// resolved condition for USDC. Binary markets redeem both outcomes on
+// ConditionalTokens and ignore amounts; neg risk markets redeem amounts, one
+// per outcome, on the NegRiskAdapter.
+func RedeemPositionsTx(chainId *big.Int, conditionID common.Hash, negRisk bool, amounts []*big.Int) (types.SafeTransaction, error) {
+	contracts, err := config.GetContracts(chainId.Int64())
+	if err != nil {
+		return types.SafeTransaction{}, fmt.Errorf("redeem positions: %w", err)
+	}
+
+	if negRisk {
+		if len(amounts) == 0 {
+			return types.SafeTransaction{}, fmt.Errorf("redeem positions: amounts are required for neg risk markets")
+		}
+		adapterABI, err := ContractMetaData.GetAbi()
+		if err != nil {
+			return types.SafeTransaction{}, fmt.Errorf("redeem positions: neg risk adapter abi: %w", err)
+		}
+		data, err := adapterABI.Pack("redeemPositions", conditionID, amounts)
+		if err != nil {
+			return types.SafeTransaction{}, fmt.Errorf("redeem positions: pack: %w", err)
+		}
+		return types.SafeTransaction{To: contracts.NegRiskAdapter.Hex(), Operation: types.OperationCall, Data: hexutil.Encode(data), Value: "0"}, nil
+	}
+
+	ctfABI, err := ConditionalTokensMetaData.GetAbi()
+	if err != nil {
+		return types.SafeTransaction{}, fmt.Errorf("redeem positions: conditional tokens abi: %w", err)
+	}
+	indexSets := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	data, err := ctfABI.Pack("redeemPositions", contracts.Collateral, [32]byte{}, conditionID, indexSets)
+	if err != nil {
+		return types.SafeTransaction{}, fmt.Errorf("redeem positions: pack: %w", err)
+	}
+	return types.SafeTransaction{To: contracts.Conditional.Hex(), Operation: types.OperationCall, Data: hexutil.Encode(data), Value: "0"}, nil
+}
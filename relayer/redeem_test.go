@@ -0,0 +1,41 @@
+package relayer_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/override-coder/go-polymarket-sdk/relayer"
+	"github.com/polymarket/go-order-utils/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedeemPositionsTx(t *testing.T) {
+	contracts, err := config.GetContracts(chaindId.Int64())
+	assert.NoError(t, err)
+	decoder, err := relayer.NewDecoder(chaindId)
+	assert.NoError(t, err)
+	conditionID := common.HexToHash("0xee1091d8be46ae92f59d743694ff29e4fbd9d9b15151064ab4a4af7062aed6de")
+
+	txn, err := relayer.RedeemPositionsTx(chaindId, conditionID, false, nil)
+	assert.NoError(t, err)
+	call, err := decoder.DecodeCall(txn)
+	assert.NoError(t, err)
+	assert.Equal(t, relayer.ContractConditionalTokens, call.Contract)
+	assert.Equal(t, relayer.RedeemAction{
+		CollateralToken: contracts.Collateral,
+		ConditionID:     conditionID,
+		IndexSets:       []*big.Int{big.NewInt(1), big.NewInt(2)},
+	}, call.Action)
+
+	amounts := []*big.Int{big.NewInt(5_000_000), big.NewInt(2_000_000)}
+	txn, err = relayer.RedeemPositionsTx(chaindId, conditionID, true, amounts)
+	assert.NoError(t, err)
+	call, err = decoder.DecodeCall(txn)
+	assert.NoError(t, err)
+	assert.Equal(t, relayer.ContractNegRiskAdapter, call.Contract)
+	assert.Equal(t, relayer.NegRiskRedeemAction{ConditionID: conditionID, Amounts: amounts}, call.Action)
+
+	_, err = relayer.RedeemPositionsTx(chaindId, conditionID, true, nil)
+	assert.Error(t, err)
+}
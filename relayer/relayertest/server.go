@@ -0,0 +1,366 @@
+// Package relayertest provides Server, an in-process fake of the Polymarket
+// relayer for offline tests of relayer.Client.
+//
+// The fake checks builder auth headers and Safe signatures the way the relayer
+// does, tracks Safe deployments and nonces, and moves submitted transactions
+// through the relayer states on a clock the test advances. Nothing is executed
+// on chain.
+package relayertest
+
+import (
+	"crypto/hmac"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+)
+
+type Options struct {
+	// StateInterval is how long a transaction stays in STATE_NEW,
+	// STATE_EXECUTED and STATE_MINED, default 2s.
+	StateInterval time.Duration
+	// Fail makes matching transactions end in STATE_FAILED once executed.
+	Fail func(req *types.TransactionRequest) bool
+	// Start is the initial time of the clock, default 2024-01-01.
+	Start time.Time
+}
+
+type transaction struct {
+	types.RelayerTransaction
+	safe      common.Address
+	deploy    bool
+	fail      bool
+	submitted time.Time
+}
+
+var (
+	successStates = []types.RelayerTransactionState{types.RelayerStateNew, types.RelayerStateExecuted, types.RelayerStateMined, types.RelayerStateConfirmed}
+	failStates    = []types.RelayerTransactionState{types.RelayerStateNew, types.RelayerStateExecuted, types.RelayerStateFailed}
+)
+
+// Server is a fake relayer listening on URL.
+type Server struct {
+	URL string
+
+	server  *httptest.Server
+	chainId *big.Int
+	factory string
+	opts    Options
+
+	mu       sync.Mutex
+	now      time.Time
+	builders map[string]sdktypes.BuilderApiKeyCreds
+	deployed map[common.Address]bool
+	nonces   map[common.Address]uint64
+	txs      []*transaction
+}
+
+func NewServer(chainId *big.Int, opts Options) *Server {
+	if opts.StateInterval <= 0 {
+		opts.StateInterval = 2 * time.Second
+	}
+	if opts.Start.IsZero() {
+		opts.Start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	s := &Server{
+		chainId:  chainId,
+		factory:  types.GetContractConfig(chainId).SafeFactory,
+		opts:     opts,
+		now:      opts.Start,
+		builders: make(map[string]sdktypes.BuilderApiKeyCreds),
+		deployed: make(map[common.Address]bool),
+		nonces:   make(map[common.Address]uint64),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+types.GET_NONCE, s.handleNonce)
+	mux.HandleFunc("GET "+types.GET_DEPLOYED, s.handleDeployed)
+	mux.HandleFunc("GET "+types.GET_TRANSACTION, s.handleTransaction)
+	mux.HandleFunc("GET "+types.GET_TRANSACTIONS, s.handleTransactions)
+	mux.HandleFunc("POST "+types.SUBMIT_TRANSACTION, s.handleSubmit)
+
+	s.server = httptest.NewServer(mux)
+	s.URL = s.server.URL
+	return s
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddBuilderKey accepts submissions signed with creds.
+func (s *Server) AddBuilderKey(creds sdktypes.BuilderApiKeyCreds) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.builders[creds.Key] = creds
+}
+
+// Now returns the time of the fake clock.
+func (s *Server) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.now
+}
+
+// Advance moves the clock forward, progressing pending transactions.
+func (s *Server) Advance(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = s.now.Add(d)
+	s.progress()
+}
+
+// DeploySafe marks the Safe of owner as deployed without a SAFE-CREATE.
+func (s *Server) DeploySafe(owner string) error {
+	safe, err := s.safe(owner)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deployed[safe] = true
+	return nil
+}
+
+// SetNonce sets the next nonce of the Safe of owner.
+func (s *Server) SetNonce(owner string, nonce uint64) error {
+	safe, err := s.safe(owner)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nonces[safe] = nonce
+	return nil
+}
+
+// Transactions returns every submitted transaction in submission order.
+func (s *Server) Transactions() []types.RelayerTransaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]types.RelayerTransaction, 0, len(s.txs))
+	for _, tx := range s.txs {
+		out = append(out, tx.RelayerTransaction)
+	}
+	return out
+}
+
+func (s *Server) safe(owner string) (common.Address, error) {
+	safe, err := types.DeriveSafeAddress(owner, s.factory)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.HexToAddress(safe), nil
+}
+
+// progress updates the state of every transaction to the clock. A deployment
+// takes effect once mined, i.e. from the third state on.
+func (s *Server) progress() {
+	for _, tx := range s.txs {
+		states := successStates
+		if tx.fail {
+			states = failStates
+		}
+		step := int(s.now.Sub(tx.submitted) / s.opts.StateInterval)
+		if step >= len(states) {
+			step = len(states) - 1
+		}
+		if states[step] == tx.State {
+			continue
+		}
+		tx.State = states[step]
+		tx.UpdatedAt = tx.submitted.Add(time.Duration(step) * s.opts.StateInterval)
+		if tx.deploy && !tx.fail && step >= 2 {
+			s.deployed[tx.safe] = true
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func (s *Server) handleNonce(w http.ResponseWriter, r *http.Request) {
+	if t := r.URL.Query().Get("type"); t != string(types.TransactionTypeSAFE) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unsupported type %q", t))
+		return
+	}
+	safe, err := s.safe(r.URL.Query().Get("address"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, types.NoncePayload{Nonce: strconv.FormatUint(s.nonces[safe], 10)})
+}
+
+func (s *Server) handleDeployed(w http.ResponseWriter, r *http.Request) {
+	address := r.URL.Query().Get("address")
+	if !common.IsHexAddress(address) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid address %q", address))
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.progress()
+	writeJSON(w, http.StatusOK, types.GetDeployedResponse{Deployed: s.deployed[common.HexToAddress(address)]})
+}
+
+func (s *Server) handleTransaction(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.progress()
+	out := []types.RelayerTransaction{}
+	for _, tx := range s.txs {
+		if tx.TransactionID == r.URL.Query().Get("id") {
+			out = append(out, tx.RelayerTransaction)
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Header.Get("POLY_BUILDER_API_KEY") != "" {
+		if err := s.authBuilder(r, ""); err != nil {
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+	}
+	s.progress()
+	out := make([]types.RelayerTransaction, 0, len(s.txs))
+	for _, tx := range s.txs {
+		out = append(out, tx.RelayerTransaction)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// authBuilder checks the headers built by headers.CreateL2BuilderHeaders.
+func (s *Server) authBuilder(r *http.Request, body string) error {
+	creds, ok := s.builders[r.Header.Get("POLY_BUILDER_API_KEY")]
+	if !ok {
+		return fmt.Errorf("invalid builder api key")
+	}
+	if r.Header.Get("POLY_BUILDER_PASSPHRASE") != creds.Passphrase {
+		return fmt.Errorf("invalid builder passphrase")
+	}
+	expected, err := signing.BuildPolyHmacSignature(creds.Secret, r.Header.Get("POLY_BUILDER_TIMESTAMP"), r.Method, r.URL.Path, &body)
+	if err != nil || !hmac.Equal([]byte(expected), []byte(r.Header.Get("POLY_BUILDER_SIGNATURE"))) {
+		return fmt.Errorf("invalid builder signature")
+	}
+	return nil
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.authBuilder(r, string(body)); err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	var req types.TransactionRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	s.progress()
+
+	tx, err := s.submit(&req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.txs = append(s.txs, tx)
+	writeJSON(w, http.StatusOK, types.RelayerTransactionResponse{
+		TransactionID:   tx.TransactionID,
+		State:           string(tx.State),
+		Hash:            tx.TransactionHash,
+		TransactionHash: tx.TransactionHash,
+	})
+}
+
+// submit validates req and returns the transaction to queue.
+func (s *Server) submit(req *types.TransactionRequest) (*transaction, error) {
+	tx := &transaction{submitted: s.now}
+	switch types.TransactionType(req.Type) {
+	case types.TransactionTypeSAFECreate:
+		if _, err := signing.VerifySafeCreateRequest(req, s.chainId); err != nil {
+			return nil, err
+		}
+		safe, err := s.safe(req.From)
+		if err != nil {
+			return nil, err
+		}
+		if req.ProxyWallet != nil && common.HexToAddress(*req.ProxyWallet) != safe {
+			return nil, fmt.Errorf("proxyWallet %s is not the safe %s of %s", *req.ProxyWallet, safe.Hex(), req.From)
+		}
+		if s.deployed[safe] {
+			return nil, fmt.Errorf("safe %s is already deployed", safe.Hex())
+		}
+		for _, pending := range s.txs {
+			if pending.deploy && pending.safe == safe && !pending.State.IsFailure() {
+				return nil, fmt.Errorf("safe %s is already being deployed", safe.Hex())
+			}
+		}
+		tx.safe, tx.deploy = safe, true
+	case types.TransactionTypeSAFE:
+		if _, err := signing.VerifySafeRequest(req, s.chainId); err != nil {
+			return nil, err
+		}
+		safe := common.HexToAddress(*req.ProxyWallet)
+		if !s.deployed[safe] {
+			return nil, fmt.Errorf("safe %s is not deployed", safe.Hex())
+		}
+		nonce, err := strconv.ParseUint(*req.Nonce, 10, 64)
+		if err != nil || nonce != s.nonces[safe] {
+			return nil, fmt.Errorf("invalid nonce %s, expected %d", *req.Nonce, s.nonces[safe])
+		}
+		s.nonces[safe]++
+		tx.safe = safe
+		tx.Nonce = *req.Nonce
+	default:
+		return nil, fmt.Errorf("unsupported type %q", req.Type)
+	}
+
+	id := crypto.Keccak256([]byte("relayertest"), big.NewInt(int64(len(s.txs))).Bytes())
+	tx.TransactionID = fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+	tx.TransactionHash = crypto.Keccak256Hash(id).Hex()
+	tx.From = req.From
+	tx.To = req.To
+	tx.ProxyAddress = tx.safe.Hex()
+	tx.Data = req.Data
+	tx.Value = "0"
+	tx.State = types.RelayerStateNew
+	tx.Type = req.Type
+	if req.Metadata != nil {
+		tx.Metadata = *req.Metadata
+	}
+	tx.CreatedAt, tx.UpdatedAt = s.now, s.now
+	tx.fail = s.opts.Fail != nil && s.opts.Fail(req)
+	return tx, nil
+}
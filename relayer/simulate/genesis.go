@@ -0,0 +1,117 @@
+package simulate
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/override-coder/go-polymarket-sdk/relayer"
+	"github.com/polymarket/go-order-utils/pkg/config"
+)
+
+// deployGenesis runs the creation transactions of the generated
+// DeployConditionalTokens and DeployContract (NegRiskAdapter) bindings at the
+// production addresses on top of alloc, with deployer as the sender, and adds
+// every account they touch to alloc. Contracts the constructors create, like
+// the adapter's WrappedCollateral, land at their production addresses too.
+func deployGenesis(alloc ethtypes.GenesisAlloc, deployer common.Address, contracts *config.Contracts) error {
+	statedb, err := state.New(ethtypes.EmptyRootHash, state.NewDatabaseForTesting())
+	if err != nil {
+		return fmt.Errorf("genesis state: %w", err)
+	}
+	for addr, account := range alloc {
+		statedb.SetCode(addr, account.Code)
+		statedb.SetNonce(addr, account.Nonce)
+		if account.Balance != nil {
+			statedb.SetBalance(addr, uint256.MustFromBig(account.Balance), tracing.BalanceChangeUnspecified)
+		}
+		for key, value := range account.Storage {
+			statedb.SetState(addr, key, value)
+		}
+	}
+
+	touched := make(map[common.Address]map[common.Hash]bool)
+	touch := func(addr common.Address) map[common.Hash]bool {
+		if touched[addr] == nil {
+			touched[addr] = make(map[common.Hash]bool)
+		}
+		return touched[addr]
+	}
+	hooked := state.NewHookedState(statedb, &tracing.Hooks{
+		OnNonceChange: func(addr common.Address, _, _ uint64) { touch(addr) },
+		OnCodeChange: func(addr common.Address, _ common.Hash, _ []byte, _ common.Hash, _ []byte) {
+			touch(addr)
+		},
+		OnStorageChange: func(addr common.Address, slot common.Hash, _, _ common.Hash) { touch(addr)[slot] = true },
+	})
+	evm := vm.NewEVM(vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		GetHash:     func(uint64) common.Hash { return common.Hash{} },
+		BlockNumber: new(big.Int),
+		GasLimit:    gasLimit,
+		Difficulty:  new(big.Int),
+		BaseFee:     new(big.Int),
+		BlobBaseFee: new(big.Int),
+		Random:      &common.Hash{},
+	}, vm.TxContext{Origin: deployer, GasPrice: new(big.Int)}, hooked, params.AllDevChainProtocolChanges, vm.Config{})
+
+	// The bindings only build the creation transactions, which run at addr
+	// instead of the deployer's CREATE address.
+	opts := &bind.TransactOpts{
+		From:     deployer,
+		Nonce:    new(big.Int),
+		GasPrice: new(big.Int),
+		GasLimit: gasLimit,
+		NoSend:   true,
+		Signer: func(_ common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			return tx, nil
+		},
+	}
+	deploy := func(addr common.Address, tx *ethtypes.Transaction, err error) error {
+		if err != nil {
+			return err
+		}
+		// A contract account starts at nonce 1 (EIP-161), which places the
+		// contracts its constructor creates.
+		hooked.SetCode(addr, tx.Data())
+		hooked.SetNonce(addr, 1)
+		code, _, err := evm.Call(vm.AccountRef(deployer), addr, nil, gasLimit, new(uint256.Int))
+		if err != nil {
+			return err
+		}
+		hooked.SetCode(addr, code)
+		return nil
+	}
+	_, tx, _, err := relayer.DeployConditionalTokens(opts, nil)
+	if err := deploy(contracts.Conditional, tx, err); err != nil {
+		return fmt.Errorf("deploy conditional tokens failed: %w", err)
+	}
+	_, tx, _, err = relayer.DeployContract(opts, nil, contracts.Conditional, contracts.Collateral, deployer)
+	if err := deploy(contracts.NegRiskAdapter, tx, err); err != nil {
+		return fmt.Errorf("deploy neg risk adapter failed: %w", err)
+	}
+
+	for addr, slots := range touched {
+		account := alloc[addr]
+		account.Code = statedb.GetCode(addr)
+		account.Nonce = statedb.GetNonce(addr)
+		account.Balance = statedb.GetBalance(addr).ToBig()
+		if account.Storage == nil && len(slots) > 0 {
+			account.Storage = make(map[common.Hash]common.Hash)
+		}
+		for slot := range slots {
+			account.Storage[slot] = statedb.GetState(addr, slot)
+		}
+		alloc[addr] = account
+	}
+	return nil
+}
@@ -0,0 +1,513 @@
+// Package simulate replays relayer Safe transactions against an in-process
+// go-ethereum simulated backend, so a batch can be checked before it is
+// submitted with relayer.Client.Execute.
+//
+// ConditionalTokens and the NegRiskAdapter are deployed from their generated
+// bindings at their production addresses, so replayed requests run unchanged.
+// The Safe, MultiSend and collateral token are stand-ins (see standins.go).
+// Exchange contracts are not deployed.
+package simulate
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/override-coder/go-polymarket-sdk/relayer"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	"github.com/polymarket/go-order-utils/pkg/config"
+)
+
+const gasLimit = 10_000_000
+
+const collateralAbi = `[
+  {"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"mint","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+  {"inputs":[{"name":"account","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+const safeStandInAbi = `[
+  {"inputs":[{"name":"owner","type":"address"},{"name":"_threshold","type":"uint256"}],"name":"addOwnerWithThreshold","outputs":[],"stateMutability":"nonpayable","type":"function"},
+  {"inputs":[{"name":"_dataHash","type":"bytes32"},{"name":"_signature","type":"bytes"}],"name":"isValidSignature","outputs":[{"name":"","type":"bytes4"}],"stateMutability":"view","type":"function"}
+]`
+
+// Result is the outcome of replaying one TransactionRequest.
+type Result struct {
+	Success      bool
+	RevertReason string
+	RevertData   []byte
+	GasUsed      uint64
+	// Decoded is the request calldata decoded with relayer.Decoder.
+	Decoded *relayer.DecodedTransaction
+	// Events holds the decoded CTF/adapter events, see relayer.Decoder.DecodeLog.
+	Events []interface{}
+	// Deltas are the Safe's collateral and position balance changes.
+	Deltas []BalanceDelta
+}
+
+// BalanceDelta is a balance change of the Safe. TokenID is nil for the
+// collateral ERC20 and the ERC1155 position ID for ConditionalTokens.
+type BalanceDelta struct {
+	Token   common.Address
+	TokenID *big.Int
+	Delta   *big.Int
+}
+
+// Simulator owns a simulated chain with the Polymarket contracts in place.
+// Simulate commits every successful replay, so later replays see its effects.
+type Simulator struct {
+	chainId   *big.Int
+	backend   *simulated.Backend
+	client    simulated.Client
+	key       *ecdsa.PrivateKey
+	auth      *bind.TransactOpts
+	contracts *config.Contracts
+	multiSend common.Address
+	safes     map[common.Address]bool
+
+	ctf            *relayer.ConditionalTokens
+	adapter        *relayer.Contract
+	collateral     *bind.BoundContract
+	safeABI        *abi.ABI
+	safeStandInABI abi.ABI
+	decoder        *relayer.Decoder
+}
+
+// New starts a simulated chain for chainId with a stand-in Safe for each
+// owner, at the address relayer.Client.GetExpectedSafe derives. Each Safe has
+// its owner and a threshold of 1.
+func New(chainId *big.Int, owners ...string) (*Simulator, error) {
+	contracts, err := config.GetContracts(chainId.Int64())
+	if err != nil {
+		return nil, fmt.Errorf("new simulator: %w", err)
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("new simulator: generate key failed: %w", err)
+	}
+
+	contractConfig := types.GetContractConfig(chainId)
+	s := &Simulator{
+		chainId:   chainId,
+		key:       key,
+		contracts: contracts,
+		multiSend: common.HexToAddress(contractConfig.SafeMultisend),
+		safes:     make(map[common.Address]bool),
+	}
+
+	deployer := crypto.PubkeyToAddress(key.PublicKey)
+	alloc := ethtypes.GenesisAlloc{
+		deployer:             {Balance: new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(1e18))},
+		s.multiSend:          {Code: multiSendCode, Balance: big.NewInt(0)},
+		contracts.Collateral: {Code: collateralCode, Balance: big.NewInt(0)},
+	}
+	for _, owner := range owners {
+		if !common.IsHexAddress(owner) {
+			return nil, fmt.Errorf("new simulator: invalid owner address: %s", owner)
+		}
+		safe, err := types.DeriveSafeAddress(owner, contractConfig.SafeFactory)
+		if err != nil {
+			return nil, fmt.Errorf("new simulator: %w", err)
+		}
+		addr := common.HexToAddress(safe)
+		s.safes[addr] = true
+		alloc[addr] = ethtypes.Account{
+			Code: safeCode,
+			Storage: map[common.Hash]common.Hash{
+				common.BigToHash(big.NewInt(1)):                        common.BigToHash(big.NewInt(1)),
+				common.BigToHash(big.NewInt(2)):                        common.BigToHash(chainId),
+				common.BigToHash(big.NewInt(3)):                        common.BytesToHash(deployer.Bytes()),
+				common.BytesToHash(common.HexToAddress(owner).Bytes()): common.BigToHash(big.NewInt(1)),
+			},
+			Balance: big.NewInt(0),
+		}
+	}
+	if err := deployGenesis(alloc, deployer, contracts); err != nil {
+		return nil, fmt.Errorf("new simulator: %w", err)
+	}
+
+	s.backend = simulated.NewBackend(alloc, simulated.WithBlockGasLimit(30_000_000))
+	s.client = s.backend.Client()
+
+	if err := s.deploy(); err != nil {
+		_ = s.backend.Close()
+		return nil, fmt.Errorf("new simulator: %w", err)
+	}
+	return s, nil
+}
+
+func (s *Simulator) deploy() error {
+	ctx := context.Background()
+	simChainId, err := s.client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("chain id failed: %w", err)
+	}
+	if s.auth, err = bind.NewKeyedTransactorWithChainID(s.key, simChainId); err != nil {
+		return fmt.Errorf("transactor failed: %w", err)
+	}
+
+	if s.ctf, err = relayer.NewConditionalTokens(s.contracts.Conditional, s.client); err != nil {
+		return fmt.Errorf("bind conditional tokens failed: %w", err)
+	}
+	if s.adapter, err = relayer.NewContract(s.contracts.NegRiskAdapter, s.client); err != nil {
+		return fmt.Errorf("bind neg risk adapter failed: %w", err)
+	}
+
+	collateralABI, err := abi.JSON(strings.NewReader(collateralAbi))
+	if err != nil {
+		return fmt.Errorf("collateral abi: %w", err)
+	}
+	s.collateral = bind.NewBoundContract(s.contracts.Collateral, collateralABI, s.client, s.client, s.client)
+	if s.safeABI, err = types.ContractMetaData.GetAbi(); err != nil {
+		return fmt.Errorf("safe abi: %w", err)
+	}
+	if s.safeStandInABI, err = abi.JSON(strings.NewReader(safeStandInAbi)); err != nil {
+		return fmt.Errorf("safe stand-in abi: %w", err)
+	}
+	if s.decoder, err = relayer.NewDecoder(s.chainId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Simulator) Close() error {
+	return s.backend.Close()
+}
+
+func (s *Simulator) Backend() *simulated.Backend {
+	return s.backend
+}
+
+// TransactOpts returns the funded deployer account. It is the oracle for
+// PrepareCondition and an admin of the NegRiskAdapter.
+func (s *Simulator) TransactOpts() *bind.TransactOpts {
+	return s.auth
+}
+
+func (s *Simulator) ConditionalTokens() (common.Address, *relayer.ConditionalTokens) {
+	return s.contracts.Conditional, s.ctf
+}
+
+func (s *Simulator) NegRiskAdapter() (common.Address, *relayer.Contract) {
+	return s.contracts.NegRiskAdapter, s.adapter
+}
+
+// FundGas sends amount wei from the deployer, e.g. to a DirectExecutor gas key.
+func (s *Simulator) FundGas(ctx context.Context, to string, amount *big.Int) error {
+	nonce, err := s.client.PendingNonceAt(ctx, s.auth.From)
+	if err != nil {
+		return fmt.Errorf("fund gas failed: %w", err)
+	}
+	gasPrice, err := s.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("fund gas failed: %w", err)
+	}
+	recipient := common.HexToAddress(to)
+	tx, err := s.auth.Signer(s.auth.From, ethtypes.NewTx(&ethtypes.LegacyTx{Nonce: nonce, To: &recipient, Value: amount, Gas: 21_000, GasPrice: gasPrice}))
+	if err != nil {
+		return fmt.Errorf("fund gas failed: %w", err)
+	}
+	if err = s.client.SendTransaction(ctx, tx); err != nil {
+		return fmt.Errorf("fund gas failed: %w", err)
+	}
+	_, err = s.mined(ctx, tx)
+	return err
+}
+
+// MintCollateral credits amount (6 decimals) of the collateral stand-in to holder.
+func (s *Simulator) MintCollateral(ctx context.Context, holder string, amount *big.Int) error {
+	tx, err := s.collateral.Transact(s.auth, "mint", common.HexToAddress(holder), amount)
+	if err != nil {
+		return fmt.Errorf("mint collateral failed: %w", err)
+	}
+	_, err = s.mined(ctx, tx)
+	return err
+}
+
+func (s *Simulator) CollateralBalance(ctx context.Context, holder string) (*big.Int, error) {
+	var out []interface{}
+	if err := s.collateral.Call(&bind.CallOpts{Context: ctx}, &out, "balanceOf", common.HexToAddress(holder)); err != nil {
+		return nil, fmt.Errorf("collateral balance failed: %w", err)
+	}
+	return out[0].(*big.Int), nil
+}
+
+// PrepareCondition prepares a condition with the deployer as oracle and returns its ID.
+func (s *Simulator) PrepareCondition(ctx context.Context, questionID common.Hash, outcomeSlotCount int64) (common.Hash, error) {
+	tx, err := s.ctf.PrepareCondition(s.auth, s.auth.From, questionID, big.NewInt(outcomeSlotCount))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("prepare condition failed: %w", err)
+	}
+	if _, err = s.mined(ctx, tx); err != nil {
+		return common.Hash{}, fmt.Errorf("prepare condition failed: %w", err)
+	}
+	conditionID, err := s.ctf.GetConditionId(&bind.CallOpts{Context: ctx}, s.auth.From, questionID, big.NewInt(outcomeSlotCount))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("get condition id failed: %w", err)
+	}
+	return conditionID, nil
+}
+
+// ReportPayouts resolves a condition prepared with PrepareCondition.
+func (s *Simulator) ReportPayouts(ctx context.Context, questionID common.Hash, payouts []*big.Int) error {
+	tx, err := s.ctf.ReportPayouts(s.auth, questionID, payouts)
+	if err != nil {
+		return fmt.Errorf("report payouts failed: %w", err)
+	}
+	_, err = s.mined(ctx, tx)
+	return err
+}
+
+// AddSafeOwners registers more owners of a stand-in Safe and sets its
+// threshold, which Simulate and IsValidSignature then require.
+func (s *Simulator) AddSafeOwners(ctx context.Context, safe string, threshold int64, owners ...string) error {
+	contract := bind.NewBoundContract(common.HexToAddress(safe), s.safeStandInABI, s.client, s.client, s.client)
+	for _, owner := range owners {
+		tx, err := contract.Transact(s.auth, "addOwnerWithThreshold", common.HexToAddress(owner), big.NewInt(threshold))
+		if err != nil {
+			return fmt.Errorf("add safe owner failed: %w", err)
+		}
+		if _, err = s.mined(ctx, tx); err != nil {
+			return fmt.Errorf("add safe owner failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// IsValidSignature calls the EIP-1271 isValidSignature(bytes32, bytes) of a
+// stand-in Safe, e.g. with the order hash and signature of a POLY_1271 order.
+// A rejected signature returns false and the Safe's revert reason (GS020 too
+// few signatures, GS026 invalid or unsorted owner).
+func (s *Simulator) IsValidSignature(ctx context.Context, safe string, hash common.Hash, signature []byte) (bool, error) {
+	if !s.safes[common.HexToAddress(safe)] {
+		return false, fmt.Errorf("is valid signature: unknown safe %s", safe)
+	}
+	input, err := s.safeStandInABI.Pack("isValidSignature", hash, signature)
+	if err != nil {
+		return false, fmt.Errorf("is valid signature: %w", err)
+	}
+	to := common.HexToAddress(safe)
+	out, err := s.client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: input}, nil)
+	if err != nil {
+		_, reason := revertReason(err)
+		return false, fmt.Errorf("is valid signature: %s", reason)
+	}
+	magic := crypto.Keccak256([]byte("isValidSignature(bytes32,bytes)"))[:4]
+	return len(out) >= 4 && bytes.Equal(out[:4], magic), nil
+}
+
+// Simulate replays a SAFE TransactionRequest, as produced by relayer.Client.BuildTx.
+// The request nonce is checked against the Safe like the relayer does, and the
+// Safe checks the owner signatures (GS026, GS020). A revert is reported in the
+// Result, not as an error.
+func (s *Simulator) Simulate(ctx context.Context, req *types.TransactionRequest) (*Result, error) {
+	if req.Type != string(types.TransactionTypeSAFE) {
+		return nil, fmt.Errorf("simulate: unsupported transaction type %q", req.Type)
+	}
+	if req.ProxyWallet == nil || req.Nonce == nil {
+		return nil, fmt.Errorf("simulate: request has no proxy wallet or nonce")
+	}
+	safe := common.HexToAddress(*req.ProxyWallet)
+	if !s.safes[safe] {
+		return nil, fmt.Errorf("simulate: safe %s is not deployed in the simulation", safe.Hex())
+	}
+
+	decoded, err := s.decoder.DecodeTransaction(req.To, req.Data)
+	if err != nil {
+		return nil, fmt.Errorf("simulate: %w", err)
+	}
+	result := &Result{Decoded: decoded}
+
+	args, err := s.execArgs(ctx, req)
+	if err != nil {
+		result.RevertReason = err.Error()
+		return result, nil
+	}
+
+	calldata, err := s.safeABI.Pack("execTransaction", args...)
+	if err != nil {
+		return nil, fmt.Errorf("simulate: pack execTransaction failed: %w", err)
+	}
+	_, err = s.client.CallContract(ctx, ethereum.CallMsg{From: s.auth.From, To: &safe, Gas: gasLimit, Data: calldata}, nil)
+	if err != nil {
+		result.RevertData, result.RevertReason = revertReason(err)
+		return result, nil
+	}
+
+	before, err := s.CollateralBalance(ctx, safe.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("simulate: %w", err)
+	}
+
+	safeContract := bind.NewBoundContract(safe, *s.safeABI, s.client, s.client, s.client)
+	opts := *s.auth
+	opts.Context = ctx
+	opts.GasLimit = gasLimit
+	tx, err := safeContract.Transact(&opts, "execTransaction", args...)
+	if err != nil {
+		return nil, fmt.Errorf("simulate: send execTransaction failed: %w", err)
+	}
+	receipt, err := s.mined(ctx, tx)
+	if receipt == nil {
+		return nil, fmt.Errorf("simulate: %w", err)
+	}
+	result.GasUsed = receipt.GasUsed
+	if err != nil {
+		result.RevertReason = err.Error()
+		return result, nil
+	}
+	result.Success = true
+
+	if result.Events, err = s.decoder.DecodeLogs(receipt.Logs); err != nil {
+		return nil, fmt.Errorf("simulate: %w", err)
+	}
+	after, err := s.CollateralBalance(ctx, safe.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("simulate: %w", err)
+	}
+	result.Deltas = s.deltas(safe, new(big.Int).Sub(after, before), receipt.Logs)
+	return result, nil
+}
+
+// SafeNonce returns the on-chain nonce of a stand-in Safe.
+func (s *Simulator) SafeNonce(ctx context.Context, safe string) (*big.Int, error) {
+	safeContract, err := types.NewContract(common.HexToAddress(safe), s.client)
+	if err != nil {
+		return nil, err
+	}
+	return safeContract.Nonce(&bind.CallOpts{Context: ctx})
+}
+
+// execArgs checks the request nonce against the Safe and returns the
+// execTransaction arguments of the request.
+func (s *Simulator) execArgs(ctx context.Context, req *types.TransactionRequest) ([]interface{}, error) {
+	nonce, err := s.SafeNonce(ctx, *req.ProxyWallet)
+	if err != nil {
+		return nil, fmt.Errorf("safe nonce failed: %w", err)
+	}
+	if nonce.String() != *req.Nonce {
+		return nil, fmt.Errorf("GS026: request nonce %s does not match safe nonce %s", *req.Nonce, nonce)
+	}
+
+	params := req.SignatureParams
+	if params.Operation == nil || params.SafeTxnGas == nil || params.BaseGas == nil || params.GasPrice == nil ||
+		params.GasToken == nil || params.RefundReceiver == nil {
+		return nil, fmt.Errorf("GS026: missing signature params")
+	}
+	var operation types.OperationType
+	switch *params.Operation {
+	case "0":
+		operation = types.OperationCall
+	case "1":
+		operation = types.OperationDelegateCall
+	default:
+		return nil, fmt.Errorf("GS026: invalid operation %q", *params.Operation)
+	}
+	data, err := hexutil.Decode(req.Data)
+	if err != nil {
+		return nil, fmt.Errorf("GS026: invalid data: %w", err)
+	}
+	signature, err := hexutil.Decode(req.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("GS026: invalid signature %q", req.Signature)
+	}
+	var gas [3]*big.Int
+	for i, value := range []string{*params.SafeTxnGas, *params.BaseGas, *params.GasPrice} {
+		var ok bool
+		if gas[i], ok = new(big.Int).SetString(value, 10); !ok {
+			return nil, fmt.Errorf("GS026: invalid signature params")
+		}
+	}
+	return []interface{}{common.HexToAddress(req.To), big.NewInt(0), data, uint8(operation), gas[0], gas[1], gas[2],
+		common.HexToAddress(*params.GasToken), common.HexToAddress(*params.RefundReceiver), signature}, nil
+}
+
+func (s *Simulator) deltas(safe common.Address, collateral *big.Int, logs []*ethtypes.Log) []BalanceDelta {
+	var out []BalanceDelta
+	if collateral.Sign() != 0 {
+		out = append(out, BalanceDelta{Token: s.contracts.Collateral, Delta: collateral})
+	}
+
+	positions := make(map[string]*big.Int)
+	add := func(id, amount *big.Int, sign int64) {
+		key := id.String()
+		if positions[key] == nil {
+			positions[key] = new(big.Int)
+		}
+		positions[key].Add(positions[key], new(big.Int).Mul(amount, big.NewInt(sign)))
+	}
+	apply := func(from, to common.Address, ids, values []*big.Int) {
+		for i := range ids {
+			if from == safe {
+				add(ids[i], values[i], -1)
+			}
+			if to == safe {
+				add(ids[i], values[i], 1)
+			}
+		}
+	}
+	for _, log := range logs {
+		if log.Address != s.contracts.Conditional {
+			continue
+		}
+		if ev, err := s.ctf.ParseTransferSingle(*log); err == nil {
+			apply(ev.From, ev.To, []*big.Int{ev.Id}, []*big.Int{ev.Value})
+		} else if ev, err := s.ctf.ParseTransferBatch(*log); err == nil {
+			apply(ev.From, ev.To, ev.Ids, ev.Values)
+		}
+	}
+
+	var ids []*big.Int
+	for key, delta := range positions {
+		if delta.Sign() != 0 {
+			id, _ := new(big.Int).SetString(key, 10)
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Cmp(ids[j]) < 0 })
+	for _, id := range ids {
+		out = append(out, BalanceDelta{Token: s.contracts.Conditional, TokenID: id, Delta: positions[id.String()]})
+	}
+	return out
+}
+
+// mined commits a block and returns the receipt, with an error if it reverted.
+func (s *Simulator) mined(ctx context.Context, tx *ethtypes.Transaction) (*ethtypes.Receipt, error) {
+	s.backend.Commit()
+	receipt, err := s.client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("receipt for %s failed: %w", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("transaction %s reverted", tx.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+func revertReason(err error) ([]byte, string) {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if hexData, ok := dataErr.ErrorData().(string); ok {
+			if data, decodeErr := hexutil.Decode(hexData); decodeErr == nil {
+				if reason, unpackErr := abi.UnpackRevert(data); unpackErr == nil {
+					return data, reason
+				}
+				return data, err.Error()
+			}
+		}
+	}
+	return nil, err.Error()
+}
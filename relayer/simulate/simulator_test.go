@@ -0,0 +1,291 @@
+package simulate_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/override-coder/go-polymarket-sdk/clob"
+	clobtypes "github.com/override-coder/go-polymarket-sdk/clob/types"
+	"github.com/override-coder/go-polymarket-sdk/relayer"
+	"github.com/override-coder/go-polymarket-sdk/relayer/simulate"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/polymarket/go-order-utils/pkg/builder"
+	"github.com/polymarket/go-order-utils/pkg/config"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	chainId       = big.NewInt(137)
+	privateKey, _ = crypto.ToECDSA(common.Hex2Bytes("3f26dbcf904a3542e5f54eed3381c740d6246d8bc81cefbfd0679ae4ce8d82c9"))
+)
+
+func signature(signer string, digest []byte) ([]byte, error) {
+	sig, err := crypto.Sign(digest, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// newClient returns a relayer client backed by a local relayer that reports
+// every Safe as deployed with nonce 0, so BuildTx runs offline.
+func newClient(t *testing.T) *relayer.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case types.GET_NONCE:
+			_ = json.NewEncoder(w).Encode(types.NoncePayload{Nonce: "0"})
+		case types.GET_DEPLOYED:
+			_ = json.NewEncoder(w).Encode(types.GetDeployedResponse{Deployed: true})
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return relayer.NewClient(server.URL, chainId, signature, &sdktypes.BuilderApiKeyCreds{
+		Key:        "test-key",
+		Secret:     "c2VjcmV0LXNlY3JldC1zZWNyZXQtc2VjcmV0LXNlY3I=",
+		Passphrase: "test-passphrase",
+	})
+}
+
+func TestSimulateSplitAndRedeem(t *testing.T) {
+	ctx := context.Background()
+	contracts, _ := config.GetContracts(chainId.Int64())
+	client := newClient(t)
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	safe, err := client.GetExpectedSafe(owner)
+	assert.NoError(t, err)
+
+	sim, err := simulate.New(chainId, owner)
+	assert.NoError(t, err)
+	defer sim.Close()
+
+	questionID := common.HexToHash("0x1234")
+	conditionID, err := sim.PrepareCondition(ctx, questionID, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, sim.MintCollateral(ctx, safe, big.NewInt(10_000_000)))
+
+	ctfABI, _ := relayer.ConditionalTokensMetaData.GetAbi()
+	approveData, err := ctfABI.Pack("setApprovalForAll", contracts.NegRiskAdapter, true)
+	assert.NoError(t, err)
+	splitData, err := ctfABI.Pack("splitPosition", contracts.Collateral, [32]byte{}, conditionID, []*big.Int{big.NewInt(1), big.NewInt(2)}, big.NewInt(4_000_000))
+	assert.NoError(t, err)
+	txns := []types.SafeTransaction{
+		approveCollateral(t, contracts.Conditional, 4_000_000),
+		{To: contracts.Conditional.Hex(), Data: hexutil.Encode(approveData), Value: "0"},
+		{To: contracts.Conditional.Hex(), Data: hexutil.Encode(splitData), Value: "0"},
+	}
+
+	req, err := client.BuildTx(txns, nil, "split", &sdktypes.AuthOption{SingerAddress: owner})
+	assert.NoError(t, err)
+	res, err := sim.Simulate(ctx, req)
+	assert.NoError(t, err)
+	assert.True(t, res.Success, res.RevertReason)
+	assert.True(t, res.Decoded.MultiSend)
+	assert.Len(t, res.Events, 1)
+	_, ok := res.Events[0].(*relayer.ConditionalTokensPositionSplit)
+	assert.True(t, ok)
+	assert.Len(t, res.Deltas, 3)
+	assert.Equal(t, contracts.Collateral, res.Deltas[0].Token)
+	assert.Equal(t, int64(-4_000_000), res.Deltas[0].Delta.Int64())
+	for _, delta := range res.Deltas[1:] {
+		assert.Equal(t, contracts.Conditional, delta.Token)
+		assert.Equal(t, int64(4_000_000), delta.Delta.Int64())
+	}
+
+	assert.NoError(t, sim.ReportPayouts(ctx, questionID, []*big.Int{big.NewInt(1), big.NewInt(0)}))
+	redeemData, err := ctfABI.Pack("redeemPositions", contracts.Collateral, [32]byte{}, conditionID, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	assert.NoError(t, err)
+	req, err = client.BuildTx([]types.SafeTransaction{{To: contracts.Conditional.Hex(), Data: hexutil.Encode(redeemData), Value: "0"}}, big.NewInt(1), "redeem", &sdktypes.AuthOption{SingerAddress: owner})
+	assert.NoError(t, err)
+	res, err = sim.Simulate(ctx, req)
+	assert.NoError(t, err)
+	assert.True(t, res.Success, res.RevertReason)
+	assert.IsType(t, relayer.RedeemAction{}, res.Decoded.Calls[0].Action)
+	balance, err := sim.CollateralBalance(ctx, safe)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10_000_000), balance.Int64())
+	nonce, err := sim.SafeNonce(ctx, safe)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), nonce.Int64())
+}
+
+func TestSimulateRevertAndBadSignature(t *testing.T) {
+	ctx := context.Background()
+	contracts, _ := config.GetContracts(chainId.Int64())
+	client := newClient(t)
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+
+	sim, err := simulate.New(chainId, owner)
+	assert.NoError(t, err)
+	defer sim.Close()
+
+	conditionID, err := sim.PrepareCondition(ctx, common.HexToHash("0x01"), 2)
+	assert.NoError(t, err)
+
+	ctfABI, _ := relayer.ConditionalTokensMetaData.GetAbi()
+	splitData, _ := ctfABI.Pack("splitPosition", contracts.Collateral, [32]byte{}, conditionID, []*big.Int{big.NewInt(1), big.NewInt(2)}, big.NewInt(1_000_000))
+	txns := []types.SafeTransaction{{To: contracts.Conditional.Hex(), Data: hexutil.Encode(splitData), Value: "0"}}
+
+	req, err := client.BuildTx(txns, nil, "split", &sdktypes.AuthOption{SingerAddress: owner})
+	assert.NoError(t, err)
+	res, err := sim.Simulate(ctx, req)
+	assert.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.Equal(t, "ERC20: insufficient balance", res.RevertReason)
+
+	// the Safe checks the owner signature on-chain
+	signature := hexutil.MustDecode(req.Signature)
+	signature[0] ^= 0xff
+	badSignature := *req
+	badSignature.Signature = hexutil.Encode(signature)
+	res, err = sim.Simulate(ctx, &badSignature)
+	assert.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.Equal(t, "GS026", res.RevertReason)
+
+	tampered := "2"
+	req.Nonce = &tampered
+	res, err = sim.Simulate(ctx, req)
+	assert.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.Contains(t, res.RevertReason, "GS026")
+
+	_, err = sim.Simulate(ctx, &types.TransactionRequest{Type: string(types.TransactionTypeSAFE), ProxyWallet: &owner, Nonce: &tampered})
+	assert.Error(t, err)
+}
+
+func TestSimulateMissingApproval(t *testing.T) {
+	ctx := context.Background()
+	contracts, _ := config.GetContracts(chainId.Int64())
+	client := newClient(t)
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	safe, _ := client.GetExpectedSafe(owner)
+
+	sim, err := simulate.New(chainId, owner)
+	assert.NoError(t, err)
+	defer sim.Close()
+
+	conditionID, err := sim.PrepareCondition(ctx, common.HexToHash("0x02"), 2)
+	assert.NoError(t, err)
+	assert.NoError(t, sim.MintCollateral(ctx, safe, big.NewInt(10_000_000)))
+
+	ctfABI, _ := relayer.ConditionalTokensMetaData.GetAbi()
+	splitData, _ := ctfABI.Pack("splitPosition", contracts.Collateral, [32]byte{}, conditionID, []*big.Int{big.NewInt(1), big.NewInt(2)}, big.NewInt(4_000_000))
+	split := types.SafeTransaction{To: contracts.Conditional.Hex(), Data: hexutil.Encode(splitData), Value: "0"}
+
+	// the approval is short of the split
+	req, err := client.BuildTx([]types.SafeTransaction{approveCollateral(t, contracts.Conditional, 3_000_000), split}, nil, "split", &sdktypes.AuthOption{SingerAddress: owner})
+	assert.NoError(t, err)
+	res, err := sim.Simulate(ctx, req)
+	assert.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.Equal(t, "ERC20: insufficient allowance", res.RevertReason)
+
+	req, err = client.BuildTx([]types.SafeTransaction{split}, nil, "split", &sdktypes.AuthOption{SingerAddress: owner})
+	assert.NoError(t, err)
+	res, err = sim.Simulate(ctx, req)
+	assert.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.Equal(t, "ERC20: insufficient allowance", res.RevertReason)
+
+	balance, err := sim.CollateralBalance(ctx, safe)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10_000_000), balance.Int64())
+}
+
+func approveCollateral(t *testing.T, spender common.Address, amount int64) types.SafeTransaction {
+	contracts, _ := config.GetContracts(chainId.Int64())
+	erc20, _ := abi.JSON(strings.NewReader(`[{"inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"}]`))
+	data, err := erc20.Pack("approve", spender, big.NewInt(amount))
+	assert.NoError(t, err)
+	return types.SafeTransaction{To: contracts.Collateral.Hex(), Data: hexutil.Encode(data), Value: "0"}
+}
+
+func TestSafeOrderIsValidSignature(t *testing.T) {
+	ctx := context.Background()
+	keys := make([]*ecdsa.PrivateKey, 3)
+	signers := make([]signing.Signer, 3)
+	owners := make([]string, 3)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		signers[i] = signing.NewPrivateKeySigner(keys[i])
+		owners[i] = signers[i].Address().Hex()
+	}
+	safe, err := types.DeriveSafeAddress(owners[0], types.GetContractConfig(chainId).SafeFactory)
+	assert.NoError(t, err)
+
+	sim, err := simulate.New(chainId, owners[0])
+	assert.NoError(t, err)
+	defer sim.Close()
+	assert.NoError(t, sim.AddSafeOwners(ctx, safe, 2, owners[1:]...))
+
+	orderBuilder := clob.NewOrderBuilder(chainId, signing.ToSignatureFunc(signers...))
+	tickSize := "0.01"
+	options := clobtypes.CreateOrderOptions{
+		AuthOption: &sdktypes.AuthOption{SingerAddress: owners[0], FunderAddress: safe},
+		TickSize:   clobtypes.TickSize(tickSize),
+		NegRisk:    true,
+	}
+	userOrder := clobtypes.UserOrder{TokenID: "1234", Price: 0.5, Size: 10, Side: clobtypes.BUY, TickSize: &tickSize}
+
+	// owners are passed unsorted; the Safe requires ascending owner order
+	order, err := orderBuilder.BuildSafeOrder(userOrder, clobtypes.OrderTypeGTC, options, []string{owners[2], owners[0]})
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToAddress(safe), order.Maker)
+	assert.Equal(t, common.HexToAddress(safe), order.Signer)
+	assert.Equal(t, int64(clobtypes.POLY_1271), order.SignatureType.Int64())
+	assert.Len(t, order.Signature, 130)
+
+	orderHash, err := builder.NewExchangeOrderBuilderImpl(chainId, nil).BuildOrderHash(&order.Order, model.NegRiskCTFExchange)
+	assert.NoError(t, err)
+	valid, err := sim.IsValidSignature(ctx, safe, orderHash, order.Signature)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// signed for the other exchange
+	otherHash, _ := builder.NewExchangeOrderBuilderImpl(chainId, nil).BuildOrderHash(&order.Order, model.CTFExchange)
+	_, err = sim.IsValidSignature(ctx, safe, otherHash, order.Signature)
+	assert.ErrorContains(t, err, "GS026")
+
+	// below threshold
+	_, err = sim.IsValidSignature(ctx, safe, orderHash, order.Signature[:65])
+	assert.ErrorContains(t, err, "GS020")
+
+	// unsorted owners
+	swapped := append(append([]byte(nil), order.Signature[65:]...), order.Signature[:65]...)
+	_, err = sim.IsValidSignature(ctx, safe, orderHash, swapped)
+	assert.ErrorContains(t, err, "GS026")
+
+	// eth_sign variant of an owner signature
+	messageHash, err := signing.SafeMessageHash(chainId.Int64(), safe, orderHash)
+	assert.NoError(t, err)
+	ethSig, err := signers[1].SignDigest(signing.SafeTxSigningDigest(messageHash).Bytes())
+	assert.NoError(t, err)
+	ethSig[64] += 4
+	rawSig, err := signers[2].SignDigest(messageHash.Bytes())
+	assert.NoError(t, err)
+	packed, err := signing.PackSafeSignatures(map[common.Address][]byte{
+		signers[1].Address(): ethSig,
+		signers[2].Address(): rawSig,
+	})
+	assert.NoError(t, err)
+	valid, err = sim.IsValidSignature(ctx, safe, orderHash, packed)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
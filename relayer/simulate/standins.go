@@ -0,0 +1,645 @@
+package simulate
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/asm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The Safe, MultiSend and collateral token have no generated bytecode in this
+// repository, so the simulator places minimal hand-assembled stand-ins at their
+// production addresses. They implement only what the relayer flow touches.
+
+// safeCode follows Safe 1.3.0 for execTransaction(to, value, data, operation,
+// safeTxGas, baseGas, gasPrice, gasToken, refundReceiver, signatures): the
+// owner signatures must sign the EIP-712 SafeTx hash with the current nonce()
+// (slot 0). It then bumps the nonce, runs a CALL or DELEGATECALL and bubbles
+// up the revert data. ERC1155 receiver hooks echo their selector so
+// ConditionalTokens can mint positions to the Safe.
+//
+// isValidSignature(bytes32, bytes) follows Safe's CompatibilityFallbackHandler
+// and checks the signatures against the SafeMessage hash. In both cases the
+// packed signatures sign the hash (or its eth_sign digest for v 31/32), are
+// sorted by owner and reach the threshold (slot 1). The domain chainId is read
+// from slot 2, as the simulated chain has its own ID. Owners are flagged at
+// storage slot <owner address>; only the simulator account in slot 3 may call
+// addOwnerWithThreshold.
+var safeCode = mustAssemble(`
+	PUSH 0
+	CALLDATALOAD
+	PUSH 224
+	SHR
+	DUP1
+	PUSH ` + selector("execTransaction(address,uint256,bytes,uint8,uint256,uint256,uint256,address,address,bytes)") + `
+	EQ
+	JUMPI @exec
+	DUP1
+	PUSH ` + selector("onERC1155Received(address,address,uint256,uint256,bytes)") + `
+	EQ
+	JUMPI @echo
+	DUP1
+	PUSH ` + selector("onERC1155BatchReceived(address,address,uint256[],uint256[],bytes)") + `
+	EQ
+	JUMPI @echo
+	DUP1
+	PUSH ` + selector("nonce()") + `
+	EQ
+	JUMPI @nonce
+	DUP1
+	PUSH ` + selector("isValidSignature(bytes32,bytes)") + `
+	EQ
+	JUMPI @isValidSignature
+	DUP1
+	PUSH ` + selector("addOwnerWithThreshold(address,uint256)") + `
+	EQ
+	JUMPI @addOwner
+	STOP
+addOwner:
+	CALLER
+	PUSH 3
+	SLOAD
+	EQ
+	ISZERO
+	JUMPI @unauthorized
+	PUSH 1
+	PUSH 4
+	CALLDATALOAD
+	SSTORE
+	PUSH 0x24
+	CALLDATALOAD
+	PUSH 1
+	SSTORE
+	STOP
+unauthorized:
+` + revertWithReason("GS031") + `
+isValidSignature:
+	POP
+	PUSH 4
+	CALLDATALOAD
+	PUSH 0
+	MSTORE
+	PUSH 0x20
+	PUSH 0
+	KECCAK256
+	PUSH 0x20
+	MSTORE
+	PUSH ` + crypto.Keccak256Hash([]byte("SafeMessage(bytes message)")).Hex() + `
+	PUSH 0
+	MSTORE
+	PUSH 0x40
+	PUSH 0
+	KECCAK256
+	PUSH 0x140
+	MSTORE
+	PUSH @validSignature
+	PUSH 0x24
+	JUMP @checkSignatures
+validSignature:
+	PUSH ` + selector("isValidSignature(bytes32,bytes)") + `
+	PUSH 224
+	SHL
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+exec:
+	POP
+	PUSH 0x44
+	CALLDATALOAD
+	PUSH 4
+	ADD
+	DUP1
+	CALLDATALOAD
+	DUP1
+	SWAP2
+	PUSH 32
+	ADD
+	PUSH 0x600
+	CALLDATACOPY
+	PUSH 0x600
+	KECCAK256
+	PUSH 0x460
+	MSTORE
+	PUSH ` + crypto.Keccak256Hash([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)")).Hex() + `
+	PUSH 0x400
+	MSTORE
+	PUSH 0x04
+	CALLDATALOAD
+	PUSH 0x420
+	MSTORE
+	PUSH 0x24
+	CALLDATALOAD
+	PUSH 0x440
+	MSTORE` + copyWords(0x64, 0x480, 6) + `
+	PUSH 0
+	SLOAD
+	PUSH 0x540
+	MSTORE
+	PUSH 0x160
+	PUSH 0x400
+	KECCAK256
+	PUSH 0x140
+	MSTORE
+	PUSH @execute
+	PUSH 0x124
+	JUMP @checkSignatures
+execute:
+	PUSH 1
+	PUSH 0
+	SLOAD
+	ADD
+	PUSH 0
+	SSTORE
+	PUSH 0x44
+	CALLDATALOAD
+	PUSH 4
+	ADD
+	DUP1
+	CALLDATALOAD
+	SWAP1
+	PUSH 32
+	ADD
+	DUP2
+	SWAP1
+	PUSH 0
+	CALLDATACOPY
+	PUSH 0x64
+	CALLDATALOAD
+	JUMPI @delegate
+	PUSH 0
+	PUSH 0
+	DUP3
+	PUSH 0
+	PUSH 0x24
+	CALLDATALOAD
+	PUSH 0x04
+	CALLDATALOAD
+	GAS
+	CALL
+	JUMP @done
+delegate:
+	PUSH 0
+	PUSH 0
+	DUP3
+	PUSH 0
+	PUSH 0x04
+	CALLDATALOAD
+	GAS
+	DELEGATECALL
+done:
+	JUMPI @ok
+	RETURNDATASIZE
+	PUSH 0
+	PUSH 0
+	RETURNDATACOPY
+	RETURNDATASIZE
+	PUSH 0
+	REVERT
+ok:
+	PUSH 1
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+checkSignatures:
+	PUSH 0x1901
+	PUSH 0x100
+	MSTORE
+	PUSH ` + crypto.Keccak256Hash([]byte("EIP712Domain(uint256 chainId,address verifyingContract)")).Hex() + `
+	PUSH 0
+	MSTORE
+	PUSH 2
+	SLOAD
+	PUSH 0x20
+	MSTORE
+	ADDRESS
+	PUSH 0x40
+	MSTORE
+	PUSH 0x60
+	PUSH 0
+	KECCAK256
+	PUSH 0x120
+	MSTORE
+	PUSH 66
+	PUSH 0x11e
+	KECCAK256
+	DUP1
+	PUSH 0x200
+	MSTORE
+	PUSH 0x300
+	MSTORE
+	PUSH 0x` + hex.EncodeToString([]byte("\x19Ethereum Signed Message:\n32")) + `
+	PUSH 0x2e0
+	MSTORE
+	PUSH 60
+	PUSH 0x2e4
+	KECCAK256
+	PUSH 0x220
+	MSTORE
+	CALLDATALOAD
+	PUSH 4
+	ADD
+	DUP1
+	CALLDATALOAD
+	SWAP1
+	PUSH 32
+	ADD
+	DUP1
+	SWAP2
+	ADD
+	SWAP1
+owners:
+	DUP2
+	DUP2
+	PUSH 65
+	ADD
+	GT
+	JUMPI @threshold
+	DUP1
+	PUSH 64
+	ADD
+	CALLDATALOAD
+	PUSH 248
+	SHR
+	DUP1
+	PUSH 30
+	LT
+	DUP1
+	PUSH 4
+	MUL
+	DUP3
+	SUB
+	PUSH 0xa0
+	MSTORE
+	PUSH 32
+	MUL
+	PUSH 0x200
+	ADD
+	MLOAD
+	PUSH 0x80
+	MSTORE
+	POP
+	DUP1
+	CALLDATALOAD
+	PUSH 0xc0
+	MSTORE
+	DUP1
+	PUSH 32
+	ADD
+	CALLDATALOAD
+	PUSH 0xe0
+	MSTORE
+	PUSH 0
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	PUSH 0x80
+	PUSH 0x80
+	PUSH 1
+	GAS
+	STATICCALL
+	POP
+	PUSH 0
+	MLOAD
+	DUP1
+	PUSH 0x240
+	MLOAD
+	LT
+	ISZERO
+	JUMPI @invalidOwner
+	DUP1
+	SLOAD
+	ISZERO
+	JUMPI @invalidOwner
+	PUSH 0x240
+	MSTORE
+	PUSH 1
+	PUSH 0x260
+	MLOAD
+	ADD
+	PUSH 0x260
+	MSTORE
+	PUSH 65
+	ADD
+	JUMP @owners
+threshold:
+	PUSH 1
+	SLOAD
+	ISZERO
+	JUMPI @belowThreshold
+	PUSH 1
+	SLOAD
+	PUSH 0x260
+	MLOAD
+	LT
+	JUMPI @belowThreshold
+	POP
+	POP
+	JUMP
+invalidOwner:
+` + revertWithReason("GS026") + `
+belowThreshold:
+` + revertWithReason("GS020") + `
+nonce:
+	PUSH 0
+	SLOAD
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+echo:
+	PUSH 224
+	SHL
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+`)
+
+// multiSendCode walks the packed multiSend(bytes) payload, see types.UnpackTransactions.
+var multiSendCode = mustAssemble(`
+	PUSH 0x24
+	CALLDATALOAD
+	PUSH 0x44
+	ADD
+	PUSH 0x44
+loop:
+	DUP2
+	DUP2
+	LT
+	ISZERO
+	JUMPI @finish
+	DUP1
+	PUSH 53
+	ADD
+	CALLDATALOAD
+	DUP1
+	DUP3
+	PUSH 85
+	ADD
+	PUSH 0
+	CALLDATACOPY
+	DUP2
+	CALLDATALOAD
+	PUSH 248
+	SHR
+	JUMPI @delegate
+	PUSH 0
+	PUSH 0
+	DUP3
+	PUSH 0
+	DUP6
+	PUSH 21
+	ADD
+	CALLDATALOAD
+	DUP7
+	PUSH 1
+	ADD
+	CALLDATALOAD
+	PUSH 96
+	SHR
+	GAS
+	CALL
+	JUMP @next
+delegate:
+	PUSH 0
+	PUSH 0
+	DUP3
+	PUSH 0
+	DUP6
+	PUSH 1
+	ADD
+	CALLDATALOAD
+	PUSH 96
+	SHR
+	GAS
+	DELEGATECALL
+next:
+	ISZERO
+	JUMPI @fail
+	PUSH 85
+	ADD
+	ADD
+	JUMP @loop
+fail:
+	RETURNDATASIZE
+	PUSH 0
+	PUSH 0
+	RETURNDATACOPY
+	RETURNDATASIZE
+	PUSH 0
+	REVERT
+finish:
+	STOP
+`)
+
+// collateralCode is a 6-decimals ERC20 keeping balances at storage slot
+// <holder address> and allowances at keccak256(owner, spender). transferFrom
+// checks the balance, then the allowance of the caller. Anyone may mint.
+var collateralCode = mustAssemble(`
+	PUSH 0
+	CALLDATALOAD
+	PUSH 224
+	SHR
+	DUP1
+	PUSH ` + selector("balanceOf(address)") + `
+	EQ
+	JUMPI @balanceOf
+	DUP1
+	PUSH ` + selector("transfer(address,uint256)") + `
+	EQ
+	JUMPI @transfer
+	DUP1
+	PUSH ` + selector("transferFrom(address,address,uint256)") + `
+	EQ
+	JUMPI @transferFrom
+	DUP1
+	PUSH ` + selector("approve(address,uint256)") + `
+	EQ
+	JUMPI @approve
+	DUP1
+	PUSH ` + selector("decimals()") + `
+	EQ
+	JUMPI @decimals
+	DUP1
+	PUSH ` + selector("mint(address,uint256)") + `
+	EQ
+	JUMPI @mint
+	PUSH 0
+	DUP1
+	REVERT
+balanceOf:
+	PUSH 4
+	CALLDATALOAD
+	SLOAD
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+decimals:
+	PUSH 6
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+approve:
+	CALLER
+	PUSH 0
+	MSTORE
+	PUSH 4
+	CALLDATALOAD
+	PUSH 0x20
+	MSTORE
+	PUSH 0x24
+	CALLDATALOAD
+	PUSH 0x40
+	PUSH 0
+	KECCAK256
+	SSTORE
+	JUMP @success
+mint:
+	PUSH 0x24
+	CALLDATALOAD
+	PUSH 4
+	CALLDATALOAD
+	SLOAD
+	ADD
+	PUSH 4
+	CALLDATALOAD
+	SSTORE
+	JUMP @success
+transfer:
+	PUSH 0x24
+	CALLDATALOAD
+	PUSH 4
+	CALLDATALOAD
+	CALLER
+	JUMP @move
+transferFrom:
+	PUSH 0x44
+	CALLDATALOAD
+	PUSH 4
+	CALLDATALOAD
+	SLOAD
+	LT
+	JUMPI @insufficient
+	PUSH 4
+	CALLDATALOAD
+	PUSH 0
+	MSTORE
+	CALLER
+	PUSH 0x20
+	MSTORE
+	PUSH 0x40
+	PUSH 0
+	KECCAK256
+	DUP1
+	SLOAD
+	PUSH 0x44
+	CALLDATALOAD
+	DUP2
+	DUP2
+	GT
+	JUMPI @noAllowance
+	SWAP1
+	SUB
+	SWAP1
+	SSTORE
+	PUSH 0x44
+	CALLDATALOAD
+	PUSH 0x24
+	CALLDATALOAD
+	PUSH 4
+	CALLDATALOAD
+move:
+	DUP1
+	SLOAD
+	DUP4
+	DUP2
+	LT
+	JUMPI @insufficient
+	DUP4
+	SWAP1
+	SUB
+	SWAP1
+	SSTORE
+	DUP1
+	SLOAD
+	DUP3
+	ADD
+	SWAP1
+	SSTORE
+	POP
+success:
+	PUSH 1
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 0
+	RETURN
+insufficient:
+` + revertWithReason("ERC20: insufficient balance") + `
+noAllowance:
+` + revertWithReason("ERC20: insufficient allowance"))
+
+// revertWithReason assembles revert(Error(reason)) for reasons up to 32 bytes.
+func revertWithReason(reason string) string {
+	word := make([]byte, 32)
+	copy(word, reason)
+	return fmt.Sprintf(`
+	PUSH %s
+	PUSH 224
+	SHL
+	PUSH 0
+	MSTORE
+	PUSH 32
+	PUSH 4
+	MSTORE
+	PUSH %d
+	PUSH 0x24
+	MSTORE
+	PUSH 0x%s
+	PUSH 0x44
+	MSTORE
+	PUSH 0x64
+	PUSH 0
+	REVERT
+`, selector("Error(string)"), len(reason), hex.EncodeToString(word))
+}
+
+// copyWords assembles copying n calldata words from offset on to memory at dst.
+func copyWords(offset, dst, n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "\n\tPUSH 0x%x\n\tCALLDATALOAD\n\tPUSH 0x%x\n\tMSTORE", offset+32*i, dst+32*i)
+	}
+	return b.String()
+}
+
+func selector(signature string) string {
+	return "0x" + hex.EncodeToString(crypto.Keccak256([]byte(signature))[:4])
+}
+
+func mustAssemble(source string) []byte {
+	compiler := asm.NewCompiler(false)
+	compiler.Feed(asm.Lex([]byte(source), false))
+	out, errs := compiler.Compile()
+	if len(errs) != 0 {
+		panic(fmt.Sprintf("simulate: assemble stand-in: %v", errs))
+	}
+	code, err := hex.DecodeString(strings.TrimPrefix(out, "0x"))
+	if err != nil {
+		panic(fmt.Sprintf("simulate: assemble stand-in: %v", err))
+	}
+	return code
+}
@@ -0,0 +1,138 @@
+package simulate_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/override-coder/go-polymarket-sdk/relayer/simulate"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/polymarket/go-order-utils/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+var standInABI, _ = abi.JSON(strings.NewReader(`[
+  {"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+  {"inputs":[{"name":"account","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+  {"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+  {"inputs":[{"name":"owner","type":"address"},{"name":"_threshold","type":"uint256"}],"name":"addOwnerWithThreshold","outputs":[],"stateMutability":"nonpayable","type":"function"},
+  {"inputs":[{"name":"operator","type":"address"},{"name":"from","type":"address"},{"name":"id","type":"uint256"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"}],"name":"onERC1155Received","outputs":[{"name":"","type":"bytes4"}],"stateMutability":"nonpayable","type":"function"},
+  {"inputs":[{"name":"operator","type":"address"},{"name":"from","type":"address"},{"name":"ids","type":"uint256[]"},{"name":"values","type":"uint256[]"},{"name":"data","type":"bytes"}],"name":"onERC1155BatchReceived","outputs":[{"name":"","type":"bytes4"}],"stateMutability":"nonpayable","type":"function"}
+]`))
+
+func call(t *testing.T, sim *simulate.Simulator, from, to common.Address, method string, args ...interface{}) ([]byte, error) {
+	data := []byte{0xde, 0xad, 0xbe, 0xef}
+	if method != "" {
+		var err error
+		data, err = standInABI.Pack(method, args...)
+		assert.NoError(t, err)
+	}
+	return sim.Backend().Client().CallContract(context.Background(), ethereum.CallMsg{From: from, To: &to, Data: data}, nil)
+}
+
+func TestSafeStandIn(t *testing.T) {
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	safe, err := types.DeriveSafeAddress(owner, types.GetContractConfig(chainId).SafeFactory)
+	assert.NoError(t, err)
+	sim, err := simulate.New(chainId, owner)
+	assert.NoError(t, err)
+	defer sim.Close()
+	safeAddr := common.HexToAddress(safe)
+	stranger := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	// ERC1155 receiver hooks echo their selector.
+	out, err := call(t, sim, stranger, safeAddr, "onERC1155Received", stranger, stranger, big.NewInt(1), big.NewInt(1), []byte{})
+	assert.NoError(t, err)
+	assert.Equal(t, standInABI.Methods["onERC1155Received"].ID, out[:4])
+	out, err = call(t, sim, stranger, safeAddr, "onERC1155BatchReceived", stranger, stranger, []*big.Int{big.NewInt(1)}, []*big.Int{big.NewInt(1)}, []byte{})
+	assert.NoError(t, err)
+	assert.Equal(t, standInABI.Methods["onERC1155BatchReceived"].ID, out[:4])
+
+	// Unknown selectors stop without output.
+	out, err = call(t, sim, stranger, safeAddr, "")
+	assert.NoError(t, err)
+	assert.Empty(t, out)
+
+	// Only the simulator account adds owners.
+	_, err = call(t, sim, stranger, safeAddr, "addOwnerWithThreshold", stranger, big.NewInt(1))
+	assert.ErrorContains(t, err, "GS031")
+	_, err = call(t, sim, sim.TransactOpts().From, safeAddr, "addOwnerWithThreshold", stranger, big.NewInt(1))
+	assert.NoError(t, err)
+}
+
+func TestCollateralStandIn(t *testing.T) {
+	ctx := context.Background()
+	contracts, _ := config.GetContracts(chainId.Int64())
+	client := newClient(t)
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	option := &sdktypes.AuthOption{SingerAddress: owner}
+	safe, _ := client.GetExpectedSafe(owner)
+	sim, err := simulate.New(chainId, owner)
+	assert.NoError(t, err)
+	defer sim.Close()
+	stranger := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	out, err := call(t, sim, stranger, contracts.Collateral, "decimals")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), new(big.Int).SetBytes(out).Int64())
+	_, err = call(t, sim, stranger, contracts.Collateral, "")
+	assert.Error(t, err, "unknown selectors revert")
+
+	assert.NoError(t, sim.MintCollateral(ctx, safe, big.NewInt(5_000_000)))
+	transfer := func(amount int64) types.SafeTransaction {
+		data, err := standInABI.Pack("transfer", stranger, big.NewInt(amount))
+		assert.NoError(t, err)
+		return types.SafeTransaction{To: contracts.Collateral.Hex(), Data: hexutil.Encode(data), Value: "0"}
+	}
+
+	req, err := client.BuildTx([]types.SafeTransaction{transfer(6_000_000)}, nil, "transfer", option)
+	assert.NoError(t, err)
+	res, err := sim.Simulate(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "ERC20: insufficient balance", res.RevertReason)
+
+	req, err = client.BuildTx([]types.SafeTransaction{transfer(2_000_000)}, nil, "transfer", option)
+	assert.NoError(t, err)
+	res, err = sim.Simulate(ctx, req)
+	assert.NoError(t, err)
+	assert.True(t, res.Success, res.RevertReason)
+	balance, err := sim.CollateralBalance(ctx, stranger.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2_000_000), balance.Int64())
+}
+
+func TestMultiSendStandIn(t *testing.T) {
+	ctx := context.Background()
+	contracts, _ := config.GetContracts(chainId.Int64())
+	client := newClient(t)
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	safe, _ := client.GetExpectedSafe(owner)
+	sim, err := simulate.New(chainId, owner)
+	assert.NoError(t, err)
+	defer sim.Close()
+
+	// A DELEGATECALL entry runs in the Safe: balanceOf reads the Safe's
+	// own storage and leaves it unchanged.
+	data, err := standInABI.Pack("balanceOf", common.HexToAddress(safe))
+	assert.NoError(t, err)
+	read := types.SafeTransaction{To: contracts.Collateral.Hex(), Data: hexutil.Encode(data), Value: "0"}
+	delegated := read
+	delegated.Operation = types.OperationDelegateCall
+	req, err := client.BuildTx([]types.SafeTransaction{read, delegated}, nil, "read", &sdktypes.AuthOption{SingerAddress: owner})
+	assert.NoError(t, err)
+	res, err := sim.Simulate(ctx, req)
+	assert.NoError(t, err)
+	assert.True(t, res.Success, res.RevertReason)
+	assert.True(t, res.Decoded.MultiSend)
+	assert.Empty(t, res.Deltas)
+	nonce, err := sim.SafeNonce(ctx, safe)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), nonce.Int64())
+}
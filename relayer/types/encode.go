@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -184,3 +185,54 @@ func padBytes(data []byte, length int) []byte {
 	copy(padded[length-len(data):], data)
 	return padded
 }
+
+// DecodeMultiSendData unpacks multiSend(bytes) calldata into the batched transactions.
+func DecodeMultiSendData(data []byte) ([]SafeTransaction, error) {
+	method := multiSendABI.Methods["multiSend"]
+	if len(data) < 4 || !bytes.Equal(data[:4], method.ID) {
+		return nil, fmt.Errorf("decodeMultiSendData: not a multiSend call")
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("decodeMultiSendData: abi.Unpack multiSend failed: %w", err)
+	}
+	packed, ok := args[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("decodeMultiSendData: unexpected transactions argument %T", args[0])
+	}
+	return UnpackTransactions(packed)
+}
+
+// UnpackTransactions is the inverse of packTransactions: each entry is
+// operation(1) | to(20) | value(32) | dataLength(32) | data.
+func UnpackTransactions(packed []byte) ([]SafeTransaction, error) {
+	const headerLen = 1 + 20 + 32 + 32
+
+	var txns []SafeTransaction
+	for offset := 0; offset < len(packed); {
+		if len(packed)-offset < headerLen {
+			return nil, fmt.Errorf("unpackTransactions: truncated header at offset %d", offset)
+		}
+		operation := OperationType(packed[offset])
+		if operation != OperationCall && operation != OperationDelegateCall {
+			return nil, fmt.Errorf("unpackTransactions: invalid operation %d at offset %d", operation, offset)
+		}
+		to := common.BytesToAddress(packed[offset+1 : offset+21])
+		value := new(big.Int).SetBytes(packed[offset+21 : offset+53])
+		dataLength := new(big.Int).SetBytes(packed[offset+53 : offset+85])
+		offset += headerLen
+
+		if !dataLength.IsInt64() || dataLength.Int64() > int64(len(packed)-offset) {
+			return nil, fmt.Errorf("unpackTransactions: data length %s exceeds remaining %d bytes", dataLength, len(packed)-offset)
+		}
+		end := offset + int(dataLength.Int64())
+		txns = append(txns, SafeTransaction{
+			To:        to.Hex(),
+			Operation: operation,
+			Data:      hexutil.Encode(packed[offset:end]),
+			Value:     value.String(),
+		})
+		offset = end
+	}
+	return txns, nil
+}
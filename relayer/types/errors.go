@@ -0,0 +1,34 @@
+package types
+
+import "fmt"
+
+// TxFailedError is returned when a relayer transaction ends in STATE_FAILED or
+// STATE_INVALID.
+type TxFailedError struct {
+	TransactionID   string
+	TransactionHash string
+	State           RelayerTransactionState
+	Metadata        string
+	Transaction     *RelayerTransaction
+}
+
+func NewTxFailedError(txn *RelayerTransaction) *TxFailedError {
+	return &TxFailedError{
+		TransactionID:   txn.TransactionID,
+		TransactionHash: txn.TransactionHash,
+		State:           txn.State,
+		Metadata:        txn.Metadata,
+		Transaction:     txn,
+	}
+}
+
+func (e *TxFailedError) Error() string {
+	msg := fmt.Sprintf("transaction %s ended in %s", e.TransactionID, e.State)
+	if e.TransactionHash != "" {
+		msg += fmt.Sprintf(" (hash=%s)", e.TransactionHash)
+	}
+	if e.Metadata != "" {
+		msg += fmt.Sprintf(" metadata=%q", e.Metadata)
+	}
+	return msg
+}
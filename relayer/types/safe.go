@@ -0,0 +1,37 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DeriveSafeAddress returns the CREATE2 address of the Polymarket Safe owned by
+// ownerAddress, as deployed by safeFactory.
+func DeriveSafeAddress(ownerAddress string, safeFactory string) (string, error) {
+	if !common.IsHexAddress(ownerAddress) {
+		return "", fmt.Errorf("deriveSafe: invalid owner address: %s", ownerAddress)
+	}
+	if !common.IsHexAddress(safeFactory) {
+		return "", fmt.Errorf("deriveSafe: invalid factory address: %s", safeFactory)
+	}
+
+	initCodeHashBytes, err := hex.DecodeString(SafeInitCodeHashHex)
+	if err != nil {
+		return "", fmt.Errorf("deriveSafe: invalid SAFE_INIT_CODE_HASH_HEX: %w", err)
+	}
+	if len(initCodeHashBytes) != 32 {
+		return "", fmt.Errorf("deriveSafe: unexpected init code hash length %d", len(initCodeHashBytes))
+	}
+
+	paddedOwner := common.LeftPadBytes(common.HexToAddress(ownerAddress).Bytes(), 32)
+	saltBytes := crypto.Keccak256(paddedOwner)
+	factoryAddr := common.HexToAddress(safeFactory)
+	salt32 := [32]byte{}
+	copy(salt32[:], saltBytes[:32])
+
+	computedAddress := crypto.CreateAddress2(factoryAddr, salt32, initCodeHashBytes)
+	return computedAddress.Hex(), nil
+}
@@ -81,6 +81,21 @@ const (
 	RelayerStateFailed    RelayerTransactionState = "STATE_FAILED"
 )
 
+// IsTerminal reports whether no further state transitions are expected.
+func (s RelayerTransactionState) IsTerminal() bool {
+	switch s {
+	case RelayerStateConfirmed, RelayerStateFailed, RelayerStateInvalid:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsFailure reports whether the state means the transaction did not execute.
+func (s RelayerTransactionState) IsFailure() bool {
+	return s == RelayerStateFailed || s == RelayerStateInvalid
+}
+
 type RelayerTransaction struct {
 	TransactionID   string                  `json:"transactionID"`
 	TransactionHash string                  `json:"transactionHash"`
@@ -0,0 +1,293 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+)
+
+// TxEvent is a state transition observed for a watched relayer transaction.
+// Err is set on the final event of a transaction that failed or could not be
+// tracked to completion (deadline, repeated fetch errors).
+type TxEvent struct {
+	TransactionID string
+	Previous      types.RelayerTransactionState
+	State         types.RelayerTransactionState
+	Transaction   *types.RelayerTransaction
+	At            time.Time
+	Err           error
+}
+
+type TxWatcherOptions struct {
+	InitialInterval time.Duration // default 1s
+	MaxInterval     time.Duration // default 30s
+	Multiplier      float64       // default 2
+	// Target is the state that completes a watch, default STATE_CONFIRMED.
+	// Failure states always complete a watch.
+	Target types.RelayerTransactionState
+	// MaxFetchErrors stops watching after this many consecutive fetch errors, default 5.
+	MaxFetchErrors int
+	// Buffer is the capacity of the events channel, default 64.
+	Buffer int
+	// Retention is how long the outcome of a finished transaction is kept
+	// for Wait, default 10m.
+	Retention time.Duration
+}
+
+// TxWatcher tracks many relayer transactions concurrently, polling each with
+// exponential backoff and emitting every state transition on Events.
+// Draining Events is optional: transitions that do not fit in the buffer are
+// dropped and counted by Dropped, and Wait is not affected. The final event
+// of a transaction is not dropped for lack of buffer: it waits for Events to
+// take it, unless Wait returns the outcome first or the watcher is closed.
+// A finished transaction is forgotten once Wait returned its outcome, or
+// after the retention.
+type TxWatcher struct {
+	client *Client
+	opts   TxWatcherOptions
+
+	events  chan TxEvent
+	dropped atomic.Uint64
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	mu      sync.Mutex
+	results map[string]*txResult
+	closed  bool
+}
+
+type txResult struct {
+	done  chan struct{} // closed once txn and err are set
+	taken chan struct{} // closed once Wait returned the outcome
+	once  sync.Once
+	txn   *types.RelayerTransaction
+	err   error
+}
+
+func NewTxWatcher(c *Client, opts TxWatcherOptions) *TxWatcher {
+	if opts.InitialInterval <= 0 {
+		opts.InitialInterval = time.Second
+	}
+	if opts.MaxInterval <= 0 {
+		opts.MaxInterval = 30 * time.Second
+	}
+	if opts.MaxInterval < opts.InitialInterval {
+		opts.MaxInterval = opts.InitialInterval
+	}
+	if opts.Multiplier < 1 {
+		opts.Multiplier = 2
+	}
+	if opts.Target == "" {
+		opts.Target = types.RelayerStateConfirmed
+	}
+	if opts.MaxFetchErrors <= 0 {
+		opts.MaxFetchErrors = 5
+	}
+	if opts.Buffer <= 0 {
+		opts.Buffer = 64
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = 10 * time.Minute
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &TxWatcher{
+		client:  c,
+		opts:    opts,
+		events:  make(chan TxEvent, opts.Buffer),
+		ctx:     ctx,
+		cancel:  cancel,
+		results: make(map[string]*txResult),
+	}
+}
+
+// Events returns the transition stream. It is closed by Close.
+func (w *TxWatcher) Events() <-chan TxEvent {
+	return w.events
+}
+
+// Dropped returns the number of events dropped because Events was full.
+func (w *TxWatcher) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Watch starts tracking the given transaction IDs. ctx bounds the whole watch;
+// use context.WithTimeout for an overall deadline. IDs already watched are ignored.
+func (w *TxWatcher) Watch(ctx context.Context, transactionIDs ...string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return fmt.Errorf("tx watcher closed")
+	}
+	for _, id := range transactionIDs {
+		if _, ok := w.results[id]; ok {
+			continue
+		}
+		res := &txResult{done: make(chan struct{}), taken: make(chan struct{})}
+		w.results[id] = res
+		w.wg.Add(1)
+		go w.run(ctx, id, res)
+	}
+	return nil
+}
+
+// Wait blocks until the transaction reaches the target or a failure state.
+// A failed transaction returns *types.TxFailedError. Once Wait returned the
+// outcome, the transaction is no longer watched.
+func (w *TxWatcher) Wait(ctx context.Context, transactionID string) (*types.RelayerTransaction, error) {
+	w.mu.Lock()
+	res, ok := w.results[transactionID]
+	w.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("transaction %s is not watched", transactionID)
+	}
+	select {
+	case <-res.done:
+		w.forget(transactionID, res)
+		res.once.Do(func() { close(res.taken) })
+		return res.txn, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops all watches, waits for the pollers to exit and closes Events.
+func (w *TxWatcher) Close() {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	w.cancel()
+	w.wg.Wait()
+	w.mu.Lock()
+	w.results = make(map[string]*txResult)
+	w.mu.Unlock()
+	close(w.events)
+}
+
+func (w *TxWatcher) forget(id string, res *txResult) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.results[id] == res {
+		delete(w.results, id)
+	}
+}
+
+func (w *TxWatcher) run(ctx context.Context, id string, res *txResult) {
+	defer w.wg.Done()
+
+	var (
+		interval = w.opts.InitialInterval
+		previous types.RelayerTransactionState
+		last     *types.RelayerTransaction
+		failures int
+	)
+
+	// finish records the outcome for the retention and delivers the final
+	// event.
+	finish := func(ev TxEvent) {
+		res.txn, res.err = ev.Transaction, ev.Err
+		close(res.done)
+		time.AfterFunc(w.opts.Retention, func() { w.forget(id, res) })
+		select {
+		case w.events <- ev:
+		case <-res.taken:
+			w.dropped.Add(1)
+		case <-w.ctx.Done():
+			w.dropped.Add(1)
+		}
+	}
+	stop := func(err error) {
+		finish(TxEvent{TransactionID: id, Previous: previous, State: previous, Transaction: last, At: time.Now(), Err: err})
+	}
+
+	for {
+		txns, err := w.client.GetTransaction(id)
+		switch {
+		case err != nil:
+			failures++
+			if failures >= w.opts.MaxFetchErrors {
+				stop(fmt.Errorf("get transaction %s failed: %w", id, err))
+				return
+			}
+		case len(txns) > 0:
+			failures = 0
+			txn := txns[0]
+			last = &txn
+			ev := TxEvent{TransactionID: id, Previous: previous, State: txn.State, Transaction: &txn, At: time.Now()}
+			if txn.State.IsFailure() {
+				ev.Err = types.NewTxFailedError(&txn)
+				finish(ev)
+				return
+			}
+			if stateRank(txn.State) >= stateRank(w.opts.Target) {
+				finish(ev)
+				return
+			}
+			if txn.State != previous {
+				w.emit(ev)
+				previous = txn.State
+				interval = w.opts.InitialInterval
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			stop(w.stopErr(ctx))
+			return
+		case <-w.ctx.Done():
+			timer.Stop()
+			stop(w.stopErr(ctx))
+			return
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * w.opts.Multiplier)
+		if interval > w.opts.MaxInterval {
+			interval = w.opts.MaxInterval
+		}
+	}
+}
+
+func (w *TxWatcher) stopErr(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("tx watcher closed")
+}
+
+// emit delivers a transition, or drops it when Events is full.
+func (w *TxWatcher) emit(ev TxEvent) {
+	select {
+	case w.events <- ev:
+	default:
+		w.dropped.Add(1)
+	}
+}
+
+// stateRank orders the success path NEW -> EXECUTED -> MINED -> CONFIRMED so a
+// watch completes even if an intermediate state was never observed.
+func stateRank(s types.RelayerTransactionState) int {
+	switch s {
+	case types.RelayerStateNew:
+		return 0
+	case types.RelayerStateExecuted:
+		return 1
+	case types.RelayerStateMined:
+		return 2
+	case types.RelayerStateConfirmed:
+		return 3
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,179 @@
+package relayer_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/relayer"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// scriptedRelayer walks each transaction through a fixed list of states, one
+// step per GET /transaction.
+type scriptedRelayer struct {
+	mu      sync.Mutex
+	scripts map[string][]types.RelayerTransactionState
+}
+
+func (r *scriptedRelayer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	id := req.URL.Query().Get("id")
+	script, ok := r.scripts[id]
+	if !ok {
+		_ = json.NewEncoder(w).Encode([]types.RelayerTransaction{})
+		return
+	}
+	state := script[0]
+	if len(script) > 1 {
+		r.scripts[id] = script[1:]
+	}
+	_ = json.NewEncoder(w).Encode([]types.RelayerTransaction{{
+		TransactionID:   id,
+		TransactionHash: "0xabc",
+		State:           state,
+		Metadata:        "redeem " + id,
+	}})
+}
+
+func TestTxWatcher(t *testing.T) {
+	fake := &scriptedRelayer{scripts: map[string][]types.RelayerTransactionState{
+		"ok":   {types.RelayerStateNew, types.RelayerStateExecuted, types.RelayerStateMined, types.RelayerStateConfirmed},
+		"fail": {types.RelayerStateNew, types.RelayerStateNew, types.RelayerStateFailed},
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := relayer.NewClient(server.URL, chaindId, signature, testBuilderCreds)
+	watcher := relayer.NewTxWatcher(client, relayer.TxWatcherOptions{
+		InitialInterval: 5 * time.Millisecond,
+		MaxInterval:     20 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, watcher.Watch(ctx, "ok", "fail"))
+
+	txn, err := watcher.Wait(ctx, "ok")
+	assert.NoError(t, err)
+	assert.Equal(t, types.RelayerStateConfirmed, txn.State)
+
+	_, err = watcher.Wait(ctx, "fail")
+	var failed *types.TxFailedError
+	assert.True(t, errors.As(err, &failed))
+	assert.Equal(t, "0xabc", failed.TransactionHash)
+	assert.Equal(t, "redeem fail", failed.Metadata)
+	assert.Equal(t, types.RelayerStateFailed, failed.State)
+
+	watcher.Close()
+	transitions := map[string][]types.RelayerTransactionState{}
+	for ev := range watcher.Events() {
+		assert.False(t, ev.At.IsZero())
+		transitions[ev.TransactionID] = append(transitions[ev.TransactionID], ev.State)
+	}
+	assert.Equal(t, []types.RelayerTransactionState{
+		types.RelayerStateNew, types.RelayerStateExecuted, types.RelayerStateMined, types.RelayerStateConfirmed,
+	}, transitions["ok"])
+	assert.Equal(t, []types.RelayerTransactionState{
+		types.RelayerStateNew, types.RelayerStateFailed,
+	}, transitions["fail"])
+}
+
+func TestTxWatcherDeadline(t *testing.T) {
+	fake := &scriptedRelayer{scripts: map[string][]types.RelayerTransactionState{
+		"stuck": {types.RelayerStateNew},
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := relayer.NewClient(server.URL, chaindId, signature, testBuilderCreds)
+	watcher := relayer.NewTxWatcher(client, relayer.TxWatcherOptions{InitialInterval: 5 * time.Millisecond})
+	defer watcher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, watcher.Watch(ctx, "stuck"))
+
+	txn, err := watcher.Wait(context.Background(), "stuck")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, types.RelayerStateNew, txn.State)
+}
+
+func TestTxWatcherUndrained(t *testing.T) {
+	confirmed := []types.RelayerTransactionState{types.RelayerStateNew, types.RelayerStateMined, types.RelayerStateConfirmed}
+	fake := &scriptedRelayer{scripts: map[string][]types.RelayerTransactionState{
+		"a": confirmed, "b": confirmed, "c": confirmed,
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := relayer.NewClient(server.URL, chaindId, signature, testBuilderCreds)
+	watcher := relayer.NewTxWatcher(client, relayer.TxWatcherOptions{InitialInterval: 5 * time.Millisecond, Buffer: 1})
+	defer watcher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, watcher.Watch(ctx, "a", "b", "c"))
+	for _, id := range []string{"a", "b", "c"} {
+		txn, err := watcher.Wait(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, types.RelayerStateConfirmed, txn.State)
+	}
+	_, err := watcher.Wait(ctx, "a")
+	assert.ErrorContains(t, err, "not watched", "waited transactions are forgotten")
+
+	watcher.Close()
+	assert.Equal(t, uint64(8), watcher.Dropped())
+}
+
+func TestTxWatcherFinalEvents(t *testing.T) {
+	confirmed := []types.RelayerTransactionState{types.RelayerStateNew, types.RelayerStateMined, types.RelayerStateConfirmed}
+	fake := &scriptedRelayer{scripts: map[string][]types.RelayerTransactionState{
+		"a": confirmed, "b": confirmed, "c": confirmed,
+		"fail": {types.RelayerStateNew, types.RelayerStateFailed},
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := relayer.NewClient(server.URL, chaindId, signature, testBuilderCreds)
+	watcher := relayer.NewTxWatcher(client, relayer.TxWatcherOptions{InitialInterval: 5 * time.Millisecond, Buffer: 1, Retention: 50 * time.Millisecond})
+	defer watcher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, watcher.Watch(ctx, "a", "b", "c", "fail"))
+
+	// Let the buffer fill before reading: transitions are dropped, the final
+	// events are not.
+	time.Sleep(100 * time.Millisecond)
+	final := map[string]relayer.TxEvent{}
+	for len(final) < 4 {
+		select {
+		case ev := <-watcher.Events():
+			if ev.State == types.RelayerStateConfirmed || ev.Err != nil {
+				final[ev.TransactionID] = ev
+			}
+		case <-ctx.Done():
+			t.Fatalf("final events missing, got %v", final)
+		}
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		assert.NoError(t, final[id].Err)
+	}
+	var failed *types.TxFailedError
+	assert.True(t, errors.As(final["fail"].Err, &failed))
+	assert.Positive(t, watcher.Dropped())
+
+	// Outcomes nobody waits for are forgotten after the retention.
+	time.Sleep(100 * time.Millisecond)
+	_, err := watcher.Wait(ctx, "a")
+	assert.ErrorContains(t, err, "not watched")
+}
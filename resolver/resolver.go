@@ -0,0 +1,372 @@
+// Package resolver maps any market identifier (slug, Gamma market ID,
+// condition ID or CLOB token ID) to one canonical ResolvedMarket, combining
+// Gamma market metadata with the CLOB tick size and neg risk flag.
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/gamma/types"
+	"github.com/shopspring/decimal"
+	"golang.org/x/sync/singleflight"
+)
+
+// GammaAPI is the part of gamma.Client used by the Resolver.
+type GammaAPI interface {
+	GetMarketsBySlug(ctx context.Context, slug string) (*types.Market, error)
+	GetMarketsByID(ctx context.Context, id string) (*types.Market, error)
+	GetMarkets(ctx context.Context, p *types.GetMarketsParams) ([]*types.Market, error)
+}
+
+// ClobAPI is the part of clob.Client used by the Resolver.
+type ClobAPI interface {
+	GetTickSize(ctx context.Context, tokenID string) (string, error)
+	GetNegRisk(ctx context.Context, tokenID string) (bool, error)
+}
+
+type Outcome struct {
+	Name    string
+	TokenID string
+	// Price is the Gamma outcome price, zero when Gamma has none.
+	Price decimal.Decimal
+}
+
+// ResolvedMarket is the canonical view of a binary or multi-outcome market.
+// Each Resolve call returns its own copy, but Event and Market point into the
+// cached Gamma market and must be treated as read-only.
+type ResolvedMarket struct {
+	MarketID     string
+	Slug         string
+	ConditionID  string
+	QuestionID   string
+	Question     string
+	Outcomes     []Outcome
+	NegRisk      bool
+	TickSize     string
+	MinOrderSize decimal.Decimal
+	EndDate      time.Time // zero when Gamma has no end date
+	Closed       bool
+	// Event is the parent event, nil for a market without one.
+	Event *types.Event
+	// Market is the Gamma market the fields above were taken from.
+	Market *types.Market
+}
+
+// TokenID returns the token ID of the named outcome (case-insensitive).
+func (m *ResolvedMarket) TokenID(outcome string) (string, bool) {
+	for _, o := range m.Outcomes {
+		if strings.EqualFold(o.Name, outcome) {
+			return o.TokenID, true
+		}
+	}
+	return "", false
+}
+
+// Outcome returns the outcome traded with tokenID.
+func (m *ResolvedMarket) Outcome(tokenID string) (Outcome, bool) {
+	for _, o := range m.Outcomes {
+		if o.TokenID == tokenID {
+			return o, true
+		}
+	}
+	return Outcome{}, false
+}
+
+func (m *ResolvedMarket) clone() *ResolvedMarket {
+	c := *m
+	c.Outcomes = append([]Outcome(nil), m.Outcomes...)
+	return &c
+}
+
+// IDKind is the kind of identifier passed to Resolve.
+type IDKind int
+
+const (
+	KindSlug IDKind = iota
+	KindMarketID
+	KindConditionID
+	KindTokenID
+)
+
+func (k IDKind) String() string {
+	switch k {
+	case KindMarketID:
+		return "market id"
+	case KindConditionID:
+		return "condition id"
+	case KindTokenID:
+		return "token id"
+	default:
+		return "slug"
+	}
+}
+
+var (
+	conditionIDPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+	digitsPattern      = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// fetchTimeout bounds a shared lookup, which runs detached from the context
+// of the caller that started it.
+const fetchTimeout = 30 * time.Second
+
+// tokenIDMinDigits separates Gamma market IDs (small integers) from CLOB token
+// IDs (uint256 values, usually 70+ digits).
+const tokenIDMinDigits = 20
+
+// DetectKind guesses the kind of id: a 0x-prefixed 32-byte hex string is a
+// condition ID, a long decimal a token ID, a short decimal a market ID and
+// anything else a slug.
+func DetectKind(id string) IDKind {
+	switch {
+	case conditionIDPattern.MatchString(id):
+		return KindConditionID
+	case digitsPattern.MatchString(id) && len(id) >= tokenIDMinDigits:
+		return KindTokenID
+	case digitsPattern.MatchString(id):
+		return KindMarketID
+	default:
+		return KindSlug
+	}
+}
+
+// DefaultTTL is how long a resolved market is cached when New is given ttl 0.
+const DefaultTTL = 10 * time.Minute
+
+type cacheEntry struct {
+	market  *ResolvedMarket
+	expires time.Time
+}
+
+// Resolver resolves and caches markets. A resolved market is cached under all
+// its identifiers, so resolving it by slug also serves later token ID lookups.
+// It is safe for concurrent use: concurrent lookups of one identifier share a
+// request, and lookups of different identifiers run in parallel except for the
+// CLOB requests, which are serialized as the clob.Client caches are not
+// synchronized. Expired markets are evicted.
+type Resolver struct {
+	gamma GammaAPI
+	clob  ClobAPI
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu    sync.RWMutex
+	cache map[string]cacheEntry
+	swept time.Time
+
+	fetches singleflight.Group
+	clobMu  sync.Mutex
+}
+
+// New returns a Resolver backed by a gamma.Client and a clob.Client. A
+// negative ttl disables caching.
+func New(gamma GammaAPI, clob ClobAPI, ttl time.Duration) *Resolver {
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	return &Resolver{gamma: gamma, clob: clob, ttl: ttl, now: time.Now, cache: make(map[string]cacheEntry)}
+}
+
+// Resolve resolves id of the kind reported by DetectKind.
+func (r *Resolver) Resolve(ctx context.Context, id string) (*ResolvedMarket, error) {
+	return r.ResolveKind(ctx, DetectKind(strings.TrimSpace(id)), id)
+}
+
+func (r *Resolver) ResolveBySlug(ctx context.Context, slug string) (*ResolvedMarket, error) {
+	return r.ResolveKind(ctx, KindSlug, slug)
+}
+
+func (r *Resolver) ResolveByMarketID(ctx context.Context, marketID string) (*ResolvedMarket, error) {
+	return r.ResolveKind(ctx, KindMarketID, marketID)
+}
+
+func (r *Resolver) ResolveByConditionID(ctx context.Context, conditionID string) (*ResolvedMarket, error) {
+	return r.ResolveKind(ctx, KindConditionID, conditionID)
+}
+
+func (r *Resolver) ResolveByTokenID(ctx context.Context, tokenID string) (*ResolvedMarket, error) {
+	return r.ResolveKind(ctx, KindTokenID, tokenID)
+}
+
+// ResolveKind resolves id as the given kind.
+func (r *Resolver) ResolveKind(ctx context.Context, kind IDKind, id string) (*ResolvedMarket, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, fmt.Errorf("resolve: empty %s", kind)
+	}
+	key := cacheKey(kind, id)
+	if m, ok := r.cached(key); ok {
+		return m.clone(), nil
+	}
+
+	// The lookup is shared, so a caller giving up must not fail the others.
+	ch := r.fetches.DoChan(key, func() (interface{}, error) {
+		if m, ok := r.cached(key); ok {
+			return m, nil
+		}
+		fctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+		market, err := r.fetchMarket(fctx, kind, id)
+		if err != nil {
+			return nil, fmt.Errorf("resolve %s %s: %w", kind, id, err)
+		}
+		resolved, err := r.resolveMarket(fctx, market)
+		if err != nil {
+			return nil, fmt.Errorf("resolve %s %s: %w", kind, id, err)
+		}
+		r.store(resolved)
+		return resolved, nil
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*ResolvedMarket).clone(), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Invalidate drops every cached market.
+func (r *Resolver) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache = make(map[string]cacheEntry)
+}
+
+func (r *Resolver) fetchMarket(ctx context.Context, kind IDKind, id string) (*types.Market, error) {
+	switch kind {
+	case KindSlug:
+		return r.gamma.GetMarketsBySlug(ctx, id)
+	case KindMarketID:
+		return r.gamma.GetMarketsByID(ctx, id)
+	}
+
+	p := &types.GetMarketsParams{Limit: 1}
+	if kind == KindConditionID {
+		p.ConditionIDs = []string{id}
+	} else {
+		p.CLOBTokenIDs = []string{id}
+	}
+	markets, err := r.gamma.GetMarkets(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if len(markets) == 0 || markets[0] == nil {
+		return nil, fmt.Errorf("market not found")
+	}
+	return markets[0], nil
+}
+
+func (r *Resolver) resolveMarket(ctx context.Context, market *types.Market) (*ResolvedMarket, error) {
+	outcomes, err := market.OutcomeList()
+	if err != nil {
+		return nil, err
+	}
+	if len(outcomes) == 0 || outcomes[0].TokenID == "" {
+		return nil, fmt.Errorf("market %s has no clob token ids", market.ID)
+	}
+
+	resolved := &ResolvedMarket{
+		MarketID:    market.ID,
+		ConditionID: market.ConditionID,
+		Slug:        deref(market.Slug),
+		QuestionID:  deref(market.QuestionID),
+		Question:    deref(market.Question),
+		Closed:      market.Closed != nil && *market.Closed,
+		Market:      market,
+	}
+	for _, o := range outcomes {
+		resolved.Outcomes = append(resolved.Outcomes, Outcome{Name: o.Name, TokenID: o.TokenID, Price: o.Price})
+	}
+	if market.OrderMinSize != nil {
+		resolved.MinOrderSize = *market.OrderMinSize
+	}
+	if market.EndDate != nil {
+		if t, err := time.Parse(time.RFC3339, *market.EndDate); err == nil {
+			resolved.EndDate = t
+		}
+	}
+	if len(market.Events) > 0 {
+		resolved.Event = &market.Events[0]
+	}
+
+	r.clobMu.Lock()
+	defer r.clobMu.Unlock()
+	if resolved.TickSize, err = r.clob.GetTickSize(ctx, outcomes[0].TokenID); err != nil {
+		return nil, fmt.Errorf("get tick size: %w", err)
+	}
+	if resolved.NegRisk, err = r.clob.GetNegRisk(ctx, outcomes[0].TokenID); err != nil {
+		return nil, fmt.Errorf("get neg risk: %w", err)
+	}
+	return resolved, nil
+}
+
+func (r *Resolver) cached(key string) (*ResolvedMarket, bool) {
+	if r.ttl < 0 {
+		return nil, false
+	}
+	r.mu.RLock()
+	entry, ok := r.cache[key]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	if r.now().After(entry.expires) {
+		r.mu.Lock()
+		if current, ok := r.cache[key]; ok && current.market == entry.market {
+			delete(r.cache, key)
+		}
+		r.mu.Unlock()
+		return nil, false
+	}
+	return entry.market, true
+}
+
+func (r *Resolver) store(m *ResolvedMarket) {
+	if r.ttl < 0 {
+		return
+	}
+	now := r.now()
+	entry := cacheEntry{market: m, expires: now.Add(r.ttl)}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Expired markets looked up again are dropped by cached; sweep the others
+	// once per ttl.
+	if now.Sub(r.swept) >= r.ttl {
+		for key, e := range r.cache {
+			if now.After(e.expires) {
+				delete(r.cache, key)
+			}
+		}
+		r.swept = now
+	}
+	r.cache[cacheKey(KindMarketID, m.MarketID)] = entry
+	r.cache[cacheKey(KindConditionID, m.ConditionID)] = entry
+	if m.Slug != "" {
+		r.cache[cacheKey(KindSlug, m.Slug)] = entry
+	}
+	for _, o := range m.Outcomes {
+		r.cache[cacheKey(KindTokenID, o.TokenID)] = entry
+	}
+}
+
+func cacheKey(kind IDKind, id string) string {
+	if kind == KindConditionID {
+		id = strings.ToLower(id)
+	}
+	return fmt.Sprintf("%d:%s", kind, id)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,206 @@
+package resolver_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/override-coder/go-polymarket-sdk/clob"
+	"github.com/override-coder/go-polymarket-sdk/gamma"
+	"github.com/override-coder/go-polymarket-sdk/resolver"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	conditionID = "0x1b6f76e5b8587ee896c35847e12d11e75290a8c3934c5952e8a9d6e4c6f03cfa"
+	yesToken    = "71321045679252212594626385532706912750332728571942532289631379312455583992563"
+	noToken     = "52114319501245915516055106046884209969926127482827954674443846427813813222426"
+	marketJSON  = `{"id":"12","slug":"will-it-rain","conditionId":"` + conditionID + `","question":"Will it rain?",
+		"outcomes":"[\"Yes\", \"No\"]","outcomePrices":"[\"0.62\", \"0.38\"]",
+		"clobTokenIds":"[\"` + yesToken + `\", \"` + noToken + `\"]",
+		"orderMinSize":5,"endDate":"2026-12-31T12:00:00Z","events":[{"id":"7","slug":"weather"}]}`
+)
+
+func TestDetectKind(t *testing.T) {
+	assert.Equal(t, resolver.KindConditionID, resolver.DetectKind(conditionID))
+	assert.Equal(t, resolver.KindTokenID, resolver.DetectKind(yesToken))
+	assert.Equal(t, resolver.KindMarketID, resolver.DetectKind("12"))
+	assert.Equal(t, resolver.KindSlug, resolver.DetectKind("will-it-rain"))
+}
+
+func TestResolveAndCache(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		requests[req.URL.Path]++
+		switch req.URL.Path {
+		case "/markets/slug/will-it-rain", "/markets/12":
+			_, _ = w.Write([]byte(marketJSON))
+		case "/markets":
+			if req.URL.Query().Get("clob_token_ids") == noToken || req.URL.Query().Get("condition_ids") == conditionID {
+				_, _ = w.Write([]byte("[" + marketJSON + "]"))
+			} else {
+				_, _ = w.Write([]byte("[]"))
+			}
+		case "/tick-size":
+			_, _ = w.Write([]byte(`{"minimum_tick_size":0.01}`))
+		case "/neg-risk":
+			_, _ = w.Write([]byte(`{"neg_risk":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r := resolver.New(gamma.NewClient(server.URL, big.NewInt(137)), clob.NewClient(server.URL, big.NewInt(137), nil, nil), time.Minute)
+	ctx := context.Background()
+
+	m, err := r.Resolve(ctx, noToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "12", m.MarketID)
+	assert.Equal(t, "Will it rain?", m.Question)
+	assert.Equal(t, "0.01", m.TickSize)
+	assert.True(t, m.NegRisk)
+	assert.Equal(t, "5", m.MinOrderSize.String())
+	assert.Equal(t, 2026, m.EndDate.Year())
+	assert.Equal(t, "weather", *m.Event.Slug)
+	token, ok := m.TokenID("yes")
+	assert.True(t, ok)
+	assert.Equal(t, yesToken, token)
+	outcome, ok := m.Outcome(noToken)
+	assert.True(t, ok)
+	assert.Equal(t, "0.38", outcome.Price.String())
+
+	// served from the cache under every identifier
+	for _, id := range []string{"will-it-rain", "12", conditionID, yesToken} {
+		cached, err := r.Resolve(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, m, cached)
+	}
+	// callers get their own copy
+	m.Outcomes[0].Name = "changed"
+	cached, err := r.Resolve(ctx, yesToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "Yes", cached.Outcomes[0].Name)
+	assert.Equal(t, 1, requests["/markets"])
+	assert.Zero(t, requests["/markets/12"])
+
+	r.Invalidate()
+	_, err = r.ResolveByMarketID(ctx, "12")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests["/markets/12"])
+
+	_, err = r.ResolveByTokenID(ctx, "1234567890123456789012345")
+	assert.ErrorContains(t, err, "market not found")
+}
+
+func TestResolveConcurrent(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		requests[req.URL.Path]++
+		mu.Unlock()
+		switch req.URL.Path {
+		case "/markets/slug/will-it-rain":
+			<-release
+			_, _ = w.Write([]byte(marketJSON))
+		case "/markets/12":
+			_, _ = w.Write([]byte(marketJSON))
+		case "/tick-size":
+			_, _ = w.Write([]byte(`{"minimum_tick_size":0.01}`))
+		case "/neg-risk":
+			_, _ = w.Write([]byte(`{"neg_risk":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r := resolver.New(gamma.NewClient(server.URL, big.NewInt(137)), clob.NewClient(server.URL, big.NewInt(137), nil, nil), time.Minute)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	slugs := make([]*resolver.ResolvedMarket, 3)
+	for i := range slugs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m, err := r.ResolveBySlug(ctx, "will-it-rain")
+			assert.NoError(t, err)
+			slugs[i] = m
+		}(i)
+	}
+
+	// the slug lookup is stuck upstream, another identifier still resolves
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests["/markets/slug/will-it-rain"] == 1
+	}, time.Second, time.Millisecond)
+	m, err := r.ResolveByMarketID(ctx, "12")
+	assert.NoError(t, err)
+	assert.Equal(t, "12", m.MarketID)
+
+	close(release)
+	wg.Wait()
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, requests["/markets/slug/will-it-rain"])
+	assert.Equal(t, slugs[0], slugs[1])
+	assert.Equal(t, slugs[0], slugs[2])
+	assert.NotSame(t, slugs[0], slugs[1])
+}
+
+func TestResolveCanceledCaller(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/markets/slug/will-it-rain":
+			<-release
+			_, _ = w.Write([]byte(marketJSON))
+		case "/tick-size":
+			_, _ = w.Write([]byte(`{"minimum_tick_size":0.01}`))
+		case "/neg-risk":
+			_, _ = w.Write([]byte(`{"neg_risk":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r := resolver.New(gamma.NewClient(server.URL, big.NewInt(137)), clob.NewClient(server.URL, big.NewInt(137), nil, nil), time.Minute)
+
+	// the first caller starts the lookup and gives up
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := r.ResolveBySlug(ctx, "will-it-rain")
+		first <- err
+	}()
+	second := make(chan *resolver.ResolvedMarket, 1)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		m, err := r.ResolveBySlug(context.Background(), "will-it-rain")
+		assert.NoError(t, err)
+		second <- m
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+
+	// the other caller still gets the market
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	m := <-second
+	if assert.NotNil(t, m) {
+		assert.Equal(t, "12", m.MarketID)
+	}
+}
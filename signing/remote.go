@@ -0,0 +1,227 @@
+package signing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const (
+	MethodSignTypedDataV4 = "eth_signTypedData_v4"
+	// MethodSignDigest signs a raw 32-byte digest. It is not a standard Ethereum
+	// method; set RemoteSignerOptions.DigestMethod to match the backend. Typed
+	// data signed through SignTypedData never uses it, only digests without
+	// typed data do, like Safe transaction hashes.
+	MethodSignDigest = "eth_signDigest"
+)
+
+type RemoteSignerOptions struct {
+	DigestMethod string            // default MethodSignDigest
+	Headers      map[string]string // e.g. an Authorization header for the vault
+	Timeout      time.Duration     // default 10s
+	HTTPClient   *http.Client
+}
+
+// RemoteSigner delegates signing to a JSON-RPC endpoint (a KMS or vault proxy):
+//
+//	eth_signTypedData_v4 [address, typedData] -> "0x<65 bytes>"
+//	<DigestMethod>       [address, "0x<32 bytes>"] -> "0x<65 bytes>"
+//
+// Returned signatures may use v 0/1 or 27/28, and must recover to the
+// signer's address. Responses other than 2xx are rejected.
+type RemoteSigner struct {
+	endpoint string
+	address  common.Address
+	opts     RemoteSignerOptions
+	client   *http.Client
+	id       atomic.Int64
+}
+
+func NewRemoteSigner(endpoint string, address common.Address, opts RemoteSignerOptions) *RemoteSigner {
+	if opts.DigestMethod == "" {
+		opts.DigestMethod = MethodSignDigest
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: opts.Timeout}
+	}
+	return &RemoteSigner{endpoint: endpoint, address: address, opts: opts, client: client}
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *RemoteSigner) SignDigest(digest []byte) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, fmt.Errorf("remote signer: invalid digest length: %d", len(digest))
+	}
+	sig, err := s.call(s.opts.DigestMethod, s.address.Hex(), hexutil.Encode(digest))
+	if err != nil {
+		return nil, err
+	}
+	return s.check(s.opts.DigestMethod, digest, sig)
+}
+
+func (s *RemoteSigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: TypedDataAndHash failed: %w", err)
+	}
+	sig, err := s.call(MethodSignTypedDataV4, s.address.Hex(), typedData)
+	if err != nil {
+		return nil, err
+	}
+	return s.check(MethodSignTypedDataV4, digest, sig)
+}
+
+// check rejects a signature of digest that does not recover to s.address.
+func (s *RemoteSigner) check(method string, digest, sig []byte) ([]byte, error) {
+	recovered, err := RecoverAddress(digest, sig)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %s: %w", method, err)
+	}
+	if recovered != s.address {
+		return nil, fmt.Errorf("remote signer: %s: signature recovers to %s, not %s", method, recovered.Hex(), s.address.Hex())
+	}
+	return sig, nil
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int64         `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int64           `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+func (s *RemoteSigner) call(method string, params ...interface{}) ([]byte, error) {
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: s.id.Add(1), Method: method, Params: params})
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: marshal request failed: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.opts.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %s failed: %w", method, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("remote signer: %s failed: status %d: %s", method, resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var out rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("remote signer: %s: status %d: decode response failed: %w", method, resp.StatusCode, err)
+	}
+	if out.Error != nil {
+		return nil, fmt.Errorf("remote signer: %s failed: %d %s", method, out.Error.Code, out.Error.Message)
+	}
+	var sigHex string
+	if err := json.Unmarshal(out.Result, &sigHex); err != nil {
+		return nil, fmt.Errorf("remote signer: %s: invalid result: %w", method, err)
+	}
+	sig, err := hexutil.Decode(sigHex)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %s: invalid signature: %w", method, err)
+	}
+	return normalizeV(sig)
+}
+
+// NewRemoteSignerHandler serves the RemoteSigner protocol backed by local
+// signers. It is a stand-in for tests and local development, not a vault.
+func NewRemoteSignerHandler(digestMethod string, signers ...Signer) http.Handler {
+	if digestMethod == "" {
+		digestMethod = MethodSignDigest
+	}
+	byAddress := make(map[common.Address]Signer, len(signers))
+	for _, s := range signers {
+		byAddress[s.Address()] = s
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var req struct {
+			ID     int64             `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		reply := func(result []byte, err error) {
+			resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+			if err != nil {
+				resp.Error = &rpcError{Code: -32000, Message: err.Error()}
+			} else {
+				resp.Result, _ = json.Marshal(hexutil.Encode(result))
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			reply(nil, fmt.Errorf("parse error: %w", err))
+			return
+		}
+		if len(req.Params) != 2 {
+			reply(nil, fmt.Errorf("expected 2 params, got %d", len(req.Params)))
+			return
+		}
+		var address common.Address
+		if err := json.Unmarshal(req.Params[0], &address); err != nil {
+			reply(nil, fmt.Errorf("invalid address: %w", err))
+			return
+		}
+		signer, ok := byAddress[address]
+		if !ok {
+			reply(nil, fmt.Errorf("unknown account %s", address.Hex()))
+			return
+		}
+
+		switch req.Method {
+		case MethodSignTypedDataV4:
+			var typedData apitypes.TypedData
+			if err := json.Unmarshal(req.Params[1], &typedData); err != nil {
+				reply(nil, fmt.Errorf("invalid typed data: %w", err))
+				return
+			}
+			reply(signer.SignTypedData(typedData))
+		case digestMethod:
+			var digest hexutil.Bytes
+			if err := json.Unmarshal(req.Params[1], &digest); err != nil {
+				reply(nil, fmt.Errorf("invalid digest: %w", err))
+				return
+			}
+			reply(signer.SignDigest(digest))
+		default:
+			reply(nil, fmt.Errorf("method %s not supported", req.Method))
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package signing
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// SafeMessageHash returns the EIP-712 SafeMessage hash the owners of a Safe
+// sign so that the Safe's EIP-1271 isValidSignature(bytes32, bytes) accepts
+// hash. The message is abi.encode(hash), as in Safe's CompatibilityFallbackHandler.
+func SafeMessageHash(chainID int64, safeAddress string, hash common.Hash) (common.Hash, error) {
+	messageHash, _, err := apitypes.TypedDataAndHash(safeMessageTypedData(chainID, safeAddress, hash))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("TypedDataAndHash failed: %w", err)
+	}
+	return common.BytesToHash(messageHash), nil
+}
+
+func safeMessageTypedData(chainID int64, safeAddress string, hash common.Hash) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"SafeMessage": []apitypes.Type{
+				{Name: "message", Type: "bytes"},
+			},
+		},
+		PrimaryType: "SafeMessage",
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           math.NewHexOrDecimal256(chainID),
+			VerifyingContract: common.HexToAddress(safeAddress).Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"message": hexutil.Encode(hash.Bytes()),
+		},
+	}
+}
+
+// PackSafeSignatures concatenates owner signatures in the format Safe's
+// checkSignatures expects: sorted by owner address, 65 bytes each. v is
+// normalized to 27/28; the eth_sign variant (31/32) is kept as is.
+func PackSafeSignatures(signatures map[common.Address][]byte) ([]byte, error) {
+	owners := make([]common.Address, 0, len(signatures))
+	for owner := range signatures {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		return bytes.Compare(owners[i].Bytes(), owners[j].Bytes()) < 0
+	})
+
+	packed := make([]byte, 0, 65*len(owners))
+	for _, owner := range owners {
+		sig := signatures[owner]
+		if len(sig) == 65 && (sig[64] == 31 || sig[64] == 32) {
+			packed = append(packed, sig...)
+			continue
+		}
+		normalized, err := normalizeV(sig)
+		if err != nil {
+			return nil, fmt.Errorf("owner %s: %w", owner.Hex(), err)
+		}
+		packed = append(packed, normalized...)
+	}
+	return packed, nil
+}
+
+// BuildSafeMessageSignature collects a signature over the SafeMessage hash of
+// hash from each owner and packs them for the Safe's isValidSignature.
+func BuildSafeMessageSignature(signatureFunc SignatureFunc, chainID int64, safeAddress string, owners []string, hash common.Hash) ([]byte, error) {
+	if len(owners) == 0 {
+		return nil, fmt.Errorf("build safe message signature: no owners")
+	}
+	typedData := safeMessageTypedData(chainID, safeAddress, hash)
+	signatures := make(map[common.Address][]byte, len(owners))
+	for _, owner := range owners {
+		if !common.IsHexAddress(owner) {
+			return nil, fmt.Errorf("build safe message signature: invalid owner address: %s", owner)
+		}
+		addr := common.HexToAddress(owner)
+		if _, ok := signatures[addr]; ok {
+			return nil, fmt.Errorf("build safe message signature: duplicate owner %s", addr.Hex())
+		}
+		sig, err := SignTypedData(signatureFunc, addr.Hex(), typedData)
+		if err != nil {
+			return nil, fmt.Errorf("signature failed: %w", err)
+		}
+		signatures[addr] = sig
+	}
+	return PackSafeSignatures(signatures)
+}
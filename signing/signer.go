@@ -0,0 +1,179 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Signer signs on behalf of a single address. Signatures are 65 bytes
+// [R || S || V] with V in {27, 28}.
+type Signer interface {
+	Address() common.Address
+	SignDigest(digest []byte) ([]byte, error)
+	SignTypedData(typedData apitypes.TypedData) ([]byte, error)
+}
+
+// PrivateKeySigner signs with an in-memory ECDSA key.
+type PrivateKeySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+func NewPrivateKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// NewPrivateKeySignerFromHex parses a hex private key, with or without 0x prefix.
+func NewPrivateKeySignerFromHex(hexKey string) (*PrivateKeySigner, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return NewPrivateKeySigner(key), nil
+}
+
+// NewKeystoreSigner decrypts a go-ethereum keystore (V3 JSON) file.
+func NewKeystoreSigner(path string, passphrase string) (*PrivateKeySigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read keystore failed: %w", err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore failed: %w", err)
+	}
+	return NewPrivateKeySigner(key.PrivateKey), nil
+}
+
+func (s *PrivateKeySigner) Address() common.Address {
+	return s.address
+}
+
+func (s *PrivateKeySigner) SignDigest(digest []byte) ([]byte, error) {
+	sig, err := crypto.Sign(digest, s.key)
+	if err != nil {
+		return nil, fmt.Errorf("sign digest failed: %w", err)
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+func (s *PrivateKeySigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("TypedDataAndHash failed: %w", err)
+	}
+	return s.SignDigest(digest)
+}
+
+// ToSignatureFunc adapts signers to the SignatureFunc taken by the clients.
+// The signer argument selects the Signer by address; v is normalized to 27/28.
+// Digests of typed data signed with SignTypedData are signed with the
+// Signer's SignTypedData, other digests (Safe transactions) with SignDigest.
+func ToSignatureFunc(signers ...Signer) SignatureFunc {
+	byAddress := make(map[common.Address]Signer, len(signers))
+	for _, s := range signers {
+		byAddress[s.Address()] = s
+	}
+	return func(signer string, digest []byte) ([]byte, error) {
+		s, ok := byAddress[common.HexToAddress(signer)]
+		if !ok {
+			return nil, fmt.Errorf("no signer for address %s", signer)
+		}
+		var (
+			sig []byte
+			err error
+		)
+		if typedData, ok := typedDigests.get(digest); ok {
+			sig, err = s.SignTypedData(typedData)
+		} else {
+			sig, err = s.SignDigest(digest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return normalizeV(sig)
+	}
+}
+
+// SignTypedData signs the EIP-712 digest of typedData with signatureFunc. A
+// SignatureFunc from ToSignatureFunc hands the typed data itself to the
+// Signer, e.g. for a RemoteSigner to sign it with eth_signTypedData_v4.
+func SignTypedData(signatureFunc SignatureFunc, signer string, typedData apitypes.TypedData) ([]byte, error) {
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("TypedDataAndHash failed: %w", err)
+	}
+	typedDigests.put(digest, typedData)
+	defer typedDigests.remove(digest)
+	return signatureFunc(signer, digest)
+}
+
+// typedDigests holds the typed data of the digests being signed by
+// SignTypedData, keyed by digest.
+var typedDigests = &typedDigestSet{entries: make(map[string]*typedDigest)}
+
+type typedDigestSet struct {
+	mu      sync.Mutex
+	entries map[string]*typedDigest
+}
+
+type typedDigest struct {
+	typedData apitypes.TypedData
+	refs      int
+}
+
+func (t *typedDigestSet) put(digest []byte, typedData apitypes.TypedData) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, ok := t.entries[string(digest)]
+	if !ok {
+		e = &typedDigest{typedData: typedData}
+		t.entries[string(digest)] = e
+	}
+	e.refs++
+}
+
+func (t *typedDigestSet) remove(digest []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if e, ok := t.entries[string(digest)]; ok {
+		if e.refs--; e.refs == 0 {
+			delete(t.entries, string(digest))
+		}
+	}
+}
+
+func (t *typedDigestSet) get(digest []byte) (apitypes.TypedData, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, ok := t.entries[string(digest)]
+	if !ok {
+		return apitypes.TypedData{}, false
+	}
+	return e.typedData, true
+}
+
+// normalizeV returns a copy of sig with v in {27, 28}.
+func normalizeV(sig []byte) ([]byte, error) {
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+	out := append([]byte(nil), sig...)
+	switch out[64] {
+	case 0, 1:
+		out[64] += 27
+	case 27, 28:
+	default:
+		return nil, fmt.Errorf("invalid signature v value: %d", out[64])
+	}
+	return out, nil
+}
@@ -0,0 +1,179 @@
+package signing_test
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	"github.com/polymarket/go-order-utils/pkg/builder"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKeyHex = "3f26dbcf904a3542e5f54eed3381c740d6246d8bc81cefbfd0679ae4ce8d82c9"
+
+func clobAuthTypedData(address common.Address) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			"ClobAuth": []apitypes.Type{
+				{Name: "address", Type: "address"},
+				{Name: "timestamp", Type: "string"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "message", Type: "string"},
+			},
+		},
+		PrimaryType: "ClobAuth",
+		Domain: apitypes.TypedDataDomain{
+			Name:    signing.ClobAuthDomain,
+			Version: signing.ClobAuthDomainVersion,
+			ChainId: math.NewHexOrDecimal256(137),
+		},
+		Message: apitypes.TypedDataMessage{
+			"address":   address.Hex(),
+			"timestamp": "1700000000",
+			"nonce":     big.NewInt(0),
+			"message":   signing.ClobAuthDomainMsgToSign,
+		},
+	}
+}
+
+func recoverAddress(t *testing.T, digest, sig []byte) common.Address {
+	assert.Len(t, sig, 65)
+	assert.Contains(t, []byte{27, 28}, sig[64])
+	normalized := append([]byte(nil), sig...)
+	normalized[64] -= 27
+	pub, err := crypto.SigToPub(digest, normalized)
+	assert.NoError(t, err)
+	return crypto.PubkeyToAddress(*pub)
+}
+
+func TestPrivateKeyAndKeystoreSigner(t *testing.T) {
+	local, err := signing.NewPrivateKeySignerFromHex("0x" + testKeyHex)
+	assert.NoError(t, err)
+	digest := crypto.Keccak256([]byte("digest"))
+	sig, err := local.SignDigest(digest)
+	assert.NoError(t, err)
+	assert.Equal(t, local.Address(), recoverAddress(t, digest, sig))
+
+	key, _ := crypto.HexToECDSA(testKeyHex)
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(key, "passphrase")
+	assert.NoError(t, err)
+
+	_, err = signing.NewKeystoreSigner(account.URL.Path, "wrong")
+	assert.Error(t, err)
+	fromFile, err := signing.NewKeystoreSigner(account.URL.Path, "passphrase")
+	assert.NoError(t, err)
+	assert.Equal(t, local.Address(), fromFile.Address())
+
+	typed := clobAuthTypedData(local.Address())
+	hash, _, err := apitypes.TypedDataAndHash(typed)
+	assert.NoError(t, err)
+	sig, err = fromFile.SignTypedData(typed)
+	assert.NoError(t, err)
+	assert.Equal(t, local.Address(), recoverAddress(t, hash, sig))
+}
+
+func TestRemoteSigner(t *testing.T) {
+	local, _ := signing.NewPrivateKeySignerFromHex(testKeyHex)
+	server := httptest.NewServer(signing.NewRemoteSignerHandler("", local))
+	defer server.Close()
+
+	remote := signing.NewRemoteSigner(server.URL, local.Address(), signing.RemoteSignerOptions{})
+
+	typed := clobAuthTypedData(local.Address())
+	want, err := local.SignTypedData(typed)
+	assert.NoError(t, err)
+	got, err := remote.SignTypedData(typed)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	digest := crypto.Keccak256([]byte("digest"))
+	signFn := signing.ToSignatureFunc(remote)
+	sig, err := signFn(local.Address().Hex(), digest)
+	assert.NoError(t, err)
+	assert.Equal(t, local.Address(), recoverAddress(t, digest, sig))
+
+	_, err = signFn("0x0000000000000000000000000000000000000001", digest)
+	assert.Error(t, err)
+
+	stranger := signing.NewRemoteSigner(server.URL, common.HexToAddress("0x01"), signing.RemoteSignerOptions{})
+	_, err = stranger.SignDigest(digest)
+	assert.ErrorContains(t, err, "unknown account")
+}
+
+func TestRemoteSignerTypedData(t *testing.T) {
+	local, _ := signing.NewPrivateKeySignerFromHex(testKeyHex)
+	// The backend only speaks eth_signTypedData_v4.
+	server := httptest.NewServer(signing.NewRemoteSignerHandler("unsupported", local))
+	defer server.Close()
+	signFn := signing.ToSignatureFunc(signing.NewRemoteSigner(server.URL, local.Address(), signing.RemoteSignerOptions{}))
+
+	sigHex, err := signing.BuildClobEip712Signature(signFn, big.NewInt(137), local.Address().Hex(), "1700000000", big.NewInt(0))
+	assert.NoError(t, err)
+	hash, _, _ := apitypes.TypedDataAndHash(clobAuthTypedData(local.Address()))
+	assert.Equal(t, local.Address(), recoverAddress(t, hash, common.FromHex(sigHex)))
+
+	order := model.Order{
+		Salt:          big.NewInt(1),
+		Maker:         local.Address(),
+		Signer:        local.Address(),
+		TokenId:       big.NewInt(1234),
+		MakerAmount:   big.NewInt(50_000_000),
+		TakerAmount:   big.NewInt(100_000_000),
+		Side:          big.NewInt(int64(model.BUY)),
+		Expiration:    big.NewInt(0),
+		Nonce:         big.NewInt(0),
+		FeeRateBps:    big.NewInt(0),
+		SignatureType: big.NewInt(int64(model.EOA)),
+	}
+	for _, contract := range []model.VerifyingContract{model.CTFExchange, model.NegRiskCTFExchange} {
+		orderHash, err := builder.NewExchangeOrderBuilderImpl(polygon, nil).BuildOrderHash(&order, contract)
+		assert.NoError(t, err)
+		typedData, err := signing.OrderTypedData(&order, polygon, contract)
+		assert.NoError(t, err)
+		sig, err := signing.SignTypedData(signFn, local.Address().Hex(), typedData)
+		assert.NoError(t, err)
+		assert.Equal(t, local.Address(), recoverAddress(t, orderHash.Bytes(), sig))
+	}
+
+	// Digests without typed data still need the digest method.
+	_, err = signFn(local.Address().Hex(), crypto.Keccak256([]byte("digest")))
+	assert.ErrorContains(t, err, "not supported")
+}
+
+func TestRemoteSignerRejects(t *testing.T) {
+	local, _ := signing.NewPrivateKeySignerFromHex(testKeyHex)
+	other, _ := crypto.GenerateKey()
+	digest := crypto.Keccak256([]byte("digest"))
+	otherSig, _ := signing.NewPrivateKeySigner(other).SignDigest(digest)
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":"%s"}`, hexutil.Encode(otherSig))
+	}))
+	defer server.Close()
+	remote := signing.NewRemoteSigner(server.URL, local.Address(), signing.RemoteSignerOptions{})
+
+	_, err := remote.SignDigest(digest)
+	assert.ErrorContains(t, err, "signature recovers to "+crypto.PubkeyToAddress(other.PublicKey).Hex())
+
+	status = http.StatusBadGateway
+	_, err = remote.SignDigest(digest)
+	assert.ErrorContains(t, err, "status 502")
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	"github.com/polymarket/go-order-utils/pkg/config"
+	"github.com/polymarket/go-order-utils/pkg/model"
 	"math/big"
 	"strings"
 )
@@ -24,7 +26,16 @@ const (
 )
 
 func BuildClobEip712Signature(signatureFunc SignatureFunc, chainId *big.Int, singer, ts string, nonce *big.Int) (sigHex string, err error) {
-	typedData := apitypes.TypedData{
+	sigBytes, err := SignTypedData(signatureFunc, singer, clobAuthTypedData(chainId, singer, ts, nonce))
+	if err != nil {
+		return "", fmt.Errorf("signature failed: %w", err)
+	}
+
+	return "0x" + hex.EncodeToString(sigBytes), nil
+}
+
+func clobAuthTypedData(chainId *big.Int, singer, ts string, nonce *big.Int) apitypes.TypedData {
+	return apitypes.TypedData{
 		Types: apitypes.Types{
 			"EIP712Domain": []apitypes.Type{
 				{Name: "name", Type: "string"},
@@ -51,13 +62,10 @@ func BuildClobEip712Signature(signatureFunc SignatureFunc, chainId *big.Int, sin
 			"message":   ClobAuthDomainMsgToSign,
 		},
 	}
+}
 
-	hash, _, err := apitypes.TypedDataAndHash(typedData)
-	if err != nil {
-		return "", fmt.Errorf("TypedDataAndHash failed: %w", err)
-	}
-
-	sigBytes, err := signatureFunc(singer, hash)
+func BuildSafeCreateTransactionEip712Signature(signatureFunc SignatureFunc, chainId *big.Int, singer, safeCfg, paymentToken, payment, paymentReceiver string) (string, error) {
+	sigBytes, err := SignTypedData(signatureFunc, singer, safeCreateTypedData(chainId, safeCfg, paymentToken, payment, paymentReceiver))
 	if err != nil {
 		return "", fmt.Errorf("signature failed: %w", err)
 	}
@@ -65,8 +73,8 @@ func BuildClobEip712Signature(signatureFunc SignatureFunc, chainId *big.Int, sin
 	return "0x" + hex.EncodeToString(sigBytes), nil
 }
 
-func BuildSafeCreateTransactionEip712Signature(signatureFunc SignatureFunc, chainId *big.Int, singer, safeCfg, paymentToken, payment, paymentReceiver string) (string, error) {
-	typedData := apitypes.TypedData{
+func safeCreateTypedData(chainId *big.Int, safeCfg, paymentToken, payment, paymentReceiver string) apitypes.TypedData {
+	return apitypes.TypedData{
 		Types: apitypes.Types{
 			"EIP712Domain": []apitypes.Type{
 				{Name: "name", Type: "string"},
@@ -91,18 +99,64 @@ func BuildSafeCreateTransactionEip712Signature(signatureFunc SignatureFunc, chai
 			"paymentReceiver": paymentReceiver,
 		},
 	}
+}
 
-	hash, _, err := apitypes.TypedDataAndHash(typedData)
+// OrderTypedData returns the EIP-712 typed data of order for the exchange
+// contract on chainId, whose digest is the order hash of go-order-utils.
+func OrderTypedData(order *model.Order, chainId *big.Int, contract model.VerifyingContract) (apitypes.TypedData, error) {
+	contracts, err := config.GetContracts(chainId.Int64())
 	if err != nil {
-		return "", fmt.Errorf("build safe createTransaction TypedDataAndHash failed: %w", err)
+		return apitypes.TypedData{}, err
 	}
-
-	sigBytes, err := signatureFunc(singer, hash)
-	if err != nil {
-		return "", fmt.Errorf("signature failed: %w", err)
+	exchange := contracts.Exchange
+	if contract == model.NegRiskCTFExchange {
+		exchange = contracts.NegRiskExchange
 	}
-
-	return "0x" + hex.EncodeToString(sigBytes), nil
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Order": []apitypes.Type{
+				{Name: "salt", Type: "uint256"},
+				{Name: "maker", Type: "address"},
+				{Name: "signer", Type: "address"},
+				{Name: "taker", Type: "address"},
+				{Name: "tokenId", Type: "uint256"},
+				{Name: "makerAmount", Type: "uint256"},
+				{Name: "takerAmount", Type: "uint256"},
+				{Name: "expiration", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "feeRateBps", Type: "uint256"},
+				{Name: "side", Type: "uint8"},
+				{Name: "signatureType", Type: "uint8"},
+			},
+		},
+		PrimaryType: "Order",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "Polymarket CTF Exchange",
+			Version:           "1",
+			ChainId:           math.NewHexOrDecimal256(chainId.Int64()),
+			VerifyingContract: exchange.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"salt":          order.Salt,
+			"maker":         order.Maker.Hex(),
+			"signer":        order.Signer.Hex(),
+			"taker":         order.Taker.Hex(),
+			"tokenId":       order.TokenId,
+			"makerAmount":   order.MakerAmount,
+			"takerAmount":   order.TakerAmount,
+			"expiration":    order.Expiration,
+			"nonce":         order.Nonce,
+			"feeRateBps":    order.FeeRateBps,
+			"side":          order.Side,
+			"signatureType": order.SignatureType,
+		},
+	}, nil
 }
 
 func BuildSafeCreateSafeSignature(
@@ -121,6 +175,33 @@ func BuildSafeCreateSafeSignature(
 	refundReceiver string,
 	nonce string,
 ) ([]byte, error) {
+	safeTxHash, err := SafeTxHash(chainID, safeAddress, to, value, data, operation, safeTxGas, baseGas, gasPrice, gasToken, refundReceiver, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	sigBytes, err := signatureFunc(from, SafeTxSigningDigest(safeTxHash).Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("signature failed: %w", err)
+	}
+	return sigBytes, nil
+}
+
+// SafeTxHash returns the EIP-712 hash of a Safe transaction (SafeTx) for the given Safe.
+func SafeTxHash(
+	chainID int64,
+	safeAddress string,
+	to string,
+	value string,
+	data string,
+	operation types.OperationType,
+	safeTxGas string,
+	baseGas string,
+	gasPrice string,
+	gasToken string,
+	refundReceiver string,
+	nonce string,
+) (common.Hash, error) {
 	typedData := apitypes.TypedData{
 		Types: apitypes.Types{
 			"EIP712Domain": []apitypes.Type{
@@ -161,16 +242,15 @@ func BuildSafeCreateSafeSignature(
 
 	structHash, _, err := apitypes.TypedDataAndHash(typedData)
 	if err != nil {
-		return nil, fmt.Errorf("TypedDataAndHash failed: %w", err)
+		return common.Hash{}, fmt.Errorf("TypedDataAndHash failed: %w", err)
 	}
+	return common.BytesToHash(structHash), nil
+}
 
-	prefixedHash := crypto.Keccak256Hash([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(structHash), structHash)))
-
-	sigBytes, err := signatureFunc(from, prefixedHash.Bytes())
-	if err != nil {
-		return nil, fmt.Errorf("signature failed: %w", err)
-	}
-	return sigBytes, nil
+// SafeTxSigningDigest is the eth_sign digest owners sign for a Safe transaction;
+// the relayer submits these signatures with v+4 (see types.SplitAndPackSignature).
+func SafeTxSigningDigest(safeTxHash common.Hash) common.Hash {
+	return crypto.Keccak256Hash([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(safeTxHash), safeTxHash.Bytes())))
 }
 
 func BuildPolyHmacSignature(secret string, timestamp string, method string, requestPath string, body *string) (string, error) {
@@ -0,0 +1,362 @@
+package signing
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	"github.com/polymarket/go-order-utils/pkg/builder"
+	"github.com/polymarket/go-order-utils/pkg/model"
+)
+
+// VerificationError reports a signature that does not recover to the expected
+// signer. Field names the field or domain member that explains the mismatch
+// when one of the usual suspects (chain, exchange, nonce, v encoding...) does;
+// it is empty when nothing matched and the key or an unguessable field differs.
+type VerificationError struct {
+	Subject   string
+	Field     string
+	Expected  common.Address
+	Recovered common.Address
+	Detail    string
+}
+
+func (e *VerificationError) Error() string {
+	msg := fmt.Sprintf("%s: signature recovers to %s, expected %s", e.Subject, e.Recovered.Hex(), e.Expected.Hex())
+	if e.Field != "" {
+		msg += fmt.Sprintf(": mismatched %s", e.Field)
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+// NormalizeSignature returns a copy of sig with v in {27, 28}. ethSign reports
+// the Safe eth_sign variant (v 31/32, see types.SplitAndPackSignature), whose
+// digest is the "\x19Ethereum Signed Message" hash of the signed data.
+func NormalizeSignature(sig []byte) (normalized []byte, ethSign bool, err error) {
+	if len(sig) != 65 {
+		return nil, false, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+	out := append([]byte(nil), sig...)
+	switch v := out[64]; v {
+	case 0, 1:
+		out[64] = v + 27
+	case 27, 28:
+	case 31, 32:
+		out[64] = v - 4
+		ethSign = true
+	default:
+		return nil, false, fmt.Errorf("invalid signature v value: %d", v)
+	}
+	return out, ethSign, nil
+}
+
+// RecoverAddress recovers the signer of digest from a 65-byte signature with v
+// in 0/1 or 27/28.
+func RecoverAddress(digest []byte, sig []byte) (common.Address, error) {
+	normalized, _, err := NormalizeSignature(sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	normalized[64] -= 27
+	pub, err := crypto.SigToPub(digest, normalized)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("recover public key failed: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// ethSignDigest is the personal_sign/eth_sign digest of a 32-byte hash.
+func ethSignDigest(hash []byte) []byte {
+	return SafeTxSigningDigest(common.BytesToHash(hash)).Bytes()
+}
+
+// candidate is an alternative digest tried when the signature does not match,
+// labelled with the field that would explain the mismatch.
+type candidate struct {
+	field  string
+	detail string
+	digest func() ([]byte, error)
+}
+
+func verify(subject string, expected common.Address, digest []byte, sig []byte, candidates []candidate) (common.Address, error) {
+	recovered, err := RecoverAddress(digest, sig)
+	if err != nil {
+		return common.Address{}, &VerificationError{Subject: subject, Field: "signature", Expected: expected, Detail: err.Error()}
+	}
+	if recovered == expected {
+		return recovered, nil
+	}
+
+	verr := &VerificationError{Subject: subject, Expected: expected, Recovered: recovered}
+	for _, c := range candidates {
+		alt, err := c.digest()
+		if err != nil {
+			continue
+		}
+		if addr, err := RecoverAddress(alt, sig); err == nil && addr == expected {
+			verr.Field, verr.Detail = c.field, c.detail
+			return recovered, verr
+		}
+	}
+	return recovered, verr
+}
+
+func otherChainIds(chainId *big.Int) []*big.Int {
+	var out []*big.Int
+	for _, id := range []int64{137, 80002} {
+		if id != chainId.Int64() {
+			out = append(out, big.NewInt(id))
+		}
+	}
+	return out
+}
+
+// contractConfig is types.GetContractConfig without the panic on unknown chains.
+func contractConfig(chainId *big.Int) (cfg *types.ContractConfig, err error) {
+	defer func() {
+		if recover() != nil {
+			err = fmt.Errorf("no relayer contract config for chain %s", chainId)
+		}
+	}()
+	return types.GetContractConfig(chainId), nil
+}
+
+func typedDataHash(td apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(td)
+	return hash, err
+}
+
+// VerifyOrder checks that a signed order recovers to order.Signer under the
+// exchange domain for chainId and contract.
+func VerifyOrder(order *model.SignedOrder, chainId *big.Int, contract model.VerifyingContract) (common.Address, error) {
+	const subject = "order"
+	if order.SignatureType != nil && order.SignatureType.Int64() == 3 {
+		return common.Address{}, fmt.Errorf("verify order: signatureType 3 (EIP-1271) is checked by the signer contract's isValidSignature, not by ecrecover")
+	}
+	if order.SignatureType != nil && order.SignatureType.Int64() == int64(model.EOA) && order.Maker != order.Signer {
+		return common.Address{}, &VerificationError{Subject: subject, Field: "maker", Expected: order.Signer, Recovered: order.Maker,
+			Detail: "EOA orders must have maker == signer"}
+	}
+
+	orderHash := func(o model.Order, id *big.Int, c model.VerifyingContract) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			hash, err := builder.NewExchangeOrderBuilderImpl(id, nil).BuildOrderHash(&o, c)
+			return hash.Bytes(), err
+		}
+	}
+	digest, err := orderHash(order.Order, chainId, contract)()
+	if err != nil {
+		return common.Address{}, fmt.Errorf("verify order: build order hash failed: %w", err)
+	}
+
+	otherContract := model.NegRiskCTFExchange
+	if contract == model.NegRiskCTFExchange {
+		otherContract = model.CTFExchange
+	}
+	candidates := []candidate{
+		{field: "domain.verifyingContract", detail: "signed for the other exchange (neg risk flag)", digest: orderHash(order.Order, chainId, otherContract)},
+		{field: "digest", detail: "signed with the eth_sign prefix; orders need a raw EIP-712 signature", digest: func() ([]byte, error) {
+			return ethSignDigest(digest), nil
+		}},
+	}
+	for _, id := range otherChainIds(chainId) {
+		candidates = append(candidates, candidate{field: "domain.chainId", detail: fmt.Sprintf("signed for chain %s", id), digest: orderHash(order.Order, id, contract)})
+	}
+	for _, st := range []int{model.EOA, model.POLY_PROXY, model.POLY_GNOSIS_SAFE} {
+		if order.SignatureType != nil && int64(st) == order.SignatureType.Int64() {
+			continue
+		}
+		alt := order.Order
+		alt.SignatureType = big.NewInt(int64(st))
+		candidates = append(candidates, candidate{field: "signatureType", detail: fmt.Sprintf("signed with signatureType %d", st), digest: orderHash(alt, chainId, contract)})
+	}
+	if order.Side != nil {
+		alt := order.Order
+		alt.Side = new(big.Int).Xor(order.Side, big.NewInt(1))
+		candidates = append(candidates, candidate{field: "side", detail: fmt.Sprintf("signed with side %s", alt.Side), digest: orderHash(alt, chainId, contract)})
+	}
+	if order.Taker != (common.Address{}) {
+		alt := order.Order
+		alt.Taker = common.Address{}
+		candidates = append(candidates, candidate{field: "taker", detail: "signed as a public order", digest: orderHash(alt, chainId, contract)})
+	}
+	return verify(subject, order.Signer, digest, order.Signature, candidates)
+}
+
+// VerifyL1Headers checks the POLY_SIGNATURE of L1 auth headers (see
+// headers.CreateL1Headers) against POLY_ADDRESS.
+func VerifyL1Headers(headers map[string]string, chainId *big.Int) (common.Address, error) {
+	const subject = "l1 headers"
+	address, ts, nonceStr, sigHex := headers["POLY_ADDRESS"], headers["POLY_TIMESTAMP"], headers["POLY_NONCE"], headers["POLY_SIGNATURE"]
+	if !common.IsHexAddress(address) {
+		return common.Address{}, &VerificationError{Subject: subject, Field: "POLY_ADDRESS", Detail: fmt.Sprintf("invalid address %q", address)}
+	}
+	expected := common.HexToAddress(address)
+	nonce, ok := new(big.Int).SetString(nonceStr, 10)
+	if !ok {
+		return common.Address{}, &VerificationError{Subject: subject, Field: "POLY_NONCE", Expected: expected, Detail: fmt.Sprintf("invalid nonce %q", nonceStr)}
+	}
+	sig, err := hexutil.Decode(sigHex)
+	if err != nil {
+		return common.Address{}, &VerificationError{Subject: subject, Field: "POLY_SIGNATURE", Expected: expected, Detail: err.Error()}
+	}
+
+	digest, err := typedDataHash(clobAuthTypedData(chainId, address, ts, nonce))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("verify l1 headers: %w", err)
+	}
+	candidates := []candidate{
+		{field: "digest", detail: "signed with the eth_sign prefix; L1 auth needs a raw EIP-712 signature", digest: func() ([]byte, error) {
+			return ethSignDigest(digest), nil
+		}},
+	}
+	for _, id := range otherChainIds(chainId) {
+		id := id
+		candidates = append(candidates, candidate{field: "domain.chainId", detail: fmt.Sprintf("signed for chain %s", id), digest: func() ([]byte, error) {
+			return typedDataHash(clobAuthTypedData(id, address, ts, nonce))
+		}})
+	}
+	if nonce.Sign() != 0 {
+		candidates = append(candidates, candidate{field: "POLY_NONCE", detail: "signed with nonce 0", digest: func() ([]byte, error) {
+			return typedDataHash(clobAuthTypedData(chainId, address, ts, big.NewInt(0)))
+		}})
+	}
+	return verify(subject, expected, digest, sig, candidates)
+}
+
+// VerifySafeCreateRequest checks a SAFE-CREATE relayer request against req.From.
+func VerifySafeCreateRequest(req *types.TransactionRequest, chainId *big.Int) (common.Address, error) {
+	const subject = "safe-create request"
+	if req.Type != string(types.TransactionTypeSAFECreate) {
+		return common.Address{}, fmt.Errorf("verify %s: unexpected type %q", subject, req.Type)
+	}
+	expected := common.HexToAddress(req.From)
+	params := req.SignatureParams
+	if params.PaymentToken == nil || params.Payment == nil || params.PaymentReceiver == nil {
+		return common.Address{}, &VerificationError{Subject: subject, Field: "signatureParams", Expected: expected, Detail: "missing payment params"}
+	}
+	cfg, err := contractConfig(chainId)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("verify %s: %w", subject, err)
+	}
+	if factory := cfg.SafeFactory; !strings.EqualFold(req.To, factory) {
+		return common.Address{}, &VerificationError{Subject: subject, Field: "to", Expected: expected, Detail: fmt.Sprintf("to %s is not the safe factory %s", req.To, factory)}
+	}
+	sig, err := hexutil.Decode(req.Signature)
+	if err != nil {
+		return common.Address{}, &VerificationError{Subject: subject, Field: "signature", Expected: expected, Detail: err.Error()}
+	}
+
+	digest, err := typedDataHash(safeCreateTypedData(chainId, req.To, *params.PaymentToken, *params.Payment, *params.PaymentReceiver))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("verify %s: %w", subject, err)
+	}
+	candidates := []candidate{
+		{field: "digest", detail: "signed with the eth_sign prefix; SAFE-CREATE needs a raw EIP-712 signature", digest: func() ([]byte, error) {
+			return ethSignDigest(digest), nil
+		}},
+	}
+	for _, id := range otherChainIds(chainId) {
+		id := id
+		candidates = append(candidates, candidate{field: "domain.chainId", detail: fmt.Sprintf("signed for chain %s", id), digest: func() ([]byte, error) {
+			return typedDataHash(safeCreateTypedData(id, req.To, *params.PaymentToken, *params.Payment, *params.PaymentReceiver))
+		}})
+	}
+	return verify(subject, expected, digest, sig, candidates)
+}
+
+// VerifySafeRequest checks a SAFE relayer request (see relayer.Client.BuildTx)
+// against req.From. The packed signature may use v 27/28 (raw SafeTx hash) or
+// 31/32 (eth_sign of the SafeTx hash).
+func VerifySafeRequest(req *types.TransactionRequest, chainId *big.Int) (common.Address, error) {
+	const subject = "safe request"
+	if req.Type != string(types.TransactionTypeSAFE) {
+		return common.Address{}, fmt.Errorf("verify %s: unexpected type %q", subject, req.Type)
+	}
+	expected := common.HexToAddress(req.From)
+	params := req.SignatureParams
+	if req.ProxyWallet == nil || req.Nonce == nil || params.Operation == nil || params.SafeTxnGas == nil || params.BaseGas == nil ||
+		params.GasPrice == nil || params.GasToken == nil || params.RefundReceiver == nil {
+		return common.Address{}, &VerificationError{Subject: subject, Field: "signatureParams", Expected: expected, Detail: "missing proxyWallet, nonce or signature params"}
+	}
+	cfg, err := contractConfig(chainId)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("verify %s: %w", subject, err)
+	}
+	safe, err := types.DeriveSafeAddress(req.From, cfg.SafeFactory)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("verify %s: %w", subject, err)
+	}
+	if !strings.EqualFold(safe, *req.ProxyWallet) {
+		return common.Address{}, &VerificationError{Subject: subject, Field: "proxyWallet", Expected: expected,
+			Detail: fmt.Sprintf("proxyWallet %s is not the safe %s of %s", *req.ProxyWallet, safe, req.From)}
+	}
+	operation, ok := map[string]types.OperationType{"0": types.OperationCall, "1": types.OperationDelegateCall}[*params.Operation]
+	if !ok {
+		return common.Address{}, &VerificationError{Subject: subject, Field: "operation", Expected: expected, Detail: fmt.Sprintf("invalid operation %q", *params.Operation)}
+	}
+	sig, err := hexutil.Decode(req.Signature)
+	if err != nil {
+		return common.Address{}, &VerificationError{Subject: subject, Field: "signature", Expected: expected, Detail: err.Error()}
+	}
+	normalized, ethSign, err := NormalizeSignature(sig)
+	if err != nil {
+		return common.Address{}, &VerificationError{Subject: subject, Field: "signature", Expected: expected, Detail: err.Error()}
+	}
+
+	safeTxHash := func(id *big.Int, op types.OperationType, nonce string) ([]byte, error) {
+		hash, err := SafeTxHash(id.Int64(), *req.ProxyWallet, req.To, "0", req.Data, op,
+			*params.SafeTxnGas, *params.BaseGas, *params.GasPrice, *params.GasToken, *params.RefundReceiver, nonce)
+		return hash.Bytes(), err
+	}
+	// digestFor applies the encoding announced by v; ethSign flips it to test
+	// a signature whose v does not match the way it was produced.
+	digestFor := func(hash []byte, prefixed bool) []byte {
+		if prefixed {
+			return ethSignDigest(hash)
+		}
+		return hash
+	}
+
+	hash, err := safeTxHash(chainId, operation, *req.Nonce)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("verify %s: %w", subject, err)
+	}
+	candidates := []candidate{
+		{field: "v", detail: "v does not match the signing method (27/28 for a raw SafeTx hash, 31/32 for eth_sign)", digest: func() ([]byte, error) {
+			return digestFor(hash, !ethSign), nil
+		}},
+		{field: "operation", detail: "signed with the other operation", digest: func() ([]byte, error) {
+			h, err := safeTxHash(chainId, 1-operation, *req.Nonce)
+			return digestFor(h, ethSign), err
+		}},
+	}
+	for _, id := range otherChainIds(chainId) {
+		id := id
+		candidates = append(candidates, candidate{field: "domain.chainId", detail: fmt.Sprintf("signed for chain %s", id), digest: func() ([]byte, error) {
+			h, err := safeTxHash(id, operation, *req.Nonce)
+			return digestFor(h, ethSign), err
+		}})
+	}
+	if nonce, ok := new(big.Int).SetString(*req.Nonce, 10); ok {
+		for _, delta := range []int64{-1, 1} {
+			alt := new(big.Int).Add(nonce, big.NewInt(delta))
+			if alt.Sign() < 0 {
+				continue
+			}
+			candidates = append(candidates, candidate{field: "nonce", detail: fmt.Sprintf("signed with nonce %s", alt), digest: func() ([]byte, error) {
+				h, err := safeTxHash(chainId, operation, alt.String())
+				return digestFor(h, ethSign), err
+			}})
+		}
+	}
+	return verify(subject, expected, digestFor(hash, ethSign), normalized, candidates)
+}
@@ -0,0 +1,157 @@
+package signing_test
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/override-coder/go-polymarket-sdk/headers"
+	"github.com/override-coder/go-polymarket-sdk/relayer"
+	"github.com/override-coder/go-polymarket-sdk/relayer/types"
+	"github.com/override-coder/go-polymarket-sdk/signing"
+	sdktypes "github.com/override-coder/go-polymarket-sdk/types"
+	"github.com/polymarket/go-order-utils/pkg/builder"
+	"github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var polygon = big.NewInt(137)
+
+func assertMismatch(t *testing.T, err error, field string) {
+	var verr *signing.VerificationError
+	if assert.True(t, errors.As(err, &verr), "%v", err) {
+		assert.Equal(t, field, verr.Field)
+	}
+}
+
+func TestNormalizeSignature(t *testing.T) {
+	sig := make([]byte, 65)
+	for v, want := range map[byte]byte{0: 27, 1: 28, 27: 27, 28: 28, 31: 27, 32: 28} {
+		sig[64] = v
+		out, ethSign, err := signing.NormalizeSignature(sig)
+		assert.NoError(t, err)
+		assert.Equal(t, want, out[64])
+		assert.Equal(t, v >= 31, ethSign)
+	}
+	sig[64] = 29
+	_, _, err := signing.NormalizeSignature(sig)
+	assert.Error(t, err)
+	_, _, err = signing.NormalizeSignature(sig[:64])
+	assert.Error(t, err)
+}
+
+func TestVerifyOrder(t *testing.T) {
+	local, _ := signing.NewPrivateKeySignerFromHex(testKeyHex)
+	order := &model.SignedOrder{Order: model.Order{
+		Salt:          big.NewInt(1),
+		Maker:         local.Address(),
+		Signer:        local.Address(),
+		TokenId:       big.NewInt(1234),
+		MakerAmount:   big.NewInt(50_000_000),
+		TakerAmount:   big.NewInt(100_000_000),
+		Side:          big.NewInt(int64(model.BUY)),
+		Expiration:    big.NewInt(0),
+		Nonce:         big.NewInt(0),
+		FeeRateBps:    big.NewInt(0),
+		SignatureType: big.NewInt(int64(model.EOA)),
+	}}
+	hash, err := builder.NewExchangeOrderBuilderImpl(polygon, nil).BuildOrderHash(&order.Order, model.NegRiskCTFExchange)
+	assert.NoError(t, err)
+	order.Signature, err = local.SignDigest(hash.Bytes())
+	assert.NoError(t, err)
+
+	signer, err := signing.VerifyOrder(order, polygon, model.NegRiskCTFExchange)
+	assert.NoError(t, err)
+	assert.Equal(t, local.Address(), signer)
+
+	_, err = signing.VerifyOrder(order, polygon, model.CTFExchange)
+	assertMismatch(t, err, "domain.verifyingContract")
+	_, err = signing.VerifyOrder(order, big.NewInt(80002), model.NegRiskCTFExchange)
+	assertMismatch(t, err, "domain.chainId")
+
+	tampered := *order
+	tampered.Side = big.NewInt(int64(model.SELL))
+	_, err = signing.VerifyOrder(&tampered, polygon, model.NegRiskCTFExchange)
+	assertMismatch(t, err, "side")
+
+	tampered = *order
+	tampered.Maker = common.HexToAddress("0x01")
+	_, err = signing.VerifyOrder(&tampered, polygon, model.NegRiskCTFExchange)
+	assertMismatch(t, err, "maker")
+}
+
+func TestVerifyL1Headers(t *testing.T) {
+	local, _ := signing.NewPrivateKeySignerFromHex(testKeyHex)
+	ts := int64(1700000000)
+	l1, err := headers.CreateL1Headers(local.Address().Hex(), signing.ToSignatureFunc(local), polygon, big.NewInt(3), &ts)
+	assert.NoError(t, err)
+
+	signer, err := signing.VerifyL1Headers(l1, polygon)
+	assert.NoError(t, err)
+	assert.Equal(t, local.Address(), signer)
+
+	l1["POLY_NONCE"] = "0"
+	_, err = signing.VerifyL1Headers(l1, polygon)
+	assert.Error(t, err)
+	l1["POLY_NONCE"] = "3"
+	_, err = signing.VerifyL1Headers(l1, big.NewInt(80002))
+	assertMismatch(t, err, "domain.chainId")
+}
+
+func TestVerifyRelayerRequests(t *testing.T) {
+	local, _ := signing.NewPrivateKeySignerFromHex(testKeyHex)
+	deployed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if deployed {
+			_, _ = w.Write([]byte(`{"deployed":true}`))
+		} else {
+			_, _ = w.Write([]byte(`{"deployed":false}`))
+		}
+	}))
+	defer server.Close()
+	client := relayer.NewClient(server.URL, polygon, signing.ToSignatureFunc(local), nil)
+	option := &sdktypes.AuthOption{SingerAddress: local.Address().Hex()}
+
+	create, err := client.BuildDeployTx(option)
+	assert.NoError(t, err)
+	signer, err := signing.VerifySafeCreateRequest(create, polygon)
+	assert.NoError(t, err)
+	assert.Equal(t, local.Address(), signer)
+	_, err = signing.VerifySafeCreateRequest(create, big.NewInt(80002))
+	assert.Error(t, err)
+
+	deployed = true
+	txn := types.SafeTransaction{To: sdktypes.ZeroAddress, Data: "0x", Value: "0"}
+	req, err := client.BuildTx([]types.SafeTransaction{txn}, big.NewInt(5), "", option)
+	assert.NoError(t, err)
+	sig := hexutil.MustDecode(req.Signature)
+	assert.Contains(t, []byte{31, 32}, sig[64])
+
+	signer, err = signing.VerifySafeRequest(req, polygon)
+	assert.NoError(t, err)
+	assert.Equal(t, local.Address(), signer)
+
+	tampered := *req
+	nonce := "6"
+	tampered.Nonce = &nonce
+	_, err = signing.VerifySafeRequest(&tampered, polygon)
+	assertMismatch(t, err, "nonce")
+
+	// the same signature announced as a raw SafeTx hash signature
+	tampered = *req
+	sig[64] -= 4
+	tampered.Signature = hexutil.Encode(sig)
+	_, err = signing.VerifySafeRequest(&tampered, polygon)
+	assertMismatch(t, err, "v")
+
+	tampered = *req
+	wallet := sdktypes.ZeroAddress
+	tampered.ProxyWallet = &wallet
+	_, err = signing.VerifySafeRequest(&tampered, polygon)
+	assertMismatch(t, err, "proxyWallet")
+}